@@ -12,7 +12,48 @@ const (
 
 	FlagUpdateClient = "update-client"
 
+	FlagVersion     = "version"
+	FlagAutoUpgrade = "auto-upgrade"
+	FlagKeepOld     = "keep-old"
+	FlagList        = "list"
+	FlagTimeout     = "timeout"
+
+	FlagDryRun = "dry-run"
+	FlagOutput = "output"
+	FlagResume = "resume"
+
+	FlagRestart = "restart"
+
+	FlagMnemonic = "mnemonic"
+	FlagFundFrom = "fund-from"
+	FlagAmount   = "amount"
+	FlagBridgeId = "bridge-id"
+
+	FlagFinalize   = "finalize"
+	FlagFrom       = "from"
+	FlagKeyringDir = "keyring-dir"
+
+	FlagBackupDir = "backup-dir"
+
+	FlagPassphrase = "passphrase"
+
+	FlagInterval = "interval"
+	FlagOnce     = "once"
+	FlagMin      = "min"
+	FlagTopUp    = "top-up"
+	FlagEvents   = "events"
+
 	FlagWithConfig      = "with-config"
+	FlagConfigFormat    = "config-format"
 	FlagKeyFile         = "key-file"
 	FlagGenerateKeyFile = "generate-key-file"
+	FlagEncryptKeyFile  = "encrypt-key-file"
+
+	FlagGenesisAccounts = "genesis-accounts"
+
+	FlagMinGasPrices    = "min-gas-prices"
+	FlagFeeWhitelist    = "fee-whitelist"
+	FlagBridgeExecutors = "bridge-executors"
+	FlagMaxValidators   = "max-validators"
+	FlagHookMaxGas      = "hook-max-gas"
 )
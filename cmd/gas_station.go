@@ -175,9 +175,10 @@ func GasStationCommand() *cobra.Command {
 func gasStationSetupCommand() *cobra.Command {
 	shortDescription := "Setup Gas Station account on Initia and Celestia for funding the OPinit-bots or relayer to send transactions"
 	setupCmd := &cobra.Command{
-		Use:   "setup",
-		Short: shortDescription,
-		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, GasStationHelperText),
+		Use:     "setup",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\n%s", shortDescription, GasStationHelperText),
+		PreRunE: unlockKeyring,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackRunEvent(cmd, args, analytics.SetupGasStationFeature, analytics.NewEmptyEvent())
 			ctx := weavecontext.NewAppContext(models.NewExistingCheckerState())
@@ -17,4 +17,5 @@ var (
 	OPinitBotsHelperText = SubHelperText("docs/opinit_bots.md")
 	RelayerHelperText    = SubHelperText("docs/relayer.md")
 	GasStationHelperText = SubHelperText("docs/gas_station.md")
+	WatchHelperText      = SubHelperText("docs/watch.md")
 )
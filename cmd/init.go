@@ -15,8 +15,9 @@ import (
 
 func InitCommand() *cobra.Command {
 	initCmd := &cobra.Command{
-		Use:   "init",
-		Short: "Initialize Weave CLI, funding gas station and setting up config.",
+		Use:     "init",
+		Short:   "Initialize Weave CLI, funding gas station and setting up config.",
+		PreRunE: unlockKeyring,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackEvent(analytics.RunEvent, analytics.NewEmptyEvent().Add(analytics.CommandEventKey, cmd.CommandPath()))
 			if config.IsFirstTimeSetup() {
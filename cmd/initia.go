@@ -31,6 +31,10 @@ func InitiaCommand() *cobra.Command {
 		initiaStopCommand(),
 		initiaRestartCommand(),
 		initiaLogCommand(),
+		initiaAdoptCommand(),
+		initiaRelocateDataCommand(),
+		initiaDiskCommand(),
+		initiaPruneCommand(),
 	)
 
 	return cmd
@@ -182,3 +186,139 @@ func initiaLogCommand() *cobra.Command {
 
 	return logCmd
 }
+
+func initiaAdoptCommand() *cobra.Command {
+	shortDescription := "Bring an existing, manually installed Initia full node under weave management"
+	adoptCmd := &cobra.Command{
+		Use:   "adopt",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe node version is detected from the running node or its binary, the matching binary is downloaded and a weave-managed service is created for the existing home. Existing systemd units for the node are migrated. Chain data is left untouched.\n\n%s",
+			shortDescription, L1NodeHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initiaHome, err := cmd.Flags().GetString(FlagInitiaHome)
+			if err != nil {
+				return err
+			}
+			version, _ := cmd.Flags().GetString(FlagVersion)
+			autoUpgrade, _ := cmd.Flags().GetBool(FlagAutoUpgrade)
+
+			if err = initia.AdoptExistingNode(initiaHome, version, autoUpgrade); err != nil {
+				return err
+			}
+
+			fmt.Printf("Initia full node at %s is now managed by weave. You can control it with `weave initia start|stop|restart|log`\n", initiaHome)
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	adoptCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "The Initia application home directory")
+	adoptCmd.Flags().String(FlagVersion, "", "The initiad version the node runs. Detected automatically when omitted")
+	adoptCmd.Flags().Bool(FlagAutoUpgrade, false, "Allow cosmovisor to download upgrade binaries automatically")
+
+	return adoptCmd
+}
+
+func initiaRelocateDataCommand() *cobra.Command {
+	shortDescription := "Move the Initia full node data directory to another path"
+	relocateCmd := &cobra.Command{
+		Use:   "relocate-data <new-path>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe service is stopped, data/ is copied and verified, then replaced with a symlink to the new path and the service is started again. The previous data is only removed once the node serves its previous height from the new path, and any failure rolls back to the original data directory.\n\n%s",
+			shortDescription, L1NodeHelperText),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, err := cmd.Flags().GetString(FlagInitiaHome)
+			if err != nil {
+				return err
+			}
+			keepOld, _ := cmd.Flags().GetBool(FlagKeepOld)
+
+			if err = initia.RelocateNodeData(service.UpgradableInitia, home, args[0], keepOld); err != nil {
+				return err
+			}
+
+			fmt.Printf("Moved the Initia full node data directory to %s. You can see the logs with `weave initia log`\n", args[0])
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	relocateCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "The Initia application home directory")
+	relocateCmd.Flags().Bool(FlagKeepOld, false, "Keep the previous data directory instead of deleting it after a successful move")
+
+	return relocateCmd
+}
+
+func initiaDiskCommand() *cobra.Command {
+	shortDescription := "Report disk usage of the Initia full node"
+	diskCmd := &cobra.Command{
+		Use:   "disk",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nShows the size of each database under the data directory, how fast it grows and the free space left. Each run is recorded to calculate the trend.\n\n%s",
+			shortDescription, L1NodeHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initiaHome, err := cmd.Flags().GetString(FlagInitiaHome)
+			if err != nil {
+				return err
+			}
+
+			report, err := initia.GetDiskUsage(initiaHome)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(report.String())
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	diskCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "The Initia application home directory")
+
+	return diskCmd
+}
+
+func initiaPruneCommand() *cobra.Command {
+	shortDescription := "Prune the Initia full node state offline"
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nStops the service, prunes application.db according to the pruning strategy configured in app.toml and starts the service again.\n\n%s",
+			shortDescription, L1NodeHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			initiaHome, err := cmd.Flags().GetString(FlagInitiaHome)
+			if err != nil {
+				return err
+			}
+
+			if err = initia.PruneNode(initiaHome); err != nil {
+				return err
+			}
+
+			fmt.Println("Pruned Initia full node. You can see the logs with `weave initia log`")
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	pruneCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "The Initia application home directory")
+
+	return pruneCmd
+}
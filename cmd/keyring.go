@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
+)
+
+// configureKeyring applies the keyring backend of the weave config to the key commands. The passphrase of the file
+// backend is read from WEAVE_KEYRING_PASSPHRASE, the configured reference, or the terminal, when it is first needed.
+func configureKeyring() error {
+	backend, err := cosmosutils.ParseKeyringBackend(config.GetKeyringBackend())
+	if err != nil {
+		return fmt.Errorf("%v, fix common.keyring_backend in the weave config or run `weave keyring set`", err)
+	}
+	cosmosutils.SetKeyringBackend(backend, func() (string, error) {
+		if passphrase := os.Getenv(cosmosutils.KeyringPassphraseEnv); passphrase != "" {
+			return passphrase, nil
+		}
+		if source := config.GetKeyringPassphraseSource(); source != "" {
+			return io.ResolveSecret(source)
+		}
+		passphrase, err := readPassphrase("Enter the keyring passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if len(passphrase) < crypto.MinPassphraseLength {
+			return "", fmt.Errorf("passphrase must be at least %d characters", crypto.MinPassphraseLength)
+		}
+		return passphrase, nil
+	})
+	return nil
+}
+
+// unlockKeyring is the PreRunE of the commands that handle keys inside an interactive program
+func unlockKeyring(_ *cobra.Command, _ []string) error {
+	return cosmosutils.UnlockKeyring()
+}
+
+// isKeyringCommand reports whether cmd is `weave keyring` or one of its subcommands
+func isKeyringCommand(cmd *cobra.Command) bool {
+	for ; cmd != nil && cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd.Name() == "keyring" && !cmd.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+func KeyringCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keyring",
+		Short: "Configure the keyring backend weave keeps keys in",
+		Long: `Configure the keyring backend weave keeps keys in.
+
+The backend is used by the initiad, minitiad and celestia-appd key and tx commands weave runs:
+  test  keys are stored unencrypted on disk (default)
+  file  keys are stored on disk encrypted with a passphrase
+  os    keys are stored in the credential store of the operating system
+
+The passphrase of the file backend is read from WEAVE_KEYRING_PASSPHRASE, from the env:NAME or file:PATH reference set with --passphrase, or asked on the terminal.
+
+The rollup operator key is moved into the backend once ` + "`minitiad launch`" + ` has written it. opinitd only supports the test backend, so the keys of the OPinit bots stay in the test keyring.`,
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+	}
+
+	cmd.AddCommand(
+		KeyringShowCommand(),
+		KeyringSetCommand(),
+	)
+
+	return cmd
+}
+
+func KeyringShowCommand() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the configured keyring backend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintf(cmd.OutOrStdout(), "Keyring backend: %s\n", config.GetKeyringBackend())
+			if source := config.GetKeyringPassphraseSource(); source != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Passphrase: %s\n", source)
+			}
+			return nil
+		},
+	}
+
+	return showCmd
+}
+
+func KeyringSetCommand() *cobra.Command {
+	setCmd := &cobra.Command{
+		Use:   "set [test|file|os]",
+		Short: "Set the keyring backend",
+		Long: `Set the keyring backend.
+
+The keys already in a keyring are not moved, add them again with their mnemonic, e.g. through ` + "`weave gas-station setup`" + `.
+eg. weave keyring set file --passphrase file:/etc/weave/keyring-passphrase`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend, err := cosmosutils.ParseKeyringBackend(args[0])
+			if err != nil {
+				return err
+			}
+			passphraseSource, _ := cmd.Flags().GetString(FlagPassphrase)
+			if passphraseSource != "" {
+				if backend != cosmosutils.KeyringBackendFile {
+					return fmt.Errorf("--%s is only used by the file backend", FlagPassphrase)
+				}
+				// the passphrase itself is never written to the weave config
+				if !strings.HasPrefix(passphraseSource, io.SecretEnvPrefix) && !strings.HasPrefix(passphraseSource, io.SecretFilePrefix) {
+					return fmt.Errorf("--%s must be an env:NAME or file:PATH reference", FlagPassphrase)
+				}
+				if _, err = io.ResolveSecret(passphraseSource); err != nil {
+					return err
+				}
+			}
+
+			if err = config.SetKeyringBackend(string(backend), passphraseSource); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Keyring backend set to %s\n", backend)
+			if backend != cosmosutils.KeyringBackendTest {
+				fmt.Fprintln(cmd.OutOrStdout(), "The OPinit bot keys stay in the test backend, opinitd only supports it")
+			}
+			return nil
+		},
+	}
+
+	setCmd.Flags().String(FlagPassphrase, "", "Reference to the passphrase of the file backend, as env:NAME or file:PATH")
+	return setCmd
+}
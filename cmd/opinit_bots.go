@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ import (
 	"github.com/initia-labs/weave/common"
 	weavecontext "github.com/initia-labs/weave/context"
 	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
 	"github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/models/opinit_bots"
 	"github.com/initia-labs/weave/service"
@@ -66,6 +68,12 @@ func OPInitBotsCommand() *cobra.Command {
 	cmd.AddCommand(OPInitBotsRestartCommand())
 	cmd.AddCommand(OPInitBotsLogCommand())
 	cmd.AddCommand(OPInitBotsResetCommand())
+	cmd.AddCommand(OPInitBotsStatusCommand())
+	cmd.AddCommand(OPInitBotsConfigCommand())
+	cmd.AddCommand(OPInitBotsRotateKeyCommand())
+	cmd.AddCommand(OPInitBotsWithdrawalCommand())
+	cmd.AddCommand(OPInitBotsDBCommand())
+	cmd.AddCommand(OPInitBotsVersionCommand())
 
 	return cmd
 }
@@ -102,9 +110,10 @@ func RunOPInit(nextModelFunc func(ctx context.Context) (tea.Model, error), homeC
 func OPInitBotsKeysSetupCommand() *cobra.Command {
 	shortDescription := "Setup keys for OPInit bots"
 	setupCmd := &cobra.Command{
-		Use:   "setup-keys",
-		Short: shortDescription,
-		Long:  fmt.Sprintf("%s.\n%s", shortDescription, OPinitBotsHelperText),
+		Use:     "setup-keys",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n%s", shortDescription, OPinitBotsHelperText),
+		PreRunE: unlockKeyring,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackRunEvent(cmd, args, analytics.SetupOPinitKeysFeature, analytics.NewEmptyEvent())
 			minitiaHome, _ := cmd.Flags().GetString(FlagMinitiaHome)
@@ -136,7 +145,8 @@ func OPInitBotsKeysSetupCommand() *cobra.Command {
 	return setupCmd
 }
 
-func generateKeyFile(keyPath string, botName string) (opinit_bots.KeyFile, error) {
+// generateKeyFile generates the bot mnemonics and writes them to keyPath, encrypted when passphrase is set
+func generateKeyFile(keyPath string, botName string, passphrase string) (opinit_bots.KeyFile, error) {
 	keyFile, err := opinit_bots.GenerateMnemonicKeyfile(botName)
 	if err != nil {
 		return keyFile, err
@@ -148,8 +158,15 @@ func generateKeyFile(keyPath string, botName string) (opinit_bots.KeyFile, error
 		return keyFile, fmt.Errorf("error marshaling KeyFile to JSON: %w", err)
 	}
 
-	// Write JSON data to a file
-	err = os.WriteFile(keyPath, data, 0644)
+	if passphrase != "" {
+		data, err = crypto.EncryptWithPassphrase(data, passphrase)
+		if err != nil {
+			return keyFile, fmt.Errorf("error encrypting key file: %w", err)
+		}
+	}
+
+	// Write JSON data to a file only readable by the current user
+	err = io.WriteSecretFile(keyPath, data)
 	if err != nil {
 		return keyFile, fmt.Errorf("error writing to file: %w", err)
 	}
@@ -181,21 +198,28 @@ func validateConfigFlags(args []string, configPath, keyFilePath string, isGenera
 	return nil
 }
 
-func handleWithConfig(cmd *cobra.Command, userHome, opInitHome, configPath, keyFilePath string, args []string, force, isGenerateKeyFile bool) error {
+func handleWithConfig(cmd *cobra.Command, userHome, opInitHome, configPath, configFormat, keyFilePath string, args []string, force, isGenerateKeyFile, encryptKeyFile bool) error {
 	botName := args[0]
 	if botName != "executor" && botName != "challenger" {
 		return fmt.Errorf("bot name '%s' is not recognized. Allowed values are 'executor' or 'challenger'", botName)
 	}
 
-	fileData, err := os.ReadFile(configPath)
-	if err != nil {
+	if _, err := io.DetectConfigFormat(configPath, configFormat); err != nil {
 		return err
 	}
 
 	var keyFile opinit_bots.KeyFile
+	var err error
 	if isGenerateKeyFile {
+		var passphrase string
+		if encryptKeyFile {
+			passphrase, err = readNewPassphrase()
+			if err != nil {
+				return err
+			}
+		}
 		keyPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("%s.%s.keyfile", common.OpinitGeneratedKeyFilename, botName))
-		keyFile, err = generateKeyFile(keyPath, botName)
+		keyFile, err = generateKeyFile(keyPath, botName, passphrase)
 		if err != nil {
 			return err
 		}
@@ -221,19 +245,35 @@ func handleWithConfig(cmd *cobra.Command, userHome, opInitHome, configPath, keyF
 		return fmt.Errorf("please specify bot name")
 	}
 
-	return initializeBotWithConfig(cmd, fileData, keyFile, opInitHome, userHome, botName)
+	return initializeBotWithConfig(cmd, configPath, configFormat, keyFile, opInitHome, userHome, botName)
 }
 
-// readAndUnmarshalKeyFile read and unmarshal the key file into the KeyFile struct
+// readAndUnmarshalKeyFile read and unmarshal the key file into the KeyFile struct. The format follows the file extension,
+// key files encrypted with a passphrase are decrypted after prompting for it, and `env:` and `file:` references are resolved.
 func readAndUnmarshalKeyFile(keyFilePath string) (opinit_bots.KeyFile, error) {
+	var keyFile opinit_bots.KeyFile
 	fileData, err := os.ReadFile(keyFilePath)
 	if err != nil {
-		return opinit_bots.KeyFile{}, err
+		return keyFile, err
 	}
 
-	var keyFile opinit_bots.KeyFile
-	err = json.Unmarshal(fileData, &keyFile)
-	return keyFile, err
+	if crypto.IsEncryptedData(fileData) {
+		passphrase, err := readPassphrase(fmt.Sprintf("Enter the passphrase of %s: ", keyFilePath))
+		if err != nil {
+			return keyFile, err
+		}
+		if fileData, err = crypto.DecryptWithPassphrase(fileData, passphrase); err != nil {
+			return keyFile, err
+		}
+		err = io.UnmarshalConfig(fileData, io.ConfigFormatJSON, &keyFile)
+	} else {
+		err = io.ReadConfigFile(keyFilePath, "", &keyFile)
+	}
+	if err != nil {
+		return keyFile, err
+	}
+
+	return keyFile, keyFile.ResolveSecrets()
 }
 
 // handleExistingOpInitHome handle the case where the opInitHome directory exists
@@ -256,20 +296,20 @@ func handleExistingOpInitHome(opInitHome string, botName string, force bool) err
 }
 
 // initializeBotWithConfig initialize a bot based on the provided config
-func initializeBotWithConfig(cmd *cobra.Command, fileData []byte, keyFile opinit_bots.KeyFile, opInitHome, userHome, botName string) error {
+func initializeBotWithConfig(cmd *cobra.Command, configPath, configFormat string, keyFile opinit_bots.KeyFile, opInitHome, userHome, botName string) error {
 	var err error
 
 	switch botName {
 	case "executor":
 		var config opinit_bots.ExecutorConfig
-		err = json.Unmarshal(fileData, &config)
+		err = io.ReadConfigFile(configPath, configFormat, &config)
 		if err != nil {
 			return err
 		}
 		err = opinit_bots.InitializeExecutorWithConfig(config, &keyFile, opInitHome, userHome)
 	case "challenger":
 		var config opinit_bots.ChallengerConfig
-		err = json.Unmarshal(fileData, &config)
+		err = io.ReadConfigFile(configPath, configFormat, &config)
 		if err != nil {
 			return err
 		}
@@ -288,10 +328,11 @@ func initializeBotWithConfig(cmd *cobra.Command, fileData []byte, keyFile opinit
 func OPInitBotsInitCommand() *cobra.Command {
 	shortDescription := "Initialize an OPinit bot"
 	initCmd := &cobra.Command{
-		Use:   "init [bot-name]",
-		Short: shortDescription,
-		Long:  fmt.Sprintf("Initialize an OPinit bot. The argument is optional, as you will be prompted to select a bot if no bot name is provided.\nAlternatively, you can specify a bot name as an argument to skip the selection. Valid options are [executor, challenger].\nExample: weave opinit init executor\n\n%s", OPinitBotsHelperText),
-		Args:  ValidateOPinitOptionalBotNameArgs,
+		Use:     "init [bot-name]",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("Initialize an OPinit bot. The argument is optional, as you will be prompted to select a bot if no bot name is provided.\nAlternatively, you can specify a bot name as an argument to skip the selection. Valid options are [executor, challenger].\nExample: weave opinit init executor\n\n%s", OPinitBotsHelperText),
+		Args:    ValidateOPinitOptionalBotNameArgs,
+		PreRunE: unlockKeyring,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			minitiaHome, _ := cmd.Flags().GetString(FlagMinitiaHome)
 			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
@@ -341,7 +382,9 @@ func OPInitBotsInitCommand() *cobra.Command {
 				return err
 			}
 			if withConfig {
-				return handleWithConfig(cmd, userHome, opInitHome, configPath, keyFilePath, args, force, isGenerateKeyFile)
+				configFormat, _ := cmd.Flags().GetString(FlagConfigFormat)
+				encryptKeyFile, _ := cmd.Flags().GetBool(FlagEncryptKeyFile)
+				return handleWithConfig(cmd, userHome, opInitHome, configPath, configFormat, keyFilePath, args, force, isGenerateKeyFile, encryptKeyFile)
 			}
 
 			_, err = RunOPInit(rootProgram, HomeConfig{
@@ -363,8 +406,10 @@ func OPInitBotsInitCommand() *cobra.Command {
 	initCmd.Flags().String(FlagOPInitHome, filepath.Join(homeDir, common.OPinitDirectory), "OPInit bots home directory")
 	initCmd.Flags().String(FlagWithConfig, "", "Bypass the interactive setup and initialize the bot by providing a path to a config file. Either --key-file or --generate-key-file has to be specified")
 	initCmd.Flags().String(FlagKeyFile, "", "Use this flag to generate the bot keys. Cannot be specified together with --key-file")
+	initCmd.Flags().String(FlagConfigFormat, "", "Format of the --with-config file: json, yaml or toml. Detected from the file extension when omitted")
 	initCmd.Flags().BoolP(FlagForce, "f", false, "Force the setup by deleting the existing .opinit directory if it exists")
 	initCmd.Flags().BoolP(FlagGenerateKeyFile, "", false, "Path to key-file.json. Cannot be specified together with --generate-key-file")
+	initCmd.Flags().Bool(FlagEncryptKeyFile, false, "Encrypt the key file generated by --generate-key-file with a passphrase")
 
 	return initCmd
 }
@@ -520,3 +565,657 @@ func OPInitBotsResetCommand() *cobra.Command {
 
 	return resetCmd
 }
+
+func OPInitBotsStatusCommand() *cobra.Command {
+	shortDescription := "Show the sync and relay status of the OPinit bots"
+	statusCmd := &cobra.Command{
+		Use:   "status [bot-name]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nQueries the HTTP API of the executor or challenger, or of every configured bot when no bot name is given, and shows the synced L1/L2 heights against the chain tips, the last output and its finalization, the pending batch, withdrawals and deposits.\nExits with an error when a bot cannot be reached.\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args:  ValidateOPinitOptionalBotNameArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			output, _ := cmd.Flags().GetString(FlagOutput)
+
+			bots := args
+			if len(bots) == 0 {
+				bots = opinit_bots.ConfiguredBots(opInitHome)
+				if len(bots) == 0 {
+					return fmt.Errorf("no OPinit bot is configured in %s, run `weave opinit init` first", opInitHome)
+				}
+			}
+
+			var statuses []*opinit_bots.BotStatus
+			var unreachable []string
+			for _, bot := range bots {
+				status, err := opinit_bots.GetBotStatus(opInitHome, bot)
+				if err != nil {
+					return err
+				}
+				statuses = append(statuses, status)
+				if status.Error != "" {
+					unreachable = append(unreachable, bot)
+				}
+			}
+
+			if output == "json" {
+				bz, err := json.MarshalIndent(statuses, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal OPinit bots status: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			} else {
+				for idx, status := range statuses {
+					if idx > 0 {
+						fmt.Fprintln(cmd.OutOrStdout())
+					}
+					fmt.Fprint(cmd.OutOrStdout(), status.String())
+				}
+			}
+
+			if len(unreachable) > 0 {
+				return fmt.Errorf("failed to query the status of the OPinit %s", strings.Join(unreachable, " and "))
+			}
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	statusCmd.Flags().String(FlagOPInitHome, filepath.Join(homeDir, common.OPinitDirectory), "OPInit bots home directory")
+	statusCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+
+	return statusCmd
+}
+
+func OPInitBotsConfigCommand() *cobra.Command {
+	shortDescription := "Read and edit the config of an OPinit bot"
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nFields are addressed by their dotted path in the config, e.g. l1_node.rpc_address. Editing the config keeps the bot's database.\n\n%s", shortDescription, OPinitBotsHelperText),
+	}
+
+	configCmd.AddCommand(
+		OPInitBotsConfigGetCommand(),
+		OPInitBotsConfigSetCommand(),
+		OPInitBotsConfigEditCommand(),
+	)
+
+	return configCmd
+}
+
+func OPInitBotsConfigGetCommand() *cobra.Command {
+	shortDescription := "Show a value of the config of an OPinit bot"
+	getCmd := &cobra.Command{
+		Use:   "get [bot-name] [path]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s. The whole config is shown when the path is omitted.\neg. weave opinit config get executor l1_node.gas_price\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("expected a bot name and an optional path, got %d arguments", len(args))
+			}
+			return ValidateOPinitBotNameArgs(cmd, args[:1])
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			configFile, err := opinit_bots.LoadBotConfigFile(opInitHome, args[0])
+			if err != nil {
+				return err
+			}
+			var path string
+			if len(args) == 2 {
+				path = args[1]
+			}
+			value, err := configFile.Get(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(getCmd)
+	return getCmd
+}
+
+func OPInitBotsConfigSetCommand() *cobra.Command {
+	shortDescription := "Change a value of the config of an OPinit bot"
+	setCmd := &cobra.Command{
+		Use:   "set [bot-name] [path] [value]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The value is validated against the type of the field, the change is shown as a diff and the bot's database is kept. Restart the bot, or pass --restart, for it to use the new value.
+
+Examples:
+  weave opinit config set executor l1_node.rpc_address https://rpc.testnet.initia.xyz
+  weave opinit config set executor l2_node.gas_price 0.15umin --restart
+  weave opinit config set challenger server.address localhost:3001 --dry-run
+
+%s`, shortDescription, OPinitBotsHelperText),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return fmt.Errorf("expected a bot name, a path and a value, got %d arguments", len(args))
+			}
+			return ValidateOPinitBotNameArgs(cmd, args[:1])
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+			restart, _ := cmd.Flags().GetBool(FlagRestart)
+			bot := args[0]
+
+			configFile, err := opinit_bots.LoadBotConfigFile(opInitHome, bot)
+			if err != nil {
+				return err
+			}
+			values := map[string]string{args[1]: args[2]}
+			if dryRun {
+				changes, err := configFile.Changes(values)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(cmd.OutOrStdout(), opinit_bots.RenderBotConfigChanges(changes))
+				return nil
+			}
+
+			changes, err := configFile.Save(values)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), opinit_bots.RenderBotConfigChanges(changes))
+			if len(changes) == 0 {
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved %s\n", configFile.Path)
+
+			if !restart {
+				fmt.Fprintf(cmd.OutOrStdout(), "Run `weave opinit restart %s` to apply the change\n", bot)
+				return nil
+			}
+			if err = opinit_bots.RestartBotService(bot); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restarted the OPinit %[1]s bot. You can see the logs with `weave opinit log %[1]s`\n", bot)
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(setCmd)
+	setCmd.Flags().Bool(FlagDryRun, false, "Show the change without saving it")
+	setCmd.Flags().Bool(FlagRestart, false, "Restart the bot service after saving the change")
+	return setCmd
+}
+
+func OPInitBotsConfigEditCommand() *cobra.Command {
+	shortDescription := "Edit the config of an OPinit bot interactively"
+	editCmd := &cobra.Command{
+		Use:   "edit [bot-name]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s. The changes are reviewed as a diff before they are saved, the bot's database is kept and the bot service can be restarted right away.\neg. weave opinit config edit executor\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args:  ValidateOPinitBotNameArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			model, err := opinit_bots.NewBotConfigEditor(opInitHome, args[0])
+			if err != nil {
+				return err
+			}
+			if finalModel, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+				return err
+			} else {
+				fmt.Println(finalModel.View())
+				return nil
+			}
+		},
+	}
+
+	addOPInitHomeFlag(editCmd)
+	return editCmd
+}
+
+func OPInitBotsRotateKeyCommand() *cobra.Command {
+	var roles []string
+	for _, role := range opinit_bots.KeyRoles {
+		roles = append(roles, string(role))
+	}
+	shortDescription := "Rotate the key of an OPinit bot"
+	rotateCmd := &cobra.Command{
+		Use:   "rotate-key [role]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+Generates a new key, or recovers it with --mnemonic, and funds it on the L1 from the gas station or the old key. A batch submitter on Celestia is funded on Celestia. The new key is then authorized on chain where needed, swapped into the keyring and the bot is restarted. What is left on the old key is sent to the new one.
+
+The keyring is first copied to <opinit home>/weave-dummy.rotated-<role>-<time>, which keeps the old key. The progress is recorded in <opinit home>/weave-rotation.<role>.json: if a step fails, run the command again to resume the rotation from there.
+
+The on-chain update depends on the role:
+  bridge-executor         the rollup operator in --minitia-dir replaces it in the opchild bridge executors
+  output-submitter        the bridge challenger, whose key must be in the keyring, sets the new proposer
+  challenger              the current challenger sets the new challenger
+  batch-submitter         the bridge proposer, whose key must be in the keyring, sets the new batch submitter
+  oracle-bridge-executor  the bridge executor grants the new key the oracle permissions
+
+Valid roles are: %s
+
+Examples:
+  weave opinit rotate-key output-submitter --dry-run
+  weave opinit rotate-key challenger --mnemonic env:NEW_CHALLENGER_MNEMONIC --fund-from old-key
+
+%s`, shortDescription, strings.Join(roles, ", "), OPinitBotsHelperText),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected exactly one argument, got %d", len(args))
+			}
+			_, err := opinit_bots.ParseKeyRole(args[0])
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			minitiaHome, _ := cmd.Flags().GetString(FlagMinitiaHome)
+			mnemonic, _ := cmd.Flags().GetString(FlagMnemonic)
+			fundFrom, _ := cmd.Flags().GetString(FlagFundFrom)
+			amount, _ := cmd.Flags().GetString(FlagAmount)
+			bridgeId, _ := cmd.Flags().GetString(FlagBridgeId)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+
+			role, err := opinit_bots.ParseKeyRole(args[0])
+			if err != nil {
+				return err
+			}
+			if mnemonic, err = io.ResolveSecret(mnemonic); err != nil {
+				return fmt.Errorf("failed to resolve --%s: %v", FlagMnemonic, err)
+			}
+
+			rotation, err := opinit_bots.PlanKeyRotation(opInitHome, role, opinit_bots.RotateKeyOptions{
+				Mnemonic:    mnemonic,
+				FundFrom:    opinit_bots.FundSource(fundFrom),
+				Amount:      amount,
+				BridgeId:    bridgeId,
+				MinitiaHome: minitiaHome,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), rotation.String())
+			if dryRun {
+				return nil
+			}
+			if rotation.GeneratedKey {
+				fmt.Fprintf(cmd.OutOrStdout(), "\nMnemonic of the new key, store it safely:\n%s\n\n", rotation.Mnemonic)
+			}
+
+			if err = rotation.Run(func(step string) {
+				fmt.Fprintln(cmd.OutOrStdout(), step)
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Rotated the %s key to %s. You can see the logs with `weave opinit log %s`\n", role, rotation.NewAddress, role.Bot())
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	addOPInitHomeFlag(rotateCmd)
+	rotateCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "Rollup application directory, whose operator updates the bridge executors")
+	rotateCmd.Flags().String(FlagMnemonic, "", "Mnemonic of the new key, or an env:NAME or file:PATH reference to it. A new key is generated when omitted")
+	rotateCmd.Flags().String(FlagFundFrom, string(opinit_bots.FundFromGasStation), fmt.Sprintf("Where the new key is funded from. Valid options are: %s, %s", opinit_bots.FundFromGasStation, opinit_bots.FundFromOldKey))
+	rotateCmd.Flags().String(FlagAmount, "", "Amount sent to the new key, in uinit or in utia for a batch submitter on Celestia. Defaults to the launch funding of the role, 0 skips the funding")
+	rotateCmd.Flags().String(FlagBridgeId, "", "Bridge ID of the rollup. Queried from the bot when omitted")
+	rotateCmd.Flags().Bool(FlagDryRun, false, "Show the rotation without changing anything")
+	return rotateCmd
+}
+
+func OPInitBotsWithdrawalCommand() *cobra.Command {
+	shortDescription := "Show the state of withdrawals from the rollup and finalize them on L1"
+	withdrawalCmd := &cobra.Command{
+		Use:   "withdrawal [sequence|address]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+Looks up a withdrawal by its sequence, or every withdrawal sent by an L2 address, through the HTTP API of the executor, and checks its output on the L1. A withdrawal is either:
+  pending output       the output that includes it is not submitted to the L1 yet
+  in challenge period  the output is submitted, the withdrawal can be finalized once its finalization period is over
+  finalizable          the output is finalized, MsgFinalizeTokenWithdrawal can be sent on the L1
+  finalized            the withdrawal is claimed on the L1
+
+With --finalize, MsgFinalizeTokenWithdrawal is signed and broadcast on the L1 for every finalizable withdrawal. Anyone can sign it, the funds always go to the receiver of the withdrawal.
+
+Examples:
+  weave opinit withdrawal 42
+  weave opinit withdrawal init1... --finalize --from my-key
+  weave opinit withdrawal 42 --finalize --mnemonic env:L1_MNEMONIC
+
+%s`, shortDescription, OPinitBotsHelperText),
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			finalize, _ := cmd.Flags().GetBool(FlagFinalize)
+			from, _ := cmd.Flags().GetString(FlagFrom)
+			mnemonic, _ := cmd.Flags().GetString(FlagMnemonic)
+			if finalize && (from == "") == (mnemonic == "") {
+				return fmt.Errorf("--%s requires exactly one of --%s or --%s", FlagFinalize, FlagFrom, FlagMnemonic)
+			}
+			if !finalize && (from != "" || mnemonic != "") {
+				return fmt.Errorf("--%s and --%s can only be used with --%s", FlagFrom, FlagMnemonic, FlagFinalize)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			finalize, _ := cmd.Flags().GetBool(FlagFinalize)
+			from, _ := cmd.Flags().GetString(FlagFrom)
+			keyringDir, _ := cmd.Flags().GetString(FlagKeyringDir)
+			mnemonic, _ := cmd.Flags().GetString(FlagMnemonic)
+
+			withdrawals, err := opinit_bots.NewWithdrawals(opInitHome)
+			if err != nil {
+				return err
+			}
+			statuses, err := withdrawals.Get(args[0])
+			if err != nil {
+				return err
+			}
+			if len(statuses) == 0 {
+				return fmt.Errorf("no withdrawal found for %s", args[0])
+			}
+
+			if finalize {
+				if mnemonic, err = io.ResolveSecret(mnemonic); err != nil {
+					return fmt.Errorf("failed to resolve --%s: %v", FlagMnemonic, err)
+				}
+				signer := opinit_bots.WithdrawalSigner{KeyName: from, KeyringDir: keyringDir, Mnemonic: mnemonic}
+				finalized := 0
+				for _, status := range statuses {
+					if status.State != opinit_bots.WithdrawalFinalizable {
+						continue
+					}
+					if err = withdrawals.Finalize(status, signer); err != nil {
+						return err
+					}
+					finalized++
+				}
+				if finalized == 0 && len(statuses) == 1 {
+					return fmt.Errorf("withdrawal %d is %s, it cannot be finalized", statuses[0].Sequence, statuses[0].State)
+				}
+			}
+
+			if output == "json" {
+				bz, err := opinit_bots.MarshalWithdrawals(statuses)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), bz)
+				return nil
+			}
+			for idx, status := range statuses {
+				if idx > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+				fmt.Fprint(cmd.OutOrStdout(), status.String())
+			}
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(withdrawalCmd)
+	withdrawalCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+	withdrawalCmd.Flags().Bool(FlagFinalize, false, "Sign and broadcast MsgFinalizeTokenWithdrawal on the L1 for the finalizable withdrawals")
+	withdrawalCmd.Flags().String(FlagFrom, "", "Name of the L1 key that signs the finalization, in the keyring of --keyring-dir")
+	withdrawalCmd.Flags().String(FlagKeyringDir, "", "Directory of the keyring holding --from, opened with the configured keyring backend. Defaults to the keyring of the initiad home")
+	withdrawalCmd.Flags().String(FlagMnemonic, "", "Mnemonic of the L1 key that signs the finalization, or an env:NAME or file:PATH reference to it")
+	return withdrawalCmd
+}
+
+func OPInitBotsDBCommand() *cobra.Command {
+	shortDescription := "Back up, restore and inspect the database of an OPinit bot"
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nRestoring a backup recovers from a corrupted database without syncing again from the start heights, as `weave opinit reset` does.\n\n%s", shortDescription, OPinitBotsHelperText),
+	}
+
+	dbCmd.AddCommand(
+		OPInitBotsDBBackupCommand(),
+		OPInitBotsDBRestoreCommand(),
+		OPInitBotsDBInfoCommand(),
+	)
+
+	return dbCmd
+}
+
+func OPInitBotsDBBackupCommand() *cobra.Command {
+	shortDescription := "Take a snapshot of the database of an OPinit bot"
+	backupCmd := &cobra.Command{
+		Use:   "backup [bot-name]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nA running bot is stopped while its database is copied and started again afterwards. The snapshot records the heights the bot processed, the opinitd version and the chains it runs for.\neg. weave opinit db backup executor\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args:  ValidateOPinitBotNameArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			backupDir, _ := cmd.Flags().GetString(FlagBackupDir)
+			if backupDir == "" {
+				backupDir = opinit_bots.DefaultDBBackupDir(opInitHome)
+			}
+
+			backup, err := opinit_bots.BackupDB(opInitHome, args[0], backupDir, func(message string) {
+				fmt.Fprintln(cmd.OutOrStdout(), message)
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backed up the OPinit %s database (%s) to %s\n", args[0], io.FormatBytes(backup.Metadata.Size), backup.Path)
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(backupCmd)
+	backupCmd.Flags().String(FlagBackupDir, "", "Directory to take the snapshot in. Defaults to <opinit-dir>/backups")
+	return backupCmd
+}
+
+func OPInitBotsDBRestoreCommand() *cobra.Command {
+	shortDescription := "Replace the database of an OPinit bot with a snapshot"
+	restoreCmd := &cobra.Command{
+		Use:   "restore [bot-name] [backup-path]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe most recent snapshot of the backup directory is restored when no path is given. The snapshot must have been taken for the chains the bot is configured for, with an opinitd version of the same minor release. A running bot is stopped while its database is replaced and started again afterwards.\neg. weave opinit db restore executor\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("expected a bot name and an optional backup path, got %d arguments", len(args))
+			}
+			return ValidateOPinitBotNameArgs(cmd, args[:1])
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			backupDir, _ := cmd.Flags().GetString(FlagBackupDir)
+			force, _ := cmd.Flags().GetBool(FlagForce)
+			if backupDir == "" {
+				backupDir = opinit_bots.DefaultDBBackupDir(opInitHome)
+			}
+
+			botName := args[0]
+			var backupPath string
+			if len(args) == 2 {
+				backupPath = args[1]
+			} else {
+				backups, err := opinit_bots.ListDBBackups(backupDir, botName)
+				if err != nil {
+					return err
+				}
+				if len(backups) == 0 {
+					return fmt.Errorf("no backup of the %s database found in %s", botName, backupDir)
+				}
+				backupPath = backups[0].Path
+			}
+
+			backup, err := opinit_bots.RestoreDB(opInitHome, botName, backupPath, force, func(message string) {
+				fmt.Fprintln(cmd.OutOrStdout(), message)
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored the OPinit %s database from %s\n", botName, backup.String())
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(restoreCmd)
+	restoreCmd.Flags().String(FlagBackupDir, "", "Directory to look for the latest snapshot in. Defaults to <opinit-dir>/backups")
+	restoreCmd.Flags().BoolP(FlagForce, "f", false, "Restore a snapshot taken for other chains or with an incompatible opinitd version")
+	return restoreCmd
+}
+
+func OPInitBotsDBInfoCommand() *cobra.Command {
+	shortDescription := "Show the size, processed heights and snapshots of the database of an OPinit bot"
+	infoCmd := &cobra.Command{
+		Use:   "info [bot-name]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe processed heights are queried from the HTTP API of the bot. When it does not answer, the heights recorded by the latest snapshot are shown.\neg. weave opinit db info executor\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args:  ValidateOPinitBotNameArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			backupDir, _ := cmd.Flags().GetString(FlagBackupDir)
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if backupDir == "" {
+				backupDir = opinit_bots.DefaultDBBackupDir(opInitHome)
+			}
+
+			info, err := opinit_bots.GetDBInfo(opInitHome, args[0], backupDir)
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				bz, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal the database info: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), info.String())
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(infoCmd)
+	infoCmd.Flags().String(FlagBackupDir, "", "Directory to list the snapshots of. Defaults to <opinit-dir>/backups")
+	infoCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+	return infoCmd
+}
+
+func OPInitBotsVersionCommand() *cobra.Command {
+	shortDescription := "List and pin the opinitd version the OPinit bots run"
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nA version can run the bots when it implements the spec version that the L1 of the bots requires in the spec_version.json of the OPinit bots.\n\n%s", shortDescription, OPinitBotsHelperText),
+	}
+
+	versionCmd.AddCommand(
+		OPInitBotsVersionListCommand(),
+		OPInitBotsVersionUseCommand(),
+	)
+
+	return versionCmd
+}
+
+func OPInitBotsVersionListCommand() *cobra.Command {
+	shortDescription := "List the released opinitd versions and their spec compatibility"
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe spec version of each release is read from its spec_version.json, for the L1 of the configured bots.\n\n%s", shortDescription, OPinitBotsHelperText),
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			output, _ := cmd.Flags().GetString(FlagOutput)
+
+			versions, err := opinit_bots.ListBotVersions(opInitHome)
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				bz, err := json.MarshalIndent(versions, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal the opinitd versions: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), versions.String())
+			return nil
+		},
+	}
+
+	addOPInitHomeFlag(listCmd)
+	listCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+	return listCmd
+}
+
+func OPInitBotsVersionUseCommand() *cobra.Command {
+	shortDescription := "Pin the opinitd version the OPinit bots run"
+	useCmd := &cobra.Command{
+		Use:   "use [version]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+Installs the version and checks that it implements the spec version the L1 of the bots requires. An incompatible version is refused, unless --force is set. The bot services are then pointed to the new binary, the version field of the bot configs is set to the spec version, left unchanged for a version used with --force, and the bots whose service runs are restarted. weave keeps using this version, e.g. in `+"`weave opinit init`"+`, until another one is pinned.
+eg. weave opinit version use v0.1.12
+
+%s`, shortDescription, OPinitBotsHelperText),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			force, _ := cmd.Flags().GetBool(FlagForce)
+			return opinit_bots.UseBotVersion(opInitHome, args[0], force, func(message string) {
+				fmt.Fprintln(cmd.OutOrStdout(), message)
+			})
+		},
+	}
+
+	addOPInitHomeFlag(useCmd)
+	useCmd.Flags().BoolP(FlagForce, "f", false, "Use a version that does not implement the required spec version")
+	return useCmd
+}
+
+func addOPInitHomeFlag(cmd *cobra.Command) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+	cmd.Flags().String(FlagOPInitHome, filepath.Join(homeDir, common.OPinitDirectory), "OPInit bots home directory")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/initia-labs/weave/crypto"
+)
+
+// readPassphrase asks for a passphrase on the terminal without echoing it
+func readPassphrase(prompt string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("a passphrase is required, but stdin is not a terminal")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %v", err)
+	}
+	return string(passphrase), nil
+}
+
+// readNewPassphrase asks for a new passphrase twice and checks that both entries match
+func readNewPassphrase() (string, error) {
+	passphrase, err := readPassphrase("Enter a passphrase to encrypt the key file: ")
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) < crypto.MinPassphraseLength {
+		return "", fmt.Errorf("passphrase must be at least %d characters", crypto.MinPassphraseLength)
+	}
+	confirmation, err := readPassphrase("Enter the passphrase again: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirmation {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
@@ -41,9 +41,10 @@ func RelayerCommand() *cobra.Command {
 func relayerInitCommand() *cobra.Command {
 	shortDescription := "Initialize and configure your relayer for IBC"
 	initCmd := &cobra.Command{
-		Use:   "init",
-		Short: shortDescription,
-		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, RelayerHelperText),
+		Use:     "init",
+		Short:   shortDescription,
+		Long:    fmt.Sprintf("%s.\n\n%s", shortDescription, RelayerHelperText),
+		PreRunE: unlockKeyring,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			analytics.TrackRunEvent(cmd, args, analytics.SetupRelayerFeature, analytics.NewEmptyEvent())
 			ctx := weavecontext.NewAppContext(relayer.NewRelayerState())
@@ -18,12 +18,14 @@ import (
 	"github.com/initia-labs/weave/cosmosutils"
 	"github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/models"
+	"github.com/initia-labs/weave/models/initia"
 	"github.com/initia-labs/weave/models/minitia"
 	"github.com/initia-labs/weave/service"
 	"github.com/initia-labs/weave/types"
 )
 
 type minitiaConfigKey struct{}
+type genesisAccountsKey struct{}
 
 var (
 	validVMOptions = []string{"evm", "move", "wasm"}
@@ -45,6 +47,12 @@ func MinitiaCommand() *cobra.Command {
 		minitiaStopCommand(),
 		minitiaRestartCommand(),
 		minitiaLogCommand(),
+		minitiaAdoptCommand(),
+		minitiaRelocateDataCommand(),
+		minitiaUpgradeCommand(),
+		minitiaInfoCommand(),
+		minitiaParamsCommand(),
+		minitiaValidateConfigCommand(),
 	)
 
 	return cmd
@@ -59,22 +67,84 @@ func validateVMFlag(vmValue string) error {
 	return fmt.Errorf("invalid value for --vm. Valid options are: %s", strings.Join(validVMOptions, ", "))
 }
 
-func loadAndParseMinitiaConfig(path string) (*types.MinitiaConfig, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
+func loadAndParseMinitiaConfig(path, format string) (*types.MinitiaConfig, error) {
 	var minitiaConfig types.MinitiaConfig
-	decoder := json.NewDecoder(file)
-	if err = decoder.Decode(&minitiaConfig); err != nil {
+	if err := io.ReadConfigFile(path, format, &minitiaConfig); err != nil {
 		return nil, err
 	}
 
 	return &minitiaConfig, nil
 }
 
+// loadAndValidateMinitiaConfig parses the config file, restores the mnemonics from the encrypted key file of a
+// launch template when keyFilePath is set, resolves `env:` and `file:` mnemonic references, and reports every
+// invalid field at once
+func loadAndValidateMinitiaConfig(path, format, vm, keyFilePath string) (*types.MinitiaConfig, error) {
+	minitiaConfig, err := loadAndParseMinitiaConfig(path, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if keyFilePath != "" {
+		if minitiaConfig.SystemKeys == nil {
+			return nil, fmt.Errorf("config %s has no system_keys to restore from %s", path, keyFilePath)
+		}
+		passphrase, err := readPassphrase(fmt.Sprintf("Enter the passphrase of %s: ", keyFilePath))
+		if err != nil {
+			return nil, err
+		}
+		if err = minitia.LoadLaunchTemplateKeys(minitiaConfig, keyFilePath, passphrase); err != nil {
+			return nil, fmt.Errorf("failed to restore mnemonics from %s: %w", keyFilePath, err)
+		}
+	}
+
+	for name, account := range minitiaConfig.SystemKeys.Accounts() {
+		if account.Mnemonic, err = io.ResolveSecret(account.Mnemonic); err != nil {
+			return nil, fmt.Errorf("failed to resolve system_keys.%s.mnemonic: %w", name, err)
+		}
+	}
+
+	if errs := minitia.ValidateMinitiaConfig(vm, minitiaConfig); len(errs) > 0 {
+		lines := make([]string, len(errs))
+		for idx, err := range errs {
+			lines[idx] = fmt.Sprintf("  - %v", err)
+		}
+		return nil, fmt.Errorf("invalid config %s:\n%s", path, strings.Join(lines, "\n"))
+	}
+
+	return minitiaConfig, nil
+}
+
+// loadGenesisAccounts validates and merges the accounts of a --genesis-accounts file and prints their totals. With
+// --with-config they are merged into the genesis accounts of the config, otherwise they are kept in the command
+// context for the interactive launch.
+func loadGenesisAccounts(cmd *cobra.Command, path string) error {
+	genesisAccounts, err := minitia.LoadGenesisAccountsFile(path)
+	if err != nil {
+		return err
+	}
+
+	if minitiaConfig, ok := cmd.Context().Value(minitiaConfigKey{}).(*types.MinitiaConfig); ok {
+		var existing types.GenesisAccounts
+		if minitiaConfig.GenesisAccounts != nil {
+			existing = *minitiaConfig.GenesisAccounts
+		}
+		merged, err := minitia.MergeGenesisAccounts(append(existing, genesisAccounts...))
+		if err != nil {
+			return fmt.Errorf("failed to merge genesis accounts into the config: %w", err)
+		}
+		minitiaConfig.GenesisAccounts = &merged
+	}
+	cmd.SetContext(context.WithValue(cmd.Context(), genesisAccountsKey{}, genesisAccounts))
+
+	totals, err := minitia.GenesisAccountTotals(genesisAccounts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d genesis accounts from %s, total: %s\n", len(genesisAccounts), path, strings.Join(totals, ", "))
+	return nil
+}
+
 func minitiaLaunchCommand() *cobra.Command {
 	shortDescription := "Launch a new rollup from scratch"
 	launchCmd := &cobra.Command{
@@ -84,6 +154,18 @@ func minitiaLaunchCommand() *cobra.Command {
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			configPath, _ := cmd.Flags().GetString(FlagWithConfig)
 			vm, _ := cmd.Flags().GetString(FlagVm)
+			keyFilePath, _ := cmd.Flags().GetString(FlagKeyFile)
+			configFormat, _ := cmd.Flags().GetString(FlagConfigFormat)
+			genesisAccountsPath, _ := cmd.Flags().GetString(FlagGenesisAccounts)
+			resume, _ := cmd.Flags().GetBool(FlagResume)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+
+			if resume {
+				if configPath != "" || vm != "" || keyFilePath != "" || genesisAccountsPath != "" || dryRun {
+					return fmt.Errorf("the --resume flag continues the recorded launch and cannot be used with --with-config, --vm, --key-file, --genesis-accounts or --dry-run")
+				}
+				return unlockKeyring(cmd, args)
+			}
 
 			if configPath != "" && vm == "" {
 				return fmt.Errorf("the --vm flag is required when using --with-config")
@@ -91,21 +173,34 @@ func minitiaLaunchCommand() *cobra.Command {
 			if configPath == "" && vm != "" {
 				return fmt.Errorf("the --vm flag can only be used with --with-config")
 			}
+			if configPath == "" && keyFilePath != "" {
+				return fmt.Errorf("the --key-file flag can only be used with --with-config")
+			}
+
+			if vm != "" {
+				if err := validateVMFlag(vm); err != nil {
+					return err
+				}
+			}
 
 			if configPath != "" {
-				minitiaConfig, err := loadAndParseMinitiaConfig(configPath)
+				minitiaConfig, err := loadAndValidateMinitiaConfig(configPath, configFormat, vm, keyFilePath)
 				if err != nil {
-					return fmt.Errorf("failed to load config: %w", err)
+					return err
 				}
 				cmd.SetContext(context.WithValue(cmd.Context(), minitiaConfigKey{}, minitiaConfig))
 			}
 
-			if vm != "" {
-				if err := validateVMFlag(vm); err != nil {
+			if genesisAccountsPath != "" {
+				if err := loadGenesisAccounts(cmd, genesisAccountsPath); err != nil {
 					return err
 				}
 			}
-			return nil
+			// a dry run touches no key
+			if dryRun {
+				return nil
+			}
+			return unlockKeyring(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
@@ -115,6 +210,21 @@ func minitiaLaunchCommand() *cobra.Command {
 			configPath, _ := cmd.Flags().GetString(FlagWithConfig)
 			vm, _ := cmd.Flags().GetString(FlagVm)
 			force, _ := cmd.Flags().GetBool(FlagForce)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+			resume, _ := cmd.Flags().GetBool(FlagResume)
+			if resume {
+				return resumeMinitiaLaunch(cmd, args, force)
+			}
+			if !dryRun {
+				checkpoint, err := minitia.LoadLaunchCheckpoint()
+				if err != nil {
+					return err
+				}
+				if checkpoint != nil {
+					checkpointPath, _ := minitia.GetLaunchCheckpointPath()
+					return fmt.Errorf("the launch of %s did not complete. Use --resume to continue it, or delete %s to start over", checkpoint.Config.L2Config.ChainID, checkpointPath)
+				}
+			}
 			state := minitia.NewLaunchState()
 			events := analytics.NewEmptyEvent()
 			if configPath != "" {
@@ -122,6 +232,30 @@ func minitiaLaunchCommand() *cobra.Command {
 					Add(analytics.VmKey, vm)
 			}
 			analytics.TrackRunEvent(cmd, args, analytics.RollupLaunchFeature, events)
+			if genesisAccounts, ok := cmd.Context().Value(genesisAccountsKey{}).(types.GenesisAccounts); ok && configPath == "" {
+				if err = state.ImportGenesisAccounts(genesisAccounts); err != nil {
+					return err
+				}
+			}
+			if configPath != "" && dryRun {
+				minitiaConfig, ok := cmd.Context().Value(minitiaConfigKey{}).(*types.MinitiaConfig)
+				if !ok {
+					return fmt.Errorf("failed to retrieve configuration from context")
+				}
+
+				version, downloadURL, err := cosmosutils.GetLatestMinitiaVersion(vm)
+				if err != nil {
+					return err
+				}
+
+				plan, err := minitia.BuildLaunchPlanFromConfig(vm, version, downloadURL, minitiaHome, minitiaConfig)
+				if err != nil {
+					return err
+				}
+				fmt.Print(plan.String())
+				return nil
+			}
+
 			if configPath != "" {
 				if io.FileOrFolderExists(minitiaHome) && !force {
 					return fmt.Errorf("existing %s folder detected. Use --force or -f to override", minitiaHome)
@@ -140,14 +274,18 @@ func minitiaLaunchCommand() *cobra.Command {
 				state.PrepareLaunchingWithConfig(vm, version, downloadURL, configPath, minitiaConfig)
 			}
 
-			if force {
+			if dryRun {
+				state.EnableDryRun()
+			} else if force {
 				if err = io.DeleteDirectory(minitiaHome); err != nil {
 					return fmt.Errorf("failed to delete %s: %v", minitiaHome, err)
 				}
 			}
 
+			initiaHome, _ := cmd.Flags().GetString(FlagInitiaHome)
 			ctx := weavecontext.NewAppContext(*state)
 			ctx = weavecontext.SetMinitiaHome(ctx, minitiaHome)
+			ctx = weavecontext.SetInitiaHome(ctx, initiaHome)
 
 			if config.IsFirstTimeSetup() {
 				checkerCtx := weavecontext.NewAppContext(models.NewExistingCheckerState())
@@ -177,13 +315,61 @@ func minitiaLaunchCommand() *cobra.Command {
 	}
 
 	launchCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	launchCmd.Flags().String(FlagInitiaHome, filepath.Join(homeDir, common.InitiaDirectory), "Home directory of the local L1 node, used to prefill the chain ID and endpoints when launching against a local L1")
 	launchCmd.Flags().String(FlagWithConfig, "", "Launch using an existing rollup config file. The argument should be the path to the config file")
 	launchCmd.Flags().String(FlagVm, "", fmt.Sprintf("VM to be used. Required when using --with-config. Valid options are: %s", strings.Join(validVMOptions, ", ")))
+	launchCmd.Flags().String(FlagConfigFormat, "", "Format of the --with-config file: json, yaml or toml. Detected from the file extension when omitted")
+	launchCmd.Flags().String(FlagKeyFile, "", "Encrypted key file saved together with a launch template. The redacted mnemonics of --with-config are restored from it")
+	launchCmd.Flags().String(FlagGenesisAccounts, "", "CSV file with address,coins rows or JSON list of {\"address\", \"coins\"} to add as genesis accounts")
 	launchCmd.Flags().BoolP(FlagForce, "f", false, "Force the launch by deleting the existing .minitia directory if it exists")
+	launchCmd.Flags().Bool(FlagDryRun, false, "Show the final config, funding transactions, files and services of the launch without broadcasting anything or running `minitiad launch`")
+	launchCmd.Flags().Bool(FlagResume, false, "Continue a launch that failed from its last completed step, without funding the system keys again")
 
 	return launchCmd
 }
 
+// resumeMinitiaLaunch continues the launch recorded by the launch checkpoint
+func resumeMinitiaLaunch(cmd *cobra.Command, args []string, force bool) error {
+	checkpoint, err := minitia.LoadLaunchCheckpoint()
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		return fmt.Errorf("there is no launch to resume")
+	}
+	analytics.TrackRunEvent(cmd, args, analytics.RollupLaunchFeature, analytics.NewEmptyEvent())
+
+	// a failed `minitiad launch` leaves a partial home behind that it refuses to launch into again
+	if !checkpoint.IsDone(minitia.LaunchStepRollupLaunched) && io.FileOrFolderExists(checkpoint.MinitiaHome) {
+		if !force {
+			return fmt.Errorf("existing %s folder detected from the failed launch. Use --force or -f to delete it and run `minitiad launch` again", checkpoint.MinitiaHome)
+		}
+		if err = io.DeleteDirectory(checkpoint.MinitiaHome); err != nil {
+			return fmt.Errorf("failed to delete %s: %v", checkpoint.MinitiaHome, err)
+		}
+	}
+
+	state := minitia.NewLaunchState()
+	if err = state.RestoreFromCheckpoint(checkpoint); err != nil {
+		return err
+	}
+	initiaHome, _ := cmd.Flags().GetString(FlagInitiaHome)
+	ctx := weavecontext.NewAppContext(*state)
+	ctx = weavecontext.SetMinitiaHome(ctx, checkpoint.MinitiaHome)
+	ctx = weavecontext.SetInitiaHome(ctx, initiaHome)
+
+	model, err := minitia.NewResumeLaunch(ctx)
+	if err != nil {
+		return err
+	}
+	if finalModel, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return err
+	} else {
+		fmt.Println(finalModel.View())
+		return nil
+	}
+}
+
 func minitiaStartCommand() *cobra.Command {
 	shortDescription := "Start the rollup full node service"
 	launchCmd := &cobra.Command{
@@ -290,3 +476,411 @@ func minitiaLogCommand() *cobra.Command {
 
 	return logCmd
 }
+
+func minitiaAdoptCommand() *cobra.Command {
+	shortDescription := "Bring an existing, manually installed rollup full node under weave management"
+	adoptCmd := &cobra.Command{
+		Use:   "adopt",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe node version is detected from the running node or its binary, the matching binary is downloaded and a weave-managed service is created for the existing home. Existing systemd units for the node are migrated. Chain data is left untouched.\n\n%s",
+			shortDescription, RollupHelperText),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			vm, _ := cmd.Flags().GetString(FlagVm)
+			if vm != "" {
+				return validateVMFlag(vm)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			vm, _ := cmd.Flags().GetString(FlagVm)
+			version, _ := cmd.Flags().GetString(FlagVersion)
+
+			if err = minitia.AdoptExistingRollup(minitiaHome, vm, version); err != nil {
+				return err
+			}
+
+			fmt.Printf("Rollup full node at %s is now managed by weave. You can control it with `weave rollup start|stop|restart|log`\n", minitiaHome)
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	adoptCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	adoptCmd.Flags().String(FlagVm, "", fmt.Sprintf("VM of the rollup. Detected from the existing service when omitted. Valid options are: %s", strings.Join(validVMOptions, ", ")))
+	adoptCmd.Flags().String(FlagVersion, "", "The minitiad version the node runs. Detected automatically when omitted")
+
+	return adoptCmd
+}
+
+func minitiaRelocateDataCommand() *cobra.Command {
+	shortDescription := "Move the rollup full node data directory to another path"
+	relocateCmd := &cobra.Command{
+		Use:   "relocate-data <new-path>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nThe service is stopped, data/ is copied and verified, then replaced with a symlink to the new path and the service is started again. The previous data is only removed once the node serves its previous height from the new path, and any failure rolls back to the original data directory.\n\n%s",
+			shortDescription, RollupHelperText),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			keepOld, _ := cmd.Flags().GetBool(FlagKeepOld)
+
+			if err = initia.RelocateNodeData(service.Minitia, home, args[0], keepOld); err != nil {
+				return err
+			}
+
+			fmt.Printf("Moved the rollup full node data directory to %s. You can see the logs with `weave rollup log`\n", args[0])
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	relocateCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	relocateCmd.Flags().Bool(FlagKeepOld, false, "Keep the previous data directory instead of deleting it after a successful move")
+
+	return relocateCmd
+}
+
+func minitiaUpgradeCommand() *cobra.Command {
+	shortDescription := "Upgrade the rollup full node to another minitiad version"
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade [version]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The binary of the new version is downloaded and checked, the service is stopped, the rollup home and its data are backed up to <home>.backup-<current version>, and the service is recreated with the new binary and started. If the node does not produce a new block within --timeout, the previous home and binary are restored and the failed home is kept at <home>.failed-<new version>, unless the new version already wrote the store.
+
+Examples:
+  weave rollup upgrade --list    List the available versions for the rollup's VM
+  weave rollup upgrade           Upgrade to the latest release
+  weave rollup upgrade v1.0.2    Upgrade to a specific version
+  weave rollup upgrade v1.0      Upgrade to the latest patch version of v1.0
+
+%s`, shortDescription, RollupHelperText),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			list, _ := cmd.Flags().GetBool(FlagList)
+			timeout, _ := cmd.Flags().GetDuration(FlagTimeout)
+
+			vm, currentVersion, err := minitia.DetectRollupService()
+			if err != nil {
+				return err
+			}
+			versions, err := cosmosutils.ListBinaryReleases(cosmosutils.GetMinitiaReleasesURL(vm))
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				return fmt.Errorf("no mini%s releases found for this platform", vm)
+			}
+			sortedVersions := cosmosutils.SortVersions(versions)
+
+			if list {
+				for _, version := range sortedVersions {
+					if version == currentVersion {
+						fmt.Printf("%s (current)\n", version)
+					} else {
+						fmt.Println(version)
+					}
+				}
+				return nil
+			}
+
+			targetVersion := sortedVersions[0]
+			if len(args) > 0 {
+				targetVersion = findMatchingVersion(cosmosutils.NormalizeVersion(args[0]), sortedVersions)
+				if targetVersion == "" {
+					return fmt.Errorf("mini%s %s does not exist, see the available versions with `weave rollup upgrade --list`", vm, args[0])
+				}
+			}
+			if targetVersion == currentVersion {
+				fmt.Printf("The rollup already runs mini%s %s\n", vm, currentVersion)
+				return nil
+			}
+			if !cosmosutils.CompareSemVer(targetVersion, currentVersion) {
+				return fmt.Errorf("mini%s %s is older than the current version %s", vm, targetVersion, currentVersion)
+			}
+
+			fmt.Printf("Upgrading the rollup from mini%s %s to %s\n", vm, currentVersion, targetVersion)
+			if err = minitia.UpgradeRollup(minitiaHome, vm, currentVersion, targetVersion, versions[targetVersion], timeout); err != nil {
+				return err
+			}
+
+			fmt.Printf("Upgraded the rollup full node to mini%s %s. You can see the logs with `weave rollup log`\n", vm, targetVersion)
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	upgradeCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	upgradeCmd.Flags().Bool(FlagList, false, "List the available minitiad versions for the rollup's VM instead of upgrading")
+	upgradeCmd.Flags().Duration(FlagTimeout, minitia.DefaultUpgradeTimeout, "How long the upgraded node gets to produce a new block before rolling back")
+
+	return upgradeCmd
+}
+
+func minitiaInfoCommand() *cobra.Command {
+	shortDescription := "Show the details of the launched rollup"
+	infoCmd := &cobra.Command{
+		Use:   "info",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nShows the chain ID, VM, version, bridge ID, L1 chain, DA target and endpoints of the rollup, the system key addresses with their live L1, L2 and DA balances, the latest L2 height and the latest output submitted to the L1.\n\n%s",
+			shortDescription, RollupHelperText),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			output, _ := cmd.Flags().GetString(FlagOutput)
+
+			info, err := minitia.GetRollupInfo(minitiaHome)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				bz, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal rollup info: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), info.String())
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	infoCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	infoCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+
+	return infoCmd
+}
+
+func minitiaParamsCommand() *cobra.Command {
+	shortDescription := "Show or update the OPchild params of the rollup"
+	paramsCmd := &cobra.Command{
+		Use:   "params",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, RollupHelperText),
+	}
+
+	paramsCmd.AddCommand(
+		minitiaParamsShowCommand(),
+		minitiaParamsUpdateCommand(),
+	)
+
+	return paramsCmd
+}
+
+func minitiaParamsShowCommand() *cobra.Command {
+	shortDescription := "Show the current OPchild params of the rollup"
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, RollupHelperText),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			output, _ := cmd.Flags().GetString(FlagOutput)
+
+			params, err := minitia.GetOPChildParams(minitiaHome)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				paramsJson, err := minitia.MarshalOPChildParams(params)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), paramsJson)
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), minitia.RenderOPChildParams(params))
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	showCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	showCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+
+	return showCmd
+}
+
+func minitiaParamsUpdateCommand() *cobra.Command {
+	shortDescription := "Update the OPchild params of the rollup"
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+Only the params given as flags are changed. A MsgUpdateParams with the updated params is signed with the operator key in the rollup home and broadcast through `+"`minitiad tx opchild execute-messages`"+`, then the params are queried again until the change shows up.
+
+Examples:
+  weave rollup params update --min-gas-prices 0.15umin
+  weave rollup params update --fee-whitelist init1...,init1... --max-validators 2
+  weave rollup params update --bridge-executors init1... --dry-run
+
+%s`, shortDescription, RollupHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minitiaHome, err := cmd.Flags().GetString(FlagMinitiaHome)
+			if err != nil {
+				return err
+			}
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+
+			var update minitia.OPChildParamsUpdate
+			if cmd.Flags().Changed(FlagMinGasPrices) {
+				minGasPrices, _ := cmd.Flags().GetString(FlagMinGasPrices)
+				update.MinGasPrices = &minGasPrices
+			}
+			if cmd.Flags().Changed(FlagFeeWhitelist) {
+				feeWhitelist, _ := cmd.Flags().GetStringSlice(FlagFeeWhitelist)
+				update.FeeWhitelist = &feeWhitelist
+			}
+			if cmd.Flags().Changed(FlagBridgeExecutors) {
+				bridgeExecutors, _ := cmd.Flags().GetStringSlice(FlagBridgeExecutors)
+				update.BridgeExecutors = &bridgeExecutors
+			}
+			if cmd.Flags().Changed(FlagMaxValidators) {
+				maxValidators, _ := cmd.Flags().GetUint32(FlagMaxValidators)
+				update.MaxValidators = &maxValidators
+			}
+			if cmd.Flags().Changed(FlagHookMaxGas) {
+				hookMaxGas, _ := cmd.Flags().GetString(FlagHookMaxGas)
+				update.HookMaxGas = &hookMaxGas
+			}
+			if update.IsEmpty() {
+				return fmt.Errorf("nothing to update, set at least one of --%s, --%s, --%s, --%s or --%s",
+					FlagMinGasPrices, FlagFeeWhitelist, FlagBridgeExecutors, FlagMaxValidators, FlagHookMaxGas)
+			}
+
+			messagesPath, err := minitia.CreateParamsMessagesFile()
+			if err != nil {
+				return err
+			}
+			if !dryRun {
+				defer os.Remove(messagesPath)
+			}
+			current, updated, err := minitia.BuildOPChildUpdateParamsMsg(minitiaHome, messagesPath, update)
+			if err != nil {
+				return err
+			}
+			fmt.Print(minitia.RenderOPChildParams(updated))
+
+			if dryRun {
+				messages, err := os.ReadFile(messagesPath)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %v", messagesPath, err)
+				}
+				fmt.Printf("\nMsgUpdateParams written to %s:\n%s\n", messagesPath, string(messages))
+				return nil
+			}
+
+			txHash, err := minitia.UpdateOPChildParams(minitiaHome, messagesPath, current, update)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nUpdated the OPchild params in tx %s\n", txHash)
+			return nil
+		},
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic(fmt.Errorf("cannot get user home directory: %v", err))
+	}
+
+	updateCmd.Flags().String(FlagMinitiaHome, filepath.Join(homeDir, common.MinitiaDirectory), "The rollup application home directory")
+	updateCmd.Flags().String(FlagMinGasPrices, "", "Comma separated minimum gas prices, e.g. 0.15umin. An empty value removes them")
+	updateCmd.Flags().StringSlice(FlagFeeWhitelist, nil, "Comma separated addresses exempted from gas fees, replacing the current list")
+	updateCmd.Flags().StringSlice(FlagBridgeExecutors, nil, "Comma separated bridge executor addresses, replacing the current list")
+	updateCmd.Flags().Uint32(FlagMaxValidators, 0, "Maximum number of validators")
+	updateCmd.Flags().String(FlagHookMaxGas, "", "Maximum gas for hook execution of deposits")
+	updateCmd.Flags().Bool(FlagDryRun, false, "Only write and print the MsgUpdateParams messages file without broadcasting it")
+
+	return updateCmd
+}
+
+func minitiaValidateConfigCommand() *cobra.Command {
+	shortDescription := "Validate a rollup config file without launching"
+	validateCmd := &cobra.Command{
+		Use:   "validate-config <file>",
+		Short: shortDescription,
+		Long: fmt.Sprintf("%s.\n\nChecks every field of a config used with `weave rollup launch --with-config` and reports all problems with their JSON paths at once. VM specific rules are applied when --vm is set.\n\n%s",
+			shortDescription, RollupHelperText),
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			vm, _ := cmd.Flags().GetString(FlagVm)
+			if vm != "" {
+				return validateVMFlag(vm)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vm, _ := cmd.Flags().GetString(FlagVm)
+			keyFilePath, _ := cmd.Flags().GetString(FlagKeyFile)
+			configFormat, _ := cmd.Flags().GetString(FlagConfigFormat)
+			if _, err := loadAndValidateMinitiaConfig(args[0], configFormat, vm, keyFilePath); err != nil {
+				return err
+			}
+			fmt.Printf("%s is a valid rollup config\n", args[0])
+			return nil
+		},
+	}
+
+	validateCmd.Flags().String(FlagVm, "", fmt.Sprintf("VM the config will be launched with. Valid options are: %s", strings.Join(validVMOptions, ", ")))
+	validateCmd.Flags().String(FlagConfigFormat, "", "Format of the config file: json, yaml or toml. Detected from the file extension when omitted")
+	validateCmd.Flags().String(FlagKeyFile, "", "Encrypted key file of a launch template to restore the redacted mnemonics from")
+
+	return validateCmd
+}
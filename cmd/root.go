@@ -22,6 +22,12 @@ func Execute() error {
 			if err := config.InitializeConfig(); err != nil {
 				return err
 			}
+			// the keyring commands must work to fix a broken keyring config
+			if !isKeyringCommand(cmd) {
+				if err := configureKeyring(); err != nil {
+					return err
+				}
+			}
 			analytics.Initialize(Version)
 			return nil
 		},
@@ -42,6 +48,8 @@ func Execute() error {
 		OPInitBotsCommand(),
 		RelayerCommand(),
 		AnalyticsCommand(),
+		KeyringCommand(),
+		WatchCommand(),
 	)
 
 	return rootCmd.ExecuteContext(context.Background())
@@ -0,0 +1,513 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/watch"
+	"github.com/initia-labs/weave/service"
+)
+
+func WatchCommand() *cobra.Command {
+	shortDescription := "Watch the balances of the keys weave set up and the challenger, and alert on what needs attention"
+	cmd := &cobra.Command{
+		Use:                        "watch",
+		Short:                      shortDescription,
+		Long:                       fmt.Sprintf("%s.\n\n%s", shortDescription, WatchHelperText),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+	}
+
+	cmd.AddCommand(
+		WatchBalancesCommand(),
+		WatchThresholdsCommand(),
+		WatchSetThresholdCommand(),
+		WatchSetDailyCapCommand(),
+		WatchStartCommand(),
+		WatchStopCommand(),
+		WatchRestartCommand(),
+		WatchLogCommand(),
+		WatchChallengerCommand(),
+		WatchAlertsCommand(),
+		WatchAddAlertCommand(),
+		WatchRemoveAlertCommand(),
+		WatchTestAlertCommand(),
+	)
+
+	return cmd
+}
+
+func WatchBalancesCommand() *cobra.Command {
+	shortDescription := "Check the balances of the OPinit bot and relayer keys and top up the low ones"
+	balancesCmd := &cobra.Command{
+		Use:   "balances",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The keys of the OPinit bots are checked on the chains they pay fees on, and the weave-relayer keys of hermes on the L1 and the L2. A key below its threshold is topped up from the gas station, unless the daily cap of the chain would be exceeded. Every check and top-up is logged.
+eg. weave watch balances --once --dry-run
+
+%s`, shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			once, _ := cmd.Flags().GetBool(FlagOnce)
+			dryRun, _ := cmd.Flags().GetBool(FlagDryRun)
+
+			settings, err := watch.LoadSettings()
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(FlagInterval) {
+				interval, _ := cmd.Flags().GetDuration(FlagInterval)
+				settings.Interval = interval.String()
+			}
+			interval, err := settings.GetInterval()
+			if err != nil {
+				return err
+			}
+			if !dryRun {
+				// ask for the passphrase of the keyring the gas station signs from before logging starts
+				if err = cosmosutils.UnlockKeyring(); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			watcher, err := watch.NewWatcher(opInitHome, settings, dryRun, func(message string) {
+				fmt.Fprintln(out, message)
+			})
+			if err != nil {
+				return err
+			}
+			if once {
+				return watcher.Check()
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watcher.Run(ctx, interval)
+		},
+	}
+
+	addOPInitHomeFlag(balancesCmd)
+	balancesCmd.Flags().Duration(FlagInterval, 0, fmt.Sprintf("Time between checks. Defaults to the configured interval, or %s", watch.DefaultInterval))
+	balancesCmd.Flags().Bool(FlagOnce, false, "Check the balances once and exit")
+	balancesCmd.Flags().Bool(FlagDryRun, false, "Report the top-ups without sending them")
+	return balancesCmd
+}
+
+func WatchThresholdsCommand() *cobra.Command {
+	shortDescription := "Show the thresholds, top-up amounts and daily caps of the balance watch"
+	thresholdsCmd := &cobra.Command{
+		Use:   "thresholds",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nAmounts are in the gas denom of the chain of the key.\n\n%s", shortDescription, WatchHelperText),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			settings, err := watch.LoadSettings()
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				bz, err := json.MarshalIndent(settings, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal the watch settings: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), settings.String())
+			return nil
+		},
+	}
+
+	thresholdsCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+	return thresholdsCmd
+}
+
+func WatchSetThresholdCommand() *cobra.Command {
+	shortDescription := "Set the threshold and top-up amount of a watched key"
+	setCmd := &cobra.Command{
+		Use:   "set-threshold [role/chain]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The key is one of [%s]. A top-up of 0 only reports the low balance.
+eg. weave watch set-threshold output-submitter/l1 --min 5000000 --top-up 10000000
+
+%s`, shortDescription, thresholdKeysText(), WatchHelperText),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := strings.ToLower(args[0])
+			settings, err := watch.LoadSettings()
+			if err != nil {
+				return err
+			}
+			threshold, ok := settings.Thresholds[key]
+			if !ok {
+				return fmt.Errorf("unknown key '%s'. Valid options are: [%s]", args[0], thresholdKeysText())
+			}
+			if !cmd.Flags().Changed(FlagMin) && !cmd.Flags().Changed(FlagTopUp) {
+				return fmt.Errorf("set --%s, --%s or both", FlagMin, FlagTopUp)
+			}
+			if cmd.Flags().Changed(FlagMin) {
+				threshold.Min, _ = cmd.Flags().GetString(FlagMin)
+			}
+			if cmd.Flags().Changed(FlagTopUp) {
+				threshold.TopUp, _ = cmd.Flags().GetString(FlagTopUp)
+			}
+			if err = watch.SetThreshold(key, threshold); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s is topped up with %s once below %s\n", key, threshold.TopUp, threshold.Min)
+			return nil
+		},
+	}
+
+	setCmd.Flags().String(FlagMin, "", "Balance under which the key is topped up")
+	setCmd.Flags().String(FlagTopUp, "", "Amount the key is topped up with, 0 to only report the low balance")
+	return setCmd
+}
+
+func WatchSetDailyCapCommand() *cobra.Command {
+	shortDescription := "Set what the gas station may send per day on a chain"
+	setCmd := &cobra.Command{
+		Use:   "set-daily-cap [l1|l2|celestia] [amount]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The amount is in the gas denom of the chain, and the day is the UTC day. A cap of 0 stops the top-ups on the chain.
+eg. weave watch set-daily-cap l1 50000000
+
+%s`, shortDescription, WatchHelperText),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := watch.SetDailyCap(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "The gas station sends at most %s per day on %s\n", args[1], args[0])
+			return nil
+		},
+	}
+
+	return setCmd
+}
+
+func thresholdKeysText() string {
+	return strings.Join(watch.ThresholdKeys(), ", ")
+}
+
+func WatchStartCommand() *cobra.Command {
+	shortDescription := "Start the balance watch service"
+	startCmd := &cobra.Command{
+		Use:   "start",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe service runs `weave watch balances` with the weave binary running this command. It is created on the first start, or again when --%s is given.\n\n%s", shortDescription, FlagOPInitHome, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			detach, err := cmd.Flags().GetBool(FlagDetach)
+			if err != nil {
+				return err
+			}
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			if err = watch.CheckServiceKeyring(); err != nil {
+				return err
+			}
+
+			var s service.Service
+			serviceFilePath, err := service.GetServiceFilePath(service.WatchBalances)
+			if err != nil {
+				return err
+			}
+			if !io.FileOrFolderExists(serviceFilePath) || cmd.Flags().Changed(FlagOPInitHome) {
+				s, err = watch.InstallService(opInitHome)
+			} else {
+				s, err = service.NewService(service.WatchBalances)
+			}
+			if err != nil {
+				return err
+			}
+
+			if detach {
+				err = s.Start()
+				if err != nil {
+					return err
+				}
+				fmt.Println("Started the balance watch service. You can see the logs with `weave watch log`")
+				return nil
+			}
+
+			return service.NonDetachStart(s)
+		},
+	}
+
+	addOPInitHomeFlag(startCmd)
+	startCmd.Flags().BoolP(FlagDetach, "d", false, "Run the balance watch service in detached mode")
+	return startCmd
+}
+
+func WatchStopCommand() *cobra.Command {
+	shortDescription := "Stop the balance watch service"
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := service.NewService(service.WatchBalances)
+			if err != nil {
+				return err
+			}
+			err = s.Stop()
+			if err != nil {
+				return err
+			}
+			fmt.Println("Stopped the balance watch service.")
+			return nil
+		},
+	}
+
+	return stopCmd
+}
+
+func WatchRestartCommand() *cobra.Command {
+	shortDescription := "Restart the balance watch service"
+	restartCmd := &cobra.Command{
+		Use:   "restart",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := watch.CheckServiceKeyring(); err != nil {
+				return err
+			}
+			s, err := service.NewService(service.WatchBalances)
+			if err != nil {
+				return err
+			}
+			err = s.Restart()
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("Started the balance watch service. You can see the logs with `weave watch log`")
+			return nil
+		},
+	}
+
+	return restartCmd
+}
+
+func WatchLogCommand() *cobra.Command {
+	shortDescription := "Stream the logs of the balance watch service"
+	logCmd := &cobra.Command{
+		Use:   "log",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := cmd.Flags().GetInt(FlagN)
+			if err != nil {
+				return err
+			}
+
+			s, err := service.NewService(service.WatchBalances)
+			if err != nil {
+				return err
+			}
+			return s.Log(n)
+		},
+	}
+
+	logCmd.Flags().IntP(FlagN, FlagN, 100, "previous log lines to show")
+	return logCmd
+}
+
+func WatchChallengerCommand() *cobra.Command {
+	shortDescription := "Watch the challenger for challenges, deleted outputs and errors, and fire the alert hooks"
+	challengerCmd := &cobra.Command{
+		Use:   "challenger",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+The HTTP API of the challenger is polled for new challenges, the L1 for outputs of the rollup being deleted, and the logs of the challenger service for errors. Challenges made before the watch started are not alerted. Set up where the alerts go with `+"`weave watch add-alert`"+`.
+eg. weave watch challenger --interval 1m
+
+%s`, shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opInitHome, _ := cmd.Flags().GetString(FlagOPInitHome)
+			interval, _ := cmd.Flags().GetDuration(FlagInterval)
+			if interval <= 0 {
+				return fmt.Errorf("invalid interval '%s', expected a positive duration such as 30s", interval)
+			}
+			once, _ := cmd.Flags().GetBool(FlagOnce)
+
+			hooks, err := watch.LoadAlertHooks()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(hooks) == 0 {
+				fmt.Fprintln(out, "No alert hook is set up, the alerts are only logged. Add one with `weave watch add-alert`")
+			}
+			watcher, err := watch.NewChallengerWatcher(opInitHome, hooks, func(message string) {
+				fmt.Fprintln(out, message)
+			})
+			if err != nil {
+				return err
+			}
+			if once {
+				return watcher.Check()
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watcher.Run(ctx, interval)
+		},
+	}
+
+	addOPInitHomeFlag(challengerCmd)
+	challengerCmd.Flags().Duration(FlagInterval, watch.DefaultChallengerInterval, "Time between checks")
+	challengerCmd.Flags().Bool(FlagOnce, false, "Check the challenger once and exit")
+	return challengerCmd
+}
+
+func WatchAlertsCommand() *cobra.Command {
+	shortDescription := "List the alert hooks fired by the challenger watch"
+	alertsCmd := &cobra.Command{
+		Use:   "alerts",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\n%s", shortDescription, WatchHelperText),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format: %s. Valid options are: text, json", output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString(FlagOutput)
+			hooks, err := watch.LoadAlertHooks()
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				bz, err := json.MarshalIndent(hooks, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal the alert hooks: %v", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+				return nil
+			}
+			if len(hooks) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No alert hook is set up. Add one with `weave watch add-alert`")
+				return nil
+			}
+			for i, hook := range hooks {
+				fmt.Fprintf(cmd.OutOrStdout(), "%d. %s\n", i+1, hook)
+			}
+			return nil
+		},
+	}
+
+	alertsCmd.Flags().StringP(FlagOutput, "o", "text", "Output format. Valid options are: text, json")
+	return alertsCmd
+}
+
+func WatchAddAlertCommand() *cobra.Command {
+	shortDescription := "Add a hook fired on the alerts of the challenger watch"
+	addCmd := &cobra.Command{
+		Use:   "add-alert [webhook|exec|desktop] [target]",
+		Short: shortDescription,
+		Long: fmt.Sprintf(`%s.
+
+A webhook is sent the event as a JSON POST body. An exec hook runs its command with sh, the event as JSON on its stdin and in the WEAVE_EVENT_TYPE and WEAVE_EVENT_MESSAGE environment variables. A desktop hook shows a notification, with notify-send on Linux and osascript on macOS, and takes no target.
+The events are [%s], a hook without --%s is fired on all of them.
+eg. weave watch add-alert webhook https://hooks.example.com/weave --events challenge,output_deleted
+    weave watch add-alert exec "logger -t weave" --events bot_error
+
+%s`, shortDescription, strings.Join(watch.EventTypes, ", "), FlagEvents, WatchHelperText),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hook := watch.AlertHook{Type: strings.ToLower(args[0])}
+			if len(args) == 2 {
+				hook.Target = args[1]
+			}
+			hook.Events, _ = cmd.Flags().GetStringSlice(FlagEvents)
+			if err := watch.AddAlertHook(hook); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Added the %s. Try it with `weave watch test-alert`\n", hook)
+			return nil
+		},
+	}
+
+	addCmd.Flags().StringSlice(FlagEvents, nil, fmt.Sprintf("Comma separated events the hook is fired on. Valid options are: %s", strings.Join(watch.EventTypes, ", ")))
+	return addCmd
+}
+
+func WatchRemoveAlertCommand() *cobra.Command {
+	shortDescription := "Remove an alert hook"
+	removeCmd := &cobra.Command{
+		Use:   "remove-alert [number]",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe number is the one listed by `weave watch alerts`.\n\n%s", shortDescription, WatchHelperText),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid number '%s', see `weave watch alerts`", args[0])
+			}
+			removed, err := watch.RemoveAlertHook(index)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed the %s\n", removed)
+			return nil
+		},
+	}
+
+	return removeCmd
+}
+
+func WatchTestAlertCommand() *cobra.Command {
+	shortDescription := "Fire a sample event to every alert hook"
+	testCmd := &cobra.Command{
+		Use:   "test-alert",
+		Short: shortDescription,
+		Long:  fmt.Sprintf("%s.\n\nThe sample event has the type test and is fired to every hook, whatever events it is limited to.\n\n%s", shortDescription, WatchHelperText),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hooks, err := watch.LoadAlertHooks()
+			if err != nil {
+				return err
+			}
+			if len(hooks) == 0 {
+				return fmt.Errorf("no alert hook is set up, add one with `weave watch add-alert`")
+			}
+			out := cmd.OutOrStdout()
+			notifier := watch.NewNotifier(hooks, func(message string) {
+				fmt.Fprintln(out, message)
+			})
+			if failed := notifier.Notify(watch.SampleEvent(time.Now())); failed > 0 {
+				return fmt.Errorf("%d of %d alert hooks failed", failed, len(hooks))
+			}
+			fmt.Fprintln(out, "Fired the sample event to every alert hook")
+			return nil
+		},
+	}
+
+	return testCmd
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"net"
 	"os"
 	"strings"
 
@@ -49,3 +50,23 @@ func WrapTextWithLimit(text string, limit int) string {
 	result = append(result, text)
 	return strings.Join(result, "\n")
 }
+
+// ListenAddressToEndpoint turns a node listen address like tcp://0.0.0.0:26657 into an address reachable from this
+// machine, prefixed with scheme
+func ListenAddressToEndpoint(laddr, scheme, fallback string) string {
+	if laddr == "" {
+		return fallback
+	}
+	hostPort := laddr
+	if idx := strings.Index(hostPort, "://"); idx >= 0 {
+		hostPort = hostPort[idx+3:]
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return fallback
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return scheme + net.JoinHostPort(host, port)
+}
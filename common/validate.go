@@ -102,6 +102,15 @@ func ValidateDecCoin(coinStr string) (err error) {
 	return nil
 }
 
+// ParseDecCoin validates coinStr like ValidateDecCoin and splits it into its amount and denom
+func ParseDecCoin(coinStr string) (amount, denom string, err error) {
+	if err = ValidateDecCoin(coinStr); err != nil {
+		return "", "", err
+	}
+	matches := reDecCoin.FindStringSubmatch(strings.TrimSpace(coinStr))
+	return matches[1], matches[2], nil
+}
+
 func ValidateDecFromStr(str string) error {
 	if str[0] == '-' {
 		return fmt.Errorf("decimal string cannot be positve")
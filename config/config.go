@@ -120,4 +120,34 @@ func SetAnalyticsOptOut(optOut bool) error {
 	return SetConfig("common.analytics_opt_out", optOut)
 }
 
+// GetKeyringBackend returns the backend of the keyring weave keeps its keys in, test when it is not configured
+func GetKeyringBackend() string {
+	if backend, ok := GetConfig("common.keyring_backend").(string); ok && backend != "" {
+		return backend
+	}
+	return "test"
+}
+
+// GetKeyringPassphraseSource returns the env:NAME or file:PATH reference to the passphrase of the file keyring backend
+func GetKeyringPassphraseSource() string {
+	source, _ := GetConfig("common.keyring_passphrase").(string)
+	return source
+}
+
+func SetKeyringBackend(backend, passphraseSource string) error {
+	viper.Set("common.keyring_backend", backend)
+	viper.Set("common.keyring_passphrase", passphraseSource)
+	return WriteConfig()
+}
+
+// GetOPInitBotsVersion returns the opinitd version pinned with `weave opinit version use`, empty when none is
+func GetOPInitBotsVersion() string {
+	version, _ := GetConfig("opinit.version").(string)
+	return version
+}
+
+func SetOPInitBotsVersion(version string) error {
+	return SetConfig("opinit.version", version)
+}
+
 const DefaultConfigTemplate = `{}`
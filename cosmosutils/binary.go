@@ -113,8 +113,13 @@ func ListWeaveReleases(url string) (BinaryVersionWithDownloadURL, error) {
 	return versions, nil
 }
 
+// GetMinitiaReleasesURL returns the GitHub releases API of mini<vm>
+func GetMinitiaReleasesURL(vm string) string {
+	return fmt.Sprintf("https://api.github.com/repos/initia-labs/mini%s/releases", vm)
+}
+
 func GetLatestMinitiaVersion(vm string) (string, string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/initia-labs/mini%s/releases", vm)
+	url := GetMinitiaReleasesURL(vm)
 	releases, err := fetchReleases(url)
 	if err != nil {
 		return "", "", err
@@ -265,6 +270,11 @@ func GetInitiaBinaryUrlFromLcd(httpClient *client.HTTPClient, rest string) (stri
 	return version, url, nil
 }
 
+// GetInitiaBinaryURL returns the release download URL of initiad for the current OS and architecture
+func GetInitiaBinaryURL(version string) (string, error) {
+	return getBinaryURL(version)
+}
+
 func getBinaryURL(version string) (string, error) {
 	goos := runtime.GOOS
 	goarch := runtime.GOARCH
@@ -346,6 +356,76 @@ func InstallInitiaBinary(version, url, binaryPath string) error {
 	return io.SetLibraryPaths(filepath.Dir(binaryPath))
 }
 
+// GetMinitiaBinaryURL looks up the release download URL of mini<vm> at the given version
+func GetMinitiaBinaryURL(vm, version string) (string, error) {
+	versions, err := ListBinaryReleases(GetMinitiaReleasesURL(vm))
+	if err != nil {
+		return "", err
+	}
+
+	url, ok := versions[version]
+	if !ok {
+		return "", fmt.Errorf("mini%s %s is not available for this platform", vm, version)
+	}
+
+	return url, nil
+}
+
+func GetMinitiaBinaryPath(vm, version string) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	extractedPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("mini%s@%s", vm, version))
+
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(extractedPath, fmt.Sprintf("mini%s_%s", vm, version), "minitiad"), nil
+	case "darwin":
+		return filepath.Join(extractedPath, "minitiad"), nil
+	default:
+		return "", fmt.Errorf("unsupported OS: %v", runtime.GOOS)
+	}
+}
+
+// InstallMinitiaBinary downloads the mini<vm> release at url to binaryPath, see GetMinitiaBinaryPath, unless it is
+// already installed, and points the library path to it for the VMs that ship libraries. downloaded tells whether it
+// was not installed yet.
+func InstallMinitiaBinary(vm, version, url, binaryPath string) (downloaded bool, err error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return false, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	tarballPath := filepath.Join(userHome, common.WeaveDataDirectory, "minitia.tar.gz")
+	extractedPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("mini%s@%s", vm, version))
+
+	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
+		if _, err := os.Stat(extractedPath); os.IsNotExist(err) {
+			err := os.MkdirAll(extractedPath, os.ModePerm)
+			if err != nil {
+				return false, fmt.Errorf("failed to create weave data directory: %v", err)
+			}
+		}
+
+		if err = io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+			return false, fmt.Errorf("failed to download and extract binary: %v", err)
+		}
+
+		err = os.Chmod(binaryPath, 0755)
+		if err != nil {
+			return false, fmt.Errorf("failed to set permissions for binary: %v", err)
+		}
+		downloaded = true
+	}
+
+	if vm == "move" || vm == "wasm" {
+		if err = io.SetLibraryPaths(filepath.Dir(binaryPath)); err != nil {
+			return downloaded, fmt.Errorf("failed to set library path: %v", err)
+		}
+	}
+	return downloaded, nil
+}
+
 func InstallCosmovisor(version string) (string, error) {
 	userHome, err := os.UserHomeDir()
 	if err != nil {
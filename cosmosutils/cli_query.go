@@ -3,14 +3,9 @@ package cosmosutils
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
-	"runtime"
 
 	"github.com/initia-labs/weave/client"
-	"github.com/initia-labs/weave/common"
-	"github.com/initia-labs/weave/io"
 	"github.com/initia-labs/weave/types"
 )
 
@@ -43,6 +38,14 @@ func NewInitiadQuerier(rest string) (*InitiadQuerier, error) {
 	}, nil
 }
 
+// NewInitiadQuerierFromBinary queries through an installed binary that has the query commands of initiad, such as
+// celestia-appd
+func NewInitiadQuerierFromBinary(binaryPath string) *InitiadQuerier {
+	return &InitiadQuerier{
+		binaryPath: binaryPath,
+	}
+}
+
 type InitiadBankBalancesQueryResponse struct {
 	Balances Coins `json:"balances"`
 }
@@ -74,40 +77,12 @@ func NewMinitiadQuerier() (*MinitiadQuerier, error) {
 		return nil, err
 	}
 
-	userHome, err := os.UserHomeDir()
+	binaryPath, err := GetMinitiaBinaryPath(DefaultMinitiadQuerierVM, version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home dir: %v", err)
-	}
-	weaveDataPath := filepath.Join(userHome, common.WeaveDataDirectory)
-	tarballPath := filepath.Join(weaveDataPath, "minitia.tar.gz")
-	extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("mini%s@%s", DefaultMinitiadQuerierVM, version))
-
-	var binaryPath string
-	switch runtime.GOOS {
-	case "linux":
-		binaryPath = filepath.Join(extractedPath, fmt.Sprintf("mini%s_%s", DefaultMinitiadQuerierVM, version), DefaultMinitiadQuerierAppName)
-	case "darwin":
-		binaryPath = filepath.Join(extractedPath, DefaultMinitiadQuerierAppName)
-	default:
-		return nil, fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+		return nil, err
 	}
-
-	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
-		if _, err := os.Stat(extractedPath); os.IsNotExist(err) {
-			err := os.MkdirAll(extractedPath, os.ModePerm)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create weave data directory: %v", err)
-			}
-		}
-
-		if err = io.DownloadAndExtractTarGz(downloadURL, tarballPath, extractedPath); err != nil {
-			return nil, fmt.Errorf("failed to download minitia binary: %v", err)
-		}
-
-		err = os.Chmod(binaryPath, 0755)
-		if err != nil {
-			return nil, fmt.Errorf("failed to set permissions for binary: %v", err)
-		}
+	if _, err = InstallMinitiaBinary(DefaultMinitiadQuerierVM, version, downloadURL, binaryPath); err != nil {
+		return nil, err
 	}
 
 	return &MinitiadQuerier{binaryPath: binaryPath}, nil
@@ -1,10 +1,15 @@
 package cosmosutils
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/initia-labs/weave/client"
@@ -107,6 +112,14 @@ func NewInitiadTxExecutor(rest string) (*InitiadTxExecutor, error) {
 	}, nil
 }
 
+// NewInitiadTxExecutorFromBinary signs and broadcasts through an installed binary that has the tx commands of
+// initiad, such as celestia-appd
+func NewInitiadTxExecutorFromBinary(binaryPath string) *InitiadTxExecutor {
+	return &InitiadTxExecutor{
+		binaryPath: binaryPath,
+	}
+}
+
 func (te *InitiadTxExecutor) BroadcastMsgSend(senderMnemonic, recipientAddress, amount, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
 	_, err := RecoverKeyFromMnemonic(te.binaryPath, TmpKeyName, senderMnemonic)
 	if err != nil {
@@ -116,9 +129,16 @@ func (te *InitiadTxExecutor) BroadcastMsgSend(senderMnemonic, recipientAddress,
 		_ = DeleteKey(te.binaryPath, TmpKeyName)
 	}()
 
-	cmd := exec.Command(te.binaryPath, "tx", "bank", "send", TmpKeyName, recipientAddress, amount, "--from",
+	keyring := DefaultKeyring()
+	input, err := keyring.Input(te.binaryPath)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"tx", "bank", "send", TmpKeyName, recipientAddress, amount, "--from",
 		TmpKeyName, "--chain-id", chainId, "--gas", "auto", "--gas-adjustment", DefaultGasAdjustment,
-		"--gas-prices", gasPrices, "--node", rpc, "--output", "json", "--keyring-backend", "test", "-y")
+		"--gas-prices", gasPrices, "--node", rpc, "--output", "json", "-y"}, keyring.Args()...)
+	cmd := exec.Command(te.binaryPath, args...)
+	cmd.Stdin = strings.NewReader(input)
 
 	outputBytes, err := cmd.Output()
 	if err != nil {
@@ -144,6 +164,170 @@ func (te *InitiadTxExecutor) BroadcastMsgSend(senderMnemonic, recipientAddress,
 }
 
 func (te *InitiadTxExecutor) waitForTransactionInclusion(rpcURL, txHash string) error {
+	return waitForTransactionInclusion(te.binaryPath, rpcURL, txHash)
+}
+
+// NewMsgSend returns a bank MsgSend as it is written in an unsigned tx
+func NewMsgSend(from, to string, amount Coins) map[string]interface{} {
+	return map[string]interface{}{
+		"@type":        "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": from,
+		"to_address":   to,
+		"amount":       amount,
+	}
+}
+
+// WriteUnsignedTx writes a tx with the messages and a fixed fee and gas limit to txPath, in the format `tx sign` reads
+func WriteUnsignedTx(txPath string, messages []map[string]interface{}, fee Coin, gasLimit uint64) error {
+	tx := map[string]interface{}{
+		"body": map[string]interface{}{
+			"messages":                       messages,
+			"memo":                           "",
+			"timeout_height":                 "0",
+			"extension_options":              []interface{}{},
+			"non_critical_extension_options": []interface{}{},
+		},
+		"auth_info": map[string]interface{}{
+			"signer_infos": []interface{}{},
+			"fee": map[string]interface{}{
+				"amount":    Coins{fee},
+				"gas_limit": fmt.Sprintf("%d", gasLimit),
+				"payer":     "",
+				"granter":   "",
+			},
+			"tip": nil,
+		},
+		"signatures": []interface{}{},
+	}
+	bz, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal unsigned tx: %v", err)
+	}
+	if err = os.WriteFile(txPath, bz, 0600); err != nil {
+		return fmt.Errorf("failed to write unsigned tx: %v", err)
+	}
+	return nil
+}
+
+// SignAndBroadcastFromKeyring signs the unsigned tx in txPath with the from key of the keyring, e.g. the test keyring
+// of the OPinit bots or the default keyring of the initiad home. The tx is broadcast and waited for until it is
+// included.
+func (te *InitiadTxExecutor) SignAndBroadcastFromKeyring(txPath string, keyring Keyring, from, rpc, chainId string) (*InitiadTxResponse, error) {
+	if err := te.sign(txPath, txPath, keyring, from, rpc, chainId); err != nil {
+		return nil, err
+	}
+
+	outputBytes, err := exec.Command(te.binaryPath, "tx", "broadcast", txPath, "--node", rpc, "--output", "json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast tx signed by %s: %v, output: %s", from, err, string(outputBytes))
+	}
+
+	var txResponse InitiadTxResponse
+	if err = json.Unmarshal(outputBytes, &txResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+	if txResponse.Code != 0 {
+		return nil, fmt.Errorf("tx failed with error: %v", txResponse.RawLog)
+	}
+
+	if err = te.waitForTransactionInclusion(rpc, txResponse.TxHash); err != nil {
+		return nil, err
+	}
+	return &txResponse, nil
+}
+
+// SimulateFromKeyring signs a copy of the unsigned tx in txPath with the from key of the keyring and simulates it on
+// the node behind lcd. It returns the gas used raised by DefaultGasAdjustment, the gas limit to sign the tx with.
+func (te *InitiadTxExecutor) SimulateFromKeyring(txPath string, keyring Keyring, from, rpc, lcd, chainId string) (uint64, error) {
+	signedFile, err := os.CreateTemp(filepath.Dir(txPath), "simulate_tx_*.json")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create simulated tx file: %v", err)
+	}
+	signedPath := signedFile.Name()
+	_ = signedFile.Close()
+	defer func() {
+		_ = os.Remove(signedPath)
+	}()
+	if err = te.sign(txPath, signedPath, keyring, from, rpc, chainId); err != nil {
+		return 0, err
+	}
+
+	encoded, err := exec.Command(te.binaryPath, "tx", "encode", signedPath).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode tx: %v, output: %s", err, string(encoded))
+	}
+	body, err := json.Marshal(map[string]string{"tx_bytes": strings.TrimSpace(string(encoded))})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal simulate request: %v", err)
+	}
+	var res struct {
+		GasInfo struct {
+			GasUsed string `json:"gas_used"`
+		} `json:"gas_info"`
+	}
+	if _, err = client.NewHTTPClient().Post(lcd, "/cosmos/tx/v1beta1/simulate", map[string]string{"Content-Type": "application/json"}, body, &res); err != nil {
+		return 0, fmt.Errorf("failed to simulate tx: %v", err)
+	}
+	gasUsed, err := strconv.ParseUint(res.GasInfo.GasUsed, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid simulated gas %q: %v", res.GasInfo.GasUsed, err)
+	}
+	return adjustGas(gasUsed)
+}
+
+// adjustGas raises the simulated gas by DefaultGasAdjustment, as --gas auto does
+func adjustGas(gasUsed uint64) (uint64, error) {
+	adjustment, err := strconv.ParseFloat(DefaultGasAdjustment, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gas adjustment %s: %v", DefaultGasAdjustment, err)
+	}
+	return uint64(math.Ceil(float64(gasUsed) * adjustment)), nil
+}
+
+// sign signs the unsigned tx in txPath with the from key of the keyring into outputPath
+func (te *InitiadTxExecutor) sign(txPath, outputPath string, keyring Keyring, from, rpc, chainId string) error {
+	input, err := keyring.Input(te.binaryPath)
+	if err != nil {
+		return err
+	}
+	args := append([]string{"tx", "sign", txPath, "--from", from, "--node", rpc, "--chain-id", chainId,
+		"--output-document", outputPath}, keyring.Args()...)
+	signCmd := exec.Command(te.binaryPath, args...)
+	signCmd.Stdin = strings.NewReader(input)
+	if outputBytes, err := signCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to sign tx with %s: %v, output: %s", from, err, string(outputBytes))
+	}
+	return nil
+}
+
+// WithMnemonicKey calls fn with the key of the mnemonic, which is only kept in the keyring for the duration of the call
+func (te *InitiadTxExecutor) WithMnemonicKey(mnemonic string, fn func(keyring Keyring, from string) error) error {
+	_, err := RecoverKeyFromMnemonic(te.binaryPath, TmpKeyName, mnemonic)
+	if err != nil {
+		return fmt.Errorf("failed to recover signer key: %v", err)
+	}
+	defer func() {
+		_ = DeleteKey(te.binaryPath, TmpKeyName)
+	}()
+	return fn(DefaultKeyring(), TmpKeyName)
+}
+
+// KeyAddress returns the address of the key name in the keyring
+func (te *InitiadTxExecutor) KeyAddress(keyring Keyring, name string) (string, error) {
+	input, err := keyring.Input(te.binaryPath)
+	if err != nil {
+		return "", err
+	}
+	showCmd := exec.Command(te.binaryPath, append([]string{"keys", "show", name, "--address"}, keyring.Args()...)...)
+	showCmd.Stdin = strings.NewReader(input)
+	outputBytes, err := showCmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get address for key %s: %v, output: %s", name, err, string(outputBytes))
+	}
+	return strings.TrimSpace(string(outputBytes)), nil
+}
+
+func waitForTransactionInclusion(binaryPath, rpcURL, txHash string) error {
 	// Poll for transaction status until it's included in a block
 	timeout := time.After(15 * time.Second)   // Example timeout for polling
 	ticker := time.NewTicker(3 * time.Second) // Poll every 3 seconds
@@ -155,7 +339,7 @@ func (te *InitiadTxExecutor) waitForTransactionInclusion(rpcURL, txHash string)
 			return fmt.Errorf("transaction not included in block within timeout")
 		case <-ticker.C:
 			// Query transaction status
-			statusCmd := exec.Command(te.binaryPath, "query", "tx", txHash, "--node", rpcURL, "--output", "json")
+			statusCmd := exec.Command(binaryPath, "query", "tx", txHash, "--node", rpcURL, "--output", "json")
 			statusRes, err := statusCmd.CombinedOutput()
 			// If the transaction is not included in a block yet, just continue polling
 			if err != nil {
@@ -180,6 +364,61 @@ func (te *InitiadTxExecutor) waitForTransactionInclusion(rpcURL, txHash string)
 	}
 }
 
+// MinitiadTxExecutor signs and broadcasts transactions on a rollup with keys stored in the rollup home
+type MinitiadTxExecutor struct {
+	binaryPath string
+	home       string
+}
+
+func NewMinitiadTxExecutor(binaryPath, home string) *MinitiadTxExecutor {
+	return &MinitiadTxExecutor{
+		binaryPath: binaryPath,
+		home:       home,
+	}
+}
+
+// ExecuteMessages runs the messages in messagesPath through `tx opchild execute-messages` with the from key of the
+// keyring, which must be the operator, and waits for the transaction to be included
+func (te *MinitiadTxExecutor) ExecuteMessages(messagesPath string, keyring Keyring, from, gasPrices, rpc, chainId string) (*InitiadTxResponse, error) {
+	input, err := keyring.Input(te.binaryPath)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"tx", "opchild", "execute-messages", messagesPath, "--from", from,
+		"--home", te.home, "--chain-id", chainId, "--gas", "auto", "--gas-adjustment", DefaultGasAdjustment,
+		"--node", rpc, "--output", "json", "-y"}, keyring.Args()...)
+	if gasPrices != "" {
+		args = append(args, "--gas-prices", gasPrices)
+	}
+
+	// the simulation of --gas auto and the signing report their failures on stderr only
+	var stderr bytes.Buffer
+	cmd := exec.Command(te.binaryPath, args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = &stderr
+	outputBytes, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute messages with %s: %v, output: %s, stderr: %s", from, err, string(outputBytes), strings.TrimSpace(stderr.String()))
+	}
+
+	var txResponse InitiadTxResponse
+	err = json.Unmarshal(outputBytes, &txResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+
+	if txResponse.Code != 0 {
+		return nil, fmt.Errorf("tx failed with error: %v", txResponse.RawLog)
+	}
+
+	err = waitForTransactionInclusion(te.binaryPath, rpc, txResponse.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txResponse, nil
+}
+
 type HermesTxExecutor struct {
 	binaryPath string
 }
@@ -1,10 +1,13 @@
 package cosmosutils
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/types"
 )
 
 const (
@@ -53,3 +56,118 @@ func QueryOPChildParams(address string) (params OPChildParams, err error) {
 
 	return res.Params, nil
 }
+
+type ABCIInfoResponse struct {
+	Result struct {
+		Response struct {
+			Data            string `json:"data"`
+			Version         string `json:"version"`
+			LastBlockHeight string `json:"last_block_height"`
+		} `json:"response"`
+	} `json:"result"`
+}
+
+// QueryABCIInfo queries the application info served by a node's RPC endpoint
+func QueryABCIInfo(rpc string) (*ABCIInfoResponse, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res ABCIInfoResponse
+	if _, err := httpClient.Get(rpc, "/abci_info", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// QueryLastOutputProposal returns the latest output submitted to the L1 for the bridge, or nil when no output has
+// been submitted yet
+func QueryLastOutputProposal(rest, bridgeId string) (*OutputProposal, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res OutputProposalsResponse
+	_, err := httpClient.Get(
+		rest,
+		fmt.Sprintf("/opinit/ophost/v1/bridges/%s/outputs", bridgeId),
+		map[string]string{"pagination.limit": "1", "pagination.reverse": "true"},
+		&res,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.OutputProposals) == 0 {
+		return nil, nil
+	}
+
+	return &res.OutputProposals[0], nil
+}
+
+// QueryLatestHeight returns the latest block height of the node serving rpc
+func QueryLatestHeight(rpc string) (int64, error) {
+	info, err := QueryABCIInfo(rpc)
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(info.Result.Response.LastBlockHeight, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block height %q: %v", info.Result.Response.LastBlockHeight, err)
+	}
+	return height, nil
+}
+
+// QueryBridge returns the bridge with its config as registered on the L1
+func QueryBridge(rest, bridgeId string) (*types.Bridge, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res types.Bridge
+	if _, err := httpClient.Get(rest, fmt.Sprintf("/opinit/ophost/v1/bridges/%s", bridgeId), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// QueryOutputProposal returns the output with the given index submitted to the L1 for the bridge
+func QueryOutputProposal(rest, bridgeId string, outputIndex uint64) (*OutputProposal, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res OutputProposal
+	if _, err := httpClient.Get(rest, fmt.Sprintf("/opinit/ophost/v1/bridges/%s/outputs/%d", bridgeId, outputIndex), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// QueryNextL1Sequence returns the sequence the next deposit to the bridge will get on the L1
+func QueryNextL1Sequence(rest, bridgeId string) (uint64, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res NextL1SequenceResponse
+	if _, err := httpClient.Get(rest, fmt.Sprintf("/opinit/ophost/v1/bridges/%s/next_l1_sequence", bridgeId), nil, &res); err != nil {
+		return 0, err
+	}
+	sequence, err := strconv.ParseUint(res.NextL1Sequence, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid next L1 sequence %q: %v", res.NextL1Sequence, err)
+	}
+
+	return sequence, nil
+}
+
+// QueryWithdrawalClaimed reports whether the withdrawal with the given hash was already finalized on the L1
+func QueryWithdrawalClaimed(rest, bridgeId string, withdrawalHash []byte) (bool, error) {
+	httpClient := client.NewHTTPClient()
+
+	var res WithdrawalClaimedResponse
+	_, err := httpClient.Get(
+		rest,
+		fmt.Sprintf("/opinit/ophost/v1/bridges/%s/withdrawals/claimed/by_hash", bridgeId),
+		map[string]string{"withdrawal_hash": base64.StdEncoding.EncodeToString(withdrawalHash)},
+		&res,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Claimed, nil
+}
@@ -0,0 +1,164 @@
+package cosmosutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/initia-labs/weave/common"
+)
+
+type KeyringBackend string
+
+const (
+	// KeyringBackendTest stores the keys unencrypted on disk
+	KeyringBackendTest KeyringBackend = "test"
+	// KeyringBackendFile stores the keys on disk encrypted with a passphrase
+	KeyringBackendFile KeyringBackend = "file"
+	// KeyringBackendOS stores the keys in the credential store of the operating system
+	KeyringBackendOS KeyringBackend = "os"
+
+	KeyringPassphraseEnv = "WEAVE_KEYRING_PASSPHRASE"
+)
+
+var KeyringBackends = []KeyringBackend{KeyringBackendTest, KeyringBackendFile, KeyringBackendOS}
+
+func ParseKeyringBackend(value string) (KeyringBackend, error) {
+	for _, backend := range KeyringBackends {
+		if string(backend) == value {
+			return backend, nil
+		}
+	}
+	return "", fmt.Errorf("invalid keyring backend: %s. Valid options are: [test, file, os]", value)
+}
+
+// Keyring is where initiad, minitiad and celestia-appd find the keys weave signs with
+type Keyring struct {
+	Backend KeyringBackend
+	// Dir is passed as --keyring-dir, the keyring of the home of the binary is used when empty
+	Dir string
+}
+
+var keyringConfig = struct {
+	sync.Mutex
+	backend          KeyringBackend
+	passphraseSource func() (string, error)
+	passphrase       string
+}{backend: KeyringBackendTest}
+
+// SetKeyringBackend sets the backend of the keyring the key commands use. With the file backend, passphraseSource is
+// called the first time the passphrase is needed.
+func SetKeyringBackend(backend KeyringBackend, passphraseSource func() (string, error)) {
+	keyringConfig.Lock()
+	defer keyringConfig.Unlock()
+	keyringConfig.backend = backend
+	keyringConfig.passphraseSource = passphraseSource
+	keyringConfig.passphrase = ""
+}
+
+// DefaultKeyring returns the keyring of the home of the binary with the configured backend
+func DefaultKeyring() Keyring {
+	keyringConfig.Lock()
+	defer keyringConfig.Unlock()
+	return Keyring{Backend: keyringConfig.backend}
+}
+
+// HomeKeyring returns the keyring in dir with the configured backend
+func HomeKeyring(dir string) Keyring {
+	keyringConfig.Lock()
+	defer keyringConfig.Unlock()
+	return Keyring{Backend: keyringConfig.backend, Dir: dir}
+}
+
+// TestKeyring returns the unencrypted keyring in dir. opinitd and `minitiad launch` always write their keys there.
+func TestKeyring(dir string) Keyring {
+	return Keyring{Backend: KeyringBackendTest, Dir: dir}
+}
+
+// UnlockKeyring resolves the passphrase of the file backend ahead of time. The commands that handle keys inside an
+// interactive program call it before the program starts, as the passphrase cannot be asked on the terminal while the
+// program owns it.
+func UnlockKeyring() error {
+	_, err := keyringPassphrase()
+	return err
+}
+
+func keyringPassphrase() (string, error) {
+	keyringConfig.Lock()
+	defer keyringConfig.Unlock()
+	if keyringConfig.backend != KeyringBackendFile || keyringConfig.passphrase != "" {
+		return keyringConfig.passphrase, nil
+	}
+	if keyringConfig.passphraseSource == nil {
+		return "", fmt.Errorf("the file keyring backend needs a passphrase, set %s", KeyringPassphraseEnv)
+	}
+	passphrase, err := keyringConfig.passphraseSource()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the keyring passphrase: %v", err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("the keyring passphrase cannot be empty")
+	}
+	keyringConfig.passphrase = passphrase
+	return passphrase, nil
+}
+
+// Args returns the flags that point a key or tx command of the binary to the keyring
+func (k Keyring) Args() []string {
+	args := []string{"--keyring-backend", string(k.Backend)}
+	if k.Dir != "" {
+		args = append(args, "--keyring-dir", k.Dir)
+	}
+	return args
+}
+
+// Input returns what the binary reads from stdin before anything else to open the keyring. The file backend asks
+// for the passphrase once, or twice when the keyring is created.
+func (k Keyring) Input(appName string) (string, error) {
+	if k.Backend != KeyringBackendFile {
+		return "", nil
+	}
+	passphrase, err := keyringPassphrase()
+	if err != nil {
+		return "", err
+	}
+	if k.exists(appName) {
+		return passphrase + "\n", nil
+	}
+	return passphrase + "\n" + passphrase + "\n", nil
+}
+
+// exists reports whether the file keyring was already created, and so has a passphrase set
+func (k Keyring) exists(appName string) bool {
+	dir := k.Dir
+	if dir == "" {
+		home, err := defaultAppHome(appName)
+		if err != nil {
+			// assume an existing keyring, the binary asks for the passphrase again if it is not
+			return true
+		}
+		dir = home
+	}
+	_, err := os.Stat(filepath.Join(dir, "keyring-file", "keyhash"))
+	return err == nil
+}
+
+// defaultAppHome returns the home the binary uses when no --home is given
+func defaultAppHome(appName string) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	switch strings.TrimSuffix(filepath.Base(appName), ".exe") {
+	case "initiad":
+		return filepath.Join(userHome, common.InitiaDirectory), nil
+	case "minitiad":
+		return filepath.Join(userHome, common.MinitiaDirectory), nil
+	case "celestia-appd":
+		return filepath.Join(userHome, ".celestia-app"), nil
+	default:
+		return "", fmt.Errorf("unknown home for %s", appName)
+	}
+}
@@ -33,13 +33,19 @@ func UnmarshalKeyInfo(rawJson string) (KeyInfo, error) {
 	return account, nil
 }
 
-// AddOrReplace adds or replaces a key using `initiad keys add <keyname> --keyring-backend test` with 'y' confirmation
+// AddOrReplace adds or replaces a key using `initiad keys add <keyname>` in the configured keyring with 'y' confirmation
 func AddOrReplace(appName, keyname string) (string, error) {
-	// Command to add the key: echo 'y' | initiad keys add <keyname> --keyring-backend test
-	cmd := exec.Command(appName, "keys", "add", keyname, "--keyring-backend", "test", "--output", "json")
+	keyring := DefaultKeyring()
+	input, err := keyring.Input(appName)
+	if err != nil {
+		return "", err
+	}
+
+	// Command to add the key: echo 'y' | initiad keys add <keyname> --keyring-backend <backend>
+	cmd := exec.Command(appName, append([]string{"keys", "add", keyname, "--output", "json"}, keyring.Args()...)...)
 
 	// Simulate pressing 'y' for confirmation
-	cmd.Stdin = bytes.NewBufferString("y\n")
+	cmd.Stdin = bytes.NewBufferString(input + "y\n")
 
 	// Run the command and capture the output
 	outputBytes, err := cmd.CombinedOutput()
@@ -51,25 +57,56 @@ func AddOrReplace(appName, keyname string) (string, error) {
 }
 
 func DeleteKey(appName, keyname string) error {
-	cmd := exec.Command(appName, "keys", "delete", keyname, "--keyring-backend", "test", "-y")
+	return DefaultKeyring().DeleteKey(appName, keyname)
+}
+
+// DeleteKey deletes a key from the keyring using `initiad keys delete <keyname>`
+func (keyring Keyring) DeleteKey(appName, keyname string) error {
+	input, err := keyring.Input(appName)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(appName, append([]string{"keys", "delete", keyname, "-y"}, keyring.Args()...)...)
+	cmd.Stdin = bytes.NewBufferString(input)
 	return cmd.Run()
 }
 
 // KeyExists checks if a key with the given keyName exists using `initiad keys show`
 func KeyExists(appName, keyname string) bool {
-	cmd := exec.Command(appName, "keys", "show", keyname, "--keyring-backend", "test")
+	return DefaultKeyring().KeyExists(appName, keyname)
+}
+
+// KeyExists checks if a key with the given keyName exists in the keyring using `initiad keys show`
+func (keyring Keyring) KeyExists(appName, keyname string) bool {
+	input, err := keyring.Input(appName)
+	if err != nil {
+		return false
+	}
+	cmd := exec.Command(appName, append([]string{"keys", "show", keyname}, keyring.Args()...)...)
+	cmd.Stdin = bytes.NewBufferString(input)
 	// Run the command and capture the output or error
-	err := cmd.Run()
+	err = cmd.Run()
 	return err == nil
 }
 
 // RecoverKeyFromMnemonic recovers or replaces a key using a mnemonic phrase
 // If the key already exists, it will replace the key and confirm with 'y' before adding the mnemonic
 func RecoverKeyFromMnemonic(appName, keyname, mnemonic string) (string, error) {
+	return DefaultKeyring().RecoverKeyFromMnemonic(appName, keyname, mnemonic)
+}
+
+// RecoverKeyFromMnemonic recovers or replaces a key in the keyring using a mnemonic phrase
+func (keyring Keyring) RecoverKeyFromMnemonic(appName, keyname, mnemonic string) (string, error) {
 	// Check if the key already exists
-	exists := KeyExists(appName, keyname)
+	exists := keyring.KeyExists(appName, keyname)
 
-	var inputBuffer bytes.Buffer
+	input, err := keyring.Input(appName)
+	if err != nil {
+		return "", err
+	}
+
+	// The keyring is opened first, with the passphrase of the file backend
+	inputBuffer := bytes.NewBufferString(input)
 	if exists {
 		// Simulate pressing 'y' for confirmation
 		inputBuffer.WriteString("y\n")
@@ -78,11 +115,11 @@ func RecoverKeyFromMnemonic(appName, keyname, mnemonic string) (string, error) {
 	// Add the mnemonic input after the confirmation (if any)
 	inputBuffer.WriteString(mnemonic + "\n")
 
-	// Command to recover (or replace) the key: initiad keys add <keyname> --recover --keyring-backend test
-	cmd := exec.Command(appName, "keys", "add", keyname, "--recover", "--keyring-backend", "test", "--output", "json")
+	// Command to recover (or replace) the key: initiad keys add <keyname> --recover --keyring-backend <backend>
+	cmd := exec.Command(appName, append([]string{"keys", "add", keyname, "--recover", "--output", "json"}, keyring.Args()...)...)
 
 	// Pass the combined confirmation and mnemonic as input to the command
-	cmd.Stdin = &inputBuffer
+	cmd.Stdin = inputBuffer
 
 	// Run the command and capture the output
 	outputBytes, err := cmd.CombinedOutput()
@@ -123,6 +160,7 @@ func GetAddressFromMnemonic(appName, mnemonic string) (string, error) {
 
 // OPInitRecoverKeyFromMnemonic recovers or replaces a key using a mnemonic phrase
 // If the key already exists, it will replace the key and confirm with 'y' before adding the mnemonic
+// The key always goes to the test keyring of opInitHome whatever the configured backend, the bots only open that one
 func OPInitRecoverKeyFromMnemonic(appName, keyname, mnemonic string, isCelestia bool, opInitHome string) (string, error) {
 	// Check if the key already exists
 	exists := OPInitKeyExist(appName, keyname, opInitHome)
@@ -218,7 +256,7 @@ func extractAddress(output, keyname string) (string, error) {
 }
 
 // OPInitAddOrReplace adds or replaces a key using `opinitd keys add <keyname> --keyring-backend test`
-// with 'y' confirmation
+// with 'y' confirmation. Like OPInitRecoverKeyFromMnemonic, it ignores the configured backend.
 func OPInitAddOrReplace(appName, keyname string, isCelestia bool, opInitHome string) (string, error) {
 	// Check if the key already exists
 	exists := OPInitKeyExist(appName, keyname, opInitHome)
@@ -0,0 +1,61 @@
+package cosmosutils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const DefaultNodeRPC string = "http://localhost:26657"
+
+type nodeConfigToml struct {
+	RPC struct {
+		Laddr string `toml:"laddr"`
+	} `toml:"rpc"`
+}
+
+// GetNodeRPCFromConfig reads the RPC listen address from <appHome>/config/config.toml and turns it into a dialable URL
+func GetNodeRPCFromConfig(appHome string) (string, error) {
+	var cfg nodeConfigToml
+	if _, err := toml.DecodeFile(filepath.Join(appHome, "config", "config.toml"), &cfg); err != nil {
+		return "", fmt.Errorf("failed to read config.toml: %v", err)
+	}
+
+	laddr := strings.TrimPrefix(cfg.RPC.Laddr, "tcp://")
+	if laddr == "" {
+		return DefaultNodeRPC, nil
+	}
+	laddr = strings.Replace(laddr, "0.0.0.0", "localhost", 1)
+
+	return "http://" + laddr, nil
+}
+
+// DetectNodeVersion finds the application version of the node living at appHome. The running node is asked
+// first and, if it is not reachable, the given candidate binaries are asked for their version in order.
+func DetectNodeVersion(appHome string, binaryCandidates []string) (string, error) {
+	if rpc, err := GetNodeRPCFromConfig(appHome); err == nil {
+		if info, err := QueryABCIInfo(rpc); err == nil && info.Result.Response.Version != "" {
+			return NormalizeVersion(info.Result.Response.Version), nil
+		}
+	}
+
+	for _, binary := range binaryCandidates {
+		version, err := GetBinaryVersion(binary)
+		if err == nil && version != "" {
+			return NormalizeVersion(version), nil
+		}
+	}
+
+	return "", fmt.Errorf("could not detect the node version from %s, please provide it explicitly", appHome)
+}
+
+// NormalizeVersion trims a version reported by a node or binary and adds the "v" prefix used by release tags
+func NormalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
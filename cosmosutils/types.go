@@ -101,3 +101,27 @@ type OPChildParams struct {
 type OPChildParamsResponse struct {
 	Params OPChildParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
 }
+
+// OutputProposal is an output root the output submitter proposed to the L1 for a bridge
+type OutputProposal struct {
+	BridgeId       string `json:"bridge_id"`
+	OutputIndex    string `json:"output_index"`
+	OutputProposal struct {
+		OutputRoot    string `json:"output_root"`
+		L1BlockNumber string `json:"l1_block_number"`
+		L1BlockTime   string `json:"l1_block_time"`
+		L2BlockNumber string `json:"l2_block_number"`
+	} `json:"output_proposal"`
+}
+
+type OutputProposalsResponse struct {
+	OutputProposals []OutputProposal `json:"output_proposals"`
+}
+
+type NextL1SequenceResponse struct {
+	NextL1Sequence string `json:"next_l1_sequence"`
+}
+
+type WithdrawalClaimedResponse struct {
+	Claimed bool `json:"claimed"`
+}
@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	MinPassphraseLength int = 8
+
+	scryptN      int = 1 << 15
+	scryptR      int = 8
+	scryptP      int = 1
+	scryptKeyLen int = 32
+	saltLen      int = 16
+)
+
+// EncryptedData is the JSON envelope written for data encrypted with a passphrase.
+// The key is derived with scrypt and the data is sealed with AES-256-GCM.
+type EncryptedData struct {
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func deriveAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptWithPassphrase encrypts plaintext and returns the JSON encoded EncryptedData
+func EncryptWithPassphrase(plaintext []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase cannot be empty")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := deriveAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return json.MarshalIndent(EncryptedData{
+		KDF:        "scrypt",
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+}
+
+// IsEncryptedData reports whether data is an envelope produced by EncryptWithPassphrase
+func IsEncryptedData(data []byte) bool {
+	var encrypted EncryptedData
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return false
+	}
+	return encrypted.KDF != "" && len(encrypted.Ciphertext) > 0
+}
+
+// DecryptWithPassphrase opens data produced by EncryptWithPassphrase
+func DecryptWithPassphrase(data []byte, passphrase string) ([]byte, error) {
+	var encrypted EncryptedData
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted data: %w", err)
+	}
+	if encrypted.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function: %s", encrypted.KDF)
+	}
+
+	aead, err := deriveAEAD(passphrase, encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce length")
+	}
+	plaintext, err := aead.Open(nil, encrypted.Nonce, encrypted.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt, wrong passphrase or corrupted data")
+	}
+	return plaintext, nil
+}
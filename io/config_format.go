@@ -0,0 +1,149 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigFormat string
+
+const (
+	ConfigFormatJSON ConfigFormat = "json"
+	ConfigFormatYAML ConfigFormat = "yaml"
+	ConfigFormatTOML ConfigFormat = "toml"
+)
+
+// DetectConfigFormat returns the format of a config file, either the explicitly requested one or the one
+// implied by the file extension. Unknown extensions are treated as JSON.
+func DetectConfigFormat(path, format string) (ConfigFormat, error) {
+	switch strings.ToLower(format) {
+	case "":
+	case "json":
+		return ConfigFormatJSON, nil
+	case "yaml", "yml":
+		return ConfigFormatYAML, nil
+	case "toml":
+		return ConfigFormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported config format %q, valid options are: json, yaml, toml", format)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ConfigFormatYAML, nil
+	case ".toml":
+		return ConfigFormatTOML, nil
+	default:
+		return ConfigFormatJSON, nil
+	}
+}
+
+// UnmarshalConfig decodes data of the given format into v. YAML and TOML documents are converted to JSON first,
+// so v is always mapped through its json tags and behaves the same regardless of the input format.
+func UnmarshalConfig(data []byte, format ConfigFormat, v interface{}) error {
+	var generic map[string]interface{}
+	switch format {
+	case ConfigFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		return decoder.Decode(v)
+	case ConfigFormatYAML:
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("failed to parse yaml: %v", err)
+		}
+	case ConfigFormatTOML:
+		if err := toml.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("failed to parse toml: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported config format %q", format)
+	}
+
+	jsonBz, err := json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to json: %v", format, err)
+	}
+	return json.Unmarshal(jsonBz, v)
+}
+
+// MarshalConfig encodes v in the given format using its json tags as keys, the inverse of UnmarshalConfig
+func MarshalConfig(v interface{}, format ConfigFormat) ([]byte, error) {
+	jsonBz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format == ConfigFormatJSON {
+		return jsonBz, nil
+	}
+
+	var generic map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBz))
+	decoder.UseNumber()
+	if err = decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	normalized := normalizeJSONValue(generic)
+
+	switch format {
+	case ConfigFormatYAML:
+		return yaml.Marshal(normalized)
+	case ConfigFormatTOML:
+		var buf bytes.Buffer
+		if err = toml.NewEncoder(&buf).Encode(normalized); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+}
+
+// normalizeJSONValue turns json.Number into int64 or float64 and drops nulls, which TOML cannot represent
+func normalizeJSONValue(value interface{}) interface{} {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key, item := range val {
+			if item == nil {
+				delete(val, key)
+				continue
+			}
+			val[key] = normalizeJSONValue(item)
+		}
+		return val
+	case []interface{}:
+		for idx, item := range val {
+			val[idx] = normalizeJSONValue(item)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	default:
+		return val
+	}
+}
+
+// ReadConfigFile reads path and decodes it into v, see DetectConfigFormat for how format is resolved
+func ReadConfigFile(path, format string, v interface{}) error {
+	configFormat, err := DetectConfigFormat(path, format)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err = UnmarshalConfig(data, configFormat, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	ChainID  string   `json:"chain_id"`
+	Interval int      `json:"interval"`
+	Enabled  bool     `json:"enabled"`
+	Peers    []string `json:"peers"`
+}
+
+func TestDetectConfigFormat(t *testing.T) {
+	for path, expected := range map[string]ConfigFormat{
+		"config.json": ConfigFormatJSON,
+		"config.yaml": ConfigFormatYAML,
+		"config.YML":  ConfigFormatYAML,
+		"config.toml": ConfigFormatTOML,
+		"config":      ConfigFormatJSON,
+	} {
+		format, err := DetectConfigFormat(path, "")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format, path)
+	}
+
+	format, err := DetectConfigFormat("config.json", "yml")
+	assert.NoError(t, err)
+	assert.Equal(t, ConfigFormatYAML, format)
+
+	_, err = DetectConfigFormat("config.json", "xml")
+	assert.Error(t, err)
+}
+
+func TestReadConfigFile(t *testing.T) {
+	expected := testConfig{ChainID: "minimove-1", Interval: 60, Enabled: true, Peers: []string{"a", "b"}}
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.json": `{"chain_id": "minimove-1", "interval": 60, "enabled": true, "peers": ["a", "b"]}`,
+		"config.yaml": "# staging rollup\nchain_id: minimove-1\ninterval: 60 # seconds\nenabled: true\npeers:\n  - a\n  - b\n",
+		"config.toml": "# staging rollup\nchain_id = \"minimove-1\"\ninterval = 60\nenabled = true\npeers = [\"a\", \"b\"]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		var cfg testConfig
+		assert.NoError(t, ReadConfigFile(path, "", &cfg), name)
+		assert.Equal(t, expected, cfg, name)
+	}
+
+	var cfg testConfig
+	assert.Error(t, ReadConfigFile(filepath.Join(dir, "config.yaml"), "toml", &cfg))
+}
+
+func TestMarshalConfigRoundTrip(t *testing.T) {
+	expected := testConfig{ChainID: "minimove-1", Interval: 60, Enabled: true, Peers: []string{"a"}}
+	for _, format := range []ConfigFormat{ConfigFormatJSON, ConfigFormatYAML, ConfigFormatTOML} {
+		data, err := MarshalConfig(expected, format)
+		assert.NoError(t, err)
+
+		var cfg testConfig
+		assert.NoError(t, UnmarshalConfig(data, format, &cfg))
+		assert.Equal(t, expected, cfg, format)
+	}
+}
@@ -0,0 +1,173 @@
+package io
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// DirectorySize returns the total size in bytes of all regular files under path
+func DirectorySize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate size of %s: %v", path, err)
+	}
+	return size, nil
+}
+
+// GetFreeSpace returns the number of bytes available to the current user on the filesystem containing path
+func GetFreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("failed to get free space of %s: %v", path, err)
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// FormatBytes renders a byte count in a human readable form, e.g. 1.5 GiB. A negative count, such as a shrinking
+// directory, keeps its sign, e.g. -1.5 GiB.
+func FormatBytes(size int64) string {
+	if size < 0 {
+		if size == math.MinInt64 {
+			return "-8.0 EiB"
+		}
+		return "-" + FormatBytes(-size)
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// CopyDirectoryWithProgress copies the content of src into des file by file, preserving modes and symlinks.
+// onProgress, if given, is called after each file with the number of bytes copied so far and the total.
+func CopyDirectoryWithProgress(src, des string, onProgress func(copied, total int64)) error {
+	total, err := DirectorySize(src)
+	if err != nil {
+		return err
+	}
+
+	var copied int64
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(des, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return fmt.Errorf("failed to read symlink %s: %v", path, err)
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			n, err := copyFile(path, target, info.Mode().Perm())
+			if err != nil {
+				return err
+			}
+			copied += n
+			if onProgress != nil {
+				onProgress(copied, total)
+			}
+		}
+		return nil
+	})
+}
+
+func copyFile(src, des string, perm os.FileMode) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %v", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(des, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %v", des, err)
+	}
+	defer out.Close()
+
+	n, err := io.Copy(out, in)
+	if err != nil {
+		return n, fmt.Errorf("failed to copy %s: %v", src, err)
+	}
+	if err = out.Sync(); err != nil {
+		return n, fmt.Errorf("failed to sync %s: %v", des, err)
+	}
+	return n, nil
+}
+
+// VerifyDirectoryCopy checks that every file under src exists under des with the same size and content
+func VerifyDirectoryCopy(src, des string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		copiedInfo, err := os.Lstat(filepath.Join(des, rel))
+		if err != nil {
+			return fmt.Errorf("missing %s in copy: %v", rel, err)
+		}
+		if copiedInfo.Size() != info.Size() {
+			return fmt.Errorf("size mismatch for %s: expected %d, got %d", rel, info.Size(), copiedInfo.Size())
+		}
+		srcSum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		copiedSum, err := fileChecksum(filepath.Join(des, rel))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(srcSum, copiedSum) {
+			return fmt.Errorf("content mismatch for %s", rel)
+		}
+		return nil
+	})
+}
+
+func fileChecksum(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,64 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.0 KiB", FormatBytes(1024))
+	assert.Equal(t, "1.5 MiB", FormatBytes(1024*1024*3/2))
+	assert.Equal(t, "2.0 GiB", FormatBytes(2*1024*1024*1024))
+	assert.Equal(t, "-512 B", FormatBytes(-512))
+	assert.Equal(t, "-1.5 MiB", FormatBytes(-1024*1024*3/2))
+}
+
+func TestCopyDirectoryWithProgress(t *testing.T) {
+	src := t.TempDir()
+	des := filepath.Join(t.TempDir(), "copy")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "blockstore.db"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "blockstore.db", "000001.ldb"), []byte("block data"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "priv_validator_state.json"), []byte("{}"), 0600))
+	assert.NoError(t, os.Symlink("blockstore.db", filepath.Join(src, "link")))
+
+	var lastCopied, lastTotal int64
+	err := CopyDirectoryWithProgress(src, des, func(copied, total int64) {
+		lastCopied, lastTotal = copied, total
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), lastTotal)
+	assert.Equal(t, lastTotal, lastCopied)
+
+	content, err := os.ReadFile(filepath.Join(des, "blockstore.db", "000001.ldb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "block data", string(content))
+
+	info, err := os.Stat(filepath.Join(des, "priv_validator_state.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(des, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, "blockstore.db", link)
+
+	assert.NoError(t, VerifyDirectoryCopy(src, des))
+}
+
+func TestVerifyDirectoryCopyFailure(t *testing.T) {
+	src := t.TempDir()
+	des := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "state.db"), []byte("state"), 0644))
+	assert.Error(t, VerifyDirectoryCopy(src, des))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(des, "state.db"), []byte("st"), 0644))
+	assert.Error(t, VerifyDirectoryCopy(src, des))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(des, "state.db"), []byte("stale"), 0644))
+	assert.ErrorContains(t, VerifyDirectoryCopy(src, des), "content mismatch for state.db")
+}
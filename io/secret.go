@@ -0,0 +1,77 @@
+package io
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	SecretEnvPrefix  string = "env:"
+	SecretFilePrefix string = "file:"
+)
+
+// ResolveSecret returns the value behind an `env:NAME` or `file:PATH` reference, or value itself when it is not
+// a reference. File contents are trimmed of surrounding whitespace.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretEnvPrefix):
+		name := strings.TrimPrefix(value, SecretEnvPrefix)
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, SecretFilePrefix):
+		path := strings.TrimPrefix(value, SecretFilePrefix)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %v", err)
+		}
+		secret := strings.TrimSpace(string(content))
+		if secret == "" {
+			return "", fmt.Errorf("secret file %s is empty", path)
+		}
+		return secret, nil
+	default:
+		return value, nil
+	}
+}
+
+// WriteSecretFile writes data to path readable and writable only by the current user, also tightening the
+// permissions of an existing file
+func WriteSecretFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0600)
+}
+
+// SecureDelete overwrites the file with zeros and syncs it before removing it. This is best effort, as journaling
+// and copy-on-write filesystems may still keep the previous content on disk.
+func SecureDelete(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	if _, err = file.Write(make([]byte, info.Size())); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to overwrite %s: %v", path, err)
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync %s: %v", path, err)
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,44 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("WEAVE_TEST_MNEMONIC", "env mnemonic")
+	secretPath := filepath.Join(t.TempDir(), "mnemonic")
+	assert.NoError(t, os.WriteFile(secretPath, []byte("file mnemonic\n"), 0600))
+
+	for value, expected := range map[string]string{
+		"plain mnemonic":              "plain mnemonic",
+		"env:WEAVE_TEST_MNEMONIC":     "env mnemonic",
+		SecretFilePrefix + secretPath: "file mnemonic",
+	} {
+		secret, err := ResolveSecret(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, secret)
+	}
+
+	_, err := ResolveSecret("env:WEAVE_TEST_MISSING_MNEMONIC")
+	assert.Error(t, err)
+	_, err = ResolveSecret("file:" + filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestWriteSecretFileAndSecureDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	assert.NoError(t, WriteSecretFile(path, []byte(`{"mnemonic": "secret"}`)))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.NoError(t, SecureDelete(path))
+	assert.NoFileExists(t, path)
+	assert.NoError(t, SecureDelete(path))
+}
@@ -0,0 +1,107 @@
+package initia
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+// AdoptExistingNode puts an initiad home that was set up by hand under weave management.
+// Only binaries and service definitions are touched, the config and data directories are left as they are.
+func AdoptExistingNode(initiaHome, version string, allowAutoUpgrade bool) error {
+	if !IsExistApp(filepath.Join(initiaHome, common.InitiaConfigDirectory)) {
+		return fmt.Errorf("no initia node found at %s", initiaHome)
+	}
+
+	units, err := service.FindExistingSystemdUnits([]string{"initiad", "cosmovisor"}, initiaHome, common.InitiaDirectory)
+	if err != nil {
+		return err
+	}
+
+	if version == "" {
+		candidates := []string{filepath.Join(initiaHome, "cosmovisor", "current", "bin", "initiad")}
+		for _, unit := range units {
+			if filepath.Base(unit.ExecPath) == "initiad" {
+				candidates = append(candidates, unit.ExecPath)
+			}
+		}
+		version, err = cosmosutils.DetectNodeVersion(initiaHome, candidates)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Detected initiad %s\n", version)
+
+	url, err := cosmosutils.GetInitiaBinaryURL(version)
+	if err != nil {
+		return err
+	}
+	binaryPath, err := cosmosutils.GetInitiaBinaryPath(version)
+	if err != nil {
+		return fmt.Errorf("failed to get initia binary path: %v", err)
+	}
+	if err = cosmosutils.InstallInitiaBinary(version, url, binaryPath); err != nil {
+		return fmt.Errorf("failed to install initia binary: %v", err)
+	}
+	cosmovisorPath, err := cosmosutils.InstallCosmovisor(CosmovisorVersion)
+	if err != nil {
+		return fmt.Errorf("failed to install cosmovisor: %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(initiaHome, "cosmovisor")); os.IsNotExist(err) {
+		runCmd := exec.Command(cosmovisorPath, "init", binaryPath)
+		runCmd.Env = append(runCmd.Env, "DAEMON_NAME=initiad", "DAEMON_HOME="+initiaHome)
+		if err := runCmd.Run(); err != nil {
+			return fmt.Errorf("failed to run cosmovisor init: %v", err)
+		}
+	} else if err = pointCosmovisorCurrent(initiaHome, binaryPath, version); err != nil {
+		return err
+	}
+	if err = io.CopyDirectory(filepath.Dir(binaryPath), filepath.Join(initiaHome, "cosmovisor", "dyld_lib")); err != nil {
+		return fmt.Errorf("failed to copy initia binary: %v", err)
+	}
+
+	serviceCommand := service.NonUpgradableInitia
+	if allowAutoUpgrade {
+		serviceCommand = service.UpgradableInitia
+	}
+	return service.TakeOverExistingUnits(serviceCommand, units, func(srv service.Service) error {
+		return srv.Create(fmt.Sprintf("cosmovisor@%s", CosmovisorVersion), initiaHome)
+	})
+}
+
+// pointCosmovisorCurrent makes the cosmovisor the operator already runs in initiaHome run the installed initiad at
+// binaryPath, unless its current binary is already of version. The binary is copied next to the cosmovisor upgrades
+// and the current link is switched to it, the genesis and upgrade binaries are left as they are.
+func pointCosmovisorCurrent(initiaHome, binaryPath, version string) error {
+	currentPath := filepath.Join(initiaHome, "cosmovisor", "current")
+	if current, err := cosmosutils.GetBinaryVersion(filepath.Join(currentPath, "bin", "initiad")); err == nil && cosmosutils.NormalizeVersion(current) == version {
+		return nil
+	}
+
+	versionPath := filepath.Join(initiaHome, "cosmovisor", "weave-"+version)
+	if err := os.MkdirAll(versionPath, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create %s: %v", versionPath, err)
+	}
+	if err := io.CopyDirectory(filepath.Dir(binaryPath), filepath.Join(versionPath, "bin")); err != nil {
+		return fmt.Errorf("failed to copy initia binary: %v", err)
+	}
+	// the link is replaced in one rename, so cosmovisor never sees it missing
+	linkPath := currentPath + ".weave"
+	_ = os.Remove(linkPath)
+	if err := os.Symlink(versionPath, linkPath); err != nil {
+		return fmt.Errorf("failed to link %s: %v", versionPath, err)
+	}
+	if err := os.Rename(linkPath, currentPath); err != nil {
+		_ = os.Remove(linkPath)
+		return fmt.Errorf("failed to point cosmovisor to initiad %s: %v", version, err)
+	}
+	fmt.Printf("Pointed the cosmovisor in %s to initiad %s\n", initiaHome, version)
+	return nil
+}
@@ -0,0 +1,41 @@
+package initia
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFakeInitiad(t *testing.T, dir, version string) string {
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	path := filepath.Join(dir, "initiad")
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho "+version+"\n"), 0755))
+	return path
+}
+
+func TestPointCosmovisorCurrent(t *testing.T) {
+	initiaHome := t.TempDir()
+	genesisPath := filepath.Join(initiaHome, "cosmovisor", "genesis")
+	writeFakeInitiad(t, filepath.Join(genesisPath, "bin"), "v0.6.0")
+	currentPath := filepath.Join(initiaHome, "cosmovisor", "current")
+	assert.NoError(t, os.Symlink(genesisPath, currentPath))
+	binaryPath := writeFakeInitiad(t, filepath.Join(t.TempDir(), "initia@v0.7.0"), "v0.7.0")
+
+	// the operator's cosmovisor runs another version, current is switched to the installed one
+	assert.NoError(t, pointCosmovisorCurrent(initiaHome, binaryPath, "v0.7.0"))
+	target, err := os.Readlink(currentPath)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(initiaHome, "cosmovisor", "weave-v0.7.0"), target)
+	assert.FileExists(t, filepath.Join(target, "bin", "initiad"))
+	assert.FileExists(t, filepath.Join(genesisPath, "bin", "initiad"))
+
+	// current already runs the version, it is left as it is
+	assert.NoError(t, os.Remove(currentPath))
+	assert.NoError(t, os.Symlink(genesisPath, currentPath))
+	assert.NoError(t, pointCosmovisorCurrent(initiaHome, binaryPath, "v0.6.0"))
+	target, err = os.Readlink(currentPath)
+	assert.NoError(t, err)
+	assert.Equal(t, genesisPath, target)
+}
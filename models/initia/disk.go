@@ -0,0 +1,275 @@
+package initia
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	DiskUsageHistoryFilename string = "disk_usage.json"
+	maxDiskUsageRecords      int    = 200
+)
+
+// DiskUsageRecord is a snapshot of the data directory sizes at a point in time
+type DiskUsageRecord struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Total     int64            `json:"total"`
+	Databases map[string]int64 `json:"databases"`
+}
+
+type DiskUsageReport struct {
+	DataPath  string
+	Current   DiskUsageRecord
+	FreeSpace uint64
+	Previous  *DiskUsageRecord
+}
+
+// GrowthPerDay returns the average number of bytes the data directory grew per day since the previous record
+func (r DiskUsageReport) GrowthPerDay() (int64, bool) {
+	if r.Previous == nil {
+		return 0, false
+	}
+	elapsed := r.Current.Timestamp.Sub(r.Previous.Timestamp)
+	if elapsed < time.Hour {
+		return 0, false
+	}
+	return int64(float64(r.Current.Total-r.Previous.Total) / elapsed.Hours() * 24), true
+}
+
+func (r DiskUsageReport) String() string {
+	var names []string
+	for name := range r.Current.Databases {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return r.Current.Databases[names[i]] > r.Current.Databases[names[j]]
+	})
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Data directory: %s\n\n", r.DataPath))
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("  %-24s %12s\n", name, io.FormatBytes(r.Current.Databases[name])))
+	}
+	b.WriteString(fmt.Sprintf("  %-24s %12s\n\n", "total", io.FormatBytes(r.Current.Total)))
+	b.WriteString(fmt.Sprintf("Free space: %s\n", io.FormatBytes(int64(r.FreeSpace))))
+
+	growth, ok := r.GrowthPerDay()
+	if !ok {
+		b.WriteString("Trend: not enough history yet, run this command again later to see how fast the data grows\n")
+		return b.String()
+	}
+	trend := io.FormatBytes(growth)
+	if growth > 0 {
+		trend = "+" + trend
+	}
+	b.WriteString(fmt.Sprintf("Trend: %s per day since %s\n", trend, r.Previous.Timestamp.Format(time.DateTime)))
+	if growth > 0 {
+		days := float64(r.FreeSpace) / float64(growth)
+		b.WriteString(fmt.Sprintf("Estimated time until the disk is full: %.0f days\n", days))
+	}
+	return b.String()
+}
+
+// GetDiskUsage measures every entry of <initiaHome>/data, stores the result in the usage history and returns it
+// together with the oldest record of the last 30 days for trend calculation.
+func GetDiskUsage(initiaHome string) (*DiskUsageReport, error) {
+	dataPath, err := filepath.EvalSymlinks(filepath.Join(initiaHome, common.InitiaDataDirectory))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data directory: %v", err)
+	}
+
+	entries, err := os.ReadDir(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory: %v", err)
+	}
+
+	record := DiskUsageRecord{Timestamp: time.Now(), Databases: make(map[string]int64)}
+	for _, entry := range entries {
+		size, err := io.DirectorySize(filepath.Join(dataPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		record.Databases[entry.Name()] = size
+		record.Total += size
+	}
+
+	free, err := io.GetFreeSpace(dataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := loadDiskUsageHistory()
+	if err != nil {
+		return nil, err
+	}
+	report := &DiskUsageReport{
+		DataPath:  dataPath,
+		Current:   record,
+		FreeSpace: free,
+		Previous:  findTrendBaseline(history[initiaHome], record.Timestamp),
+	}
+
+	history[initiaHome] = append(history[initiaHome], record)
+	if len(history[initiaHome]) > maxDiskUsageRecords {
+		history[initiaHome] = history[initiaHome][len(history[initiaHome])-maxDiskUsageRecords:]
+	}
+	if err = saveDiskUsageHistory(history); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// findTrendBaseline picks the oldest record that is at most 30 days older than now
+func findTrendBaseline(records []DiskUsageRecord, now time.Time) *DiskUsageRecord {
+	for idx := range records {
+		if now.Sub(records[idx].Timestamp) <= 30*24*time.Hour {
+			return &records[idx]
+		}
+	}
+	return nil
+}
+
+func getDiskUsageHistoryPath() (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return filepath.Join(userHome, common.WeaveDataDirectory, DiskUsageHistoryFilename), nil
+}
+
+func loadDiskUsageHistory() (map[string][]DiskUsageRecord, error) {
+	history := make(map[string][]DiskUsageRecord)
+	path, err := getDiskUsageHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read disk usage history: %v", err)
+	}
+	if err = json.Unmarshal(content, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse disk usage history: %v", err)
+	}
+	return history, nil
+}
+
+func saveDiskUsageHistory(history map[string][]DiskUsageRecord) error {
+	path, err := getDiskUsageHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create weave data directory: %v", err)
+	}
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal disk usage history: %v", err)
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+type pruningConfig struct {
+	Pruning           string `toml:"pruning"`
+	PruningKeepRecent string `toml:"pruning-keep-recent"`
+	PruningInterval   string `toml:"pruning-interval"`
+	AppDBBackend      string `toml:"app-db-backend"`
+}
+
+// buildPruneArgs turns the pruning settings of app.toml into the arguments of `initiad prune`
+func buildPruneArgs(cfg pruningConfig, initiaHome string) ([]string, error) {
+	strategy := cfg.Pruning
+	if strategy == "" {
+		strategy = "default"
+	}
+
+	args := []string{"prune", strategy, "--home", initiaHome}
+	switch strategy {
+	case "nothing":
+		return nil, fmt.Errorf("pruning strategy is set to \"nothing\" in app.toml, there is nothing to prune")
+	case "custom":
+		args = append(args, "--pruning-keep-recent", cfg.PruningKeepRecent, "--pruning-interval", cfg.PruningInterval)
+	case "default", "everything":
+	default:
+		return nil, fmt.Errorf("unknown pruning strategy %q in app.toml", strategy)
+	}
+	if cfg.AppDBBackend != "" {
+		args = append(args, "--app-db-backend", cfg.AppDBBackend)
+	}
+	return args, nil
+}
+
+// PruneNode stops the node, prunes application.db offline with the pruning strategy configured in app.toml
+// and starts the node again
+func PruneNode(initiaHome string) error {
+	var cfg pruningConfig
+	if _, err := toml.DecodeFile(filepath.Join(initiaHome, common.InitiaConfigDirectory, "app.toml"), &cfg); err != nil {
+		return fmt.Errorf("failed to read app.toml: %v", err)
+	}
+	args, err := buildPruneArgs(cfg, initiaHome)
+	if err != nil {
+		return err
+	}
+
+	binaryPath := filepath.Join(initiaHome, "cosmovisor", "current", "bin", "initiad")
+	if !io.FileOrFolderExists(binaryPath) {
+		return fmt.Errorf("initiad binary not found at %s, is the node set up with `weave initia init`?", binaryPath)
+	}
+
+	dataPath := filepath.Join(initiaHome, common.InitiaDataDirectory)
+	before, err := io.DirectorySize(dataPath)
+	if err != nil {
+		return err
+	}
+
+	srv, err := service.NewService(service.UpgradableInitia)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Stopping the service...")
+	if err = srv.Stop(); err != nil {
+		return fmt.Errorf("failed to stop service: %v", err)
+	}
+
+	fmt.Printf("Pruning with strategy %q...\n", args[1])
+	pruneCmd := exec.Command(binaryPath, args...)
+	libraryPathKey := "LD_LIBRARY_PATH"
+	if runtime.GOOS == "darwin" {
+		libraryPathKey = "DYLD_LIBRARY_PATH"
+	}
+	pruneCmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", libraryPathKey, filepath.Join(initiaHome, "cosmovisor", "dyld_lib")))
+	pruneCmd.Stdout = os.Stdout
+	pruneCmd.Stderr = os.Stderr
+	pruneErr := pruneCmd.Run()
+
+	fmt.Println("Starting the service...")
+	if err = srv.Start(); err != nil {
+		return fmt.Errorf("failed to start service: %v", err)
+	}
+	if pruneErr != nil {
+		return fmt.Errorf("failed to prune: %v", pruneErr)
+	}
+
+	after, err := io.DirectorySize(dataPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Data directory size: %s -> %s\n", io.FormatBytes(before), io.FormatBytes(after))
+	return nil
+}
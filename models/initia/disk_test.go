@@ -0,0 +1,51 @@
+package initia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPruneArgs(t *testing.T) {
+	args, err := buildPruneArgs(pruningConfig{}, "/home/.initia")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prune", "default", "--home", "/home/.initia"}, args)
+
+	args, err = buildPruneArgs(pruningConfig{Pruning: "custom", PruningKeepRecent: "100", PruningInterval: "10", AppDBBackend: "goleveldb"}, "/home/.initia")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prune", "custom", "--home", "/home/.initia", "--pruning-keep-recent", "100", "--pruning-interval", "10", "--app-db-backend", "goleveldb"}, args)
+
+	_, err = buildPruneArgs(pruningConfig{Pruning: "nothing"}, "/home/.initia")
+	assert.Error(t, err)
+
+	_, err = buildPruneArgs(pruningConfig{Pruning: "sometimes"}, "/home/.initia")
+	assert.Error(t, err)
+}
+
+func TestDiskUsageTrend(t *testing.T) {
+	now := time.Now()
+	records := []DiskUsageRecord{
+		{Timestamp: now.Add(-60 * 24 * time.Hour), Total: 100},
+		{Timestamp: now.Add(-2 * 24 * time.Hour), Total: 1000},
+		{Timestamp: now.Add(-24 * time.Hour), Total: 1500},
+	}
+
+	baseline := findTrendBaseline(records, now)
+	assert.NotNil(t, baseline)
+	assert.Equal(t, int64(1000), baseline.Total)
+
+	report := DiskUsageReport{Current: DiskUsageRecord{Timestamp: now, Total: 3000}, Previous: baseline}
+	growth, ok := report.GrowthPerDay()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), growth)
+	assert.Contains(t, report.String(), "Trend: +1000 B per day")
+
+	shrinking := DiskUsageReport{Current: DiskUsageRecord{Timestamp: now, Total: 0}, Previous: baseline}
+	assert.Contains(t, shrinking.String(), "Trend: -500 B per day")
+	assert.NotContains(t, shrinking.String(), "Estimated time until the disk is full")
+
+	_, ok = DiskUsageReport{Current: DiskUsageRecord{Timestamp: now}}.GrowthPerDay()
+	assert.False(t, ok)
+	assert.Nil(t, findTrendBaseline(records[:1], now))
+}
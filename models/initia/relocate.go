@@ -0,0 +1,222 @@
+package initia
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	relocateHealthTimeout      = 3 * time.Minute
+	relocateHealthPollInterval = 5 * time.Second
+)
+
+// RelocateNodeData moves <appHome>/data to newPath and leaves a symlink behind. The symlink is used instead of
+// config.toml's db_dir because db_dir only covers the CometBFT databases, while application.db and
+// priv_validator_state.json stay under <appHome>/data. The service home therefore never changes.
+// The previous data is only removed once the node serves at least the height it had before the move from newPath.
+func RelocateNodeData(commandName service.CommandName, appHome, newPath string, keepOld bool) error {
+	srv, err := service.NewService(commandName)
+	if err != nil {
+		return err
+	}
+	r := &dataRelocation{
+		srv:          srv,
+		appHome:      appHome,
+		keepOld:      keepOld,
+		pollInterval: relocateHealthPollInterval,
+		timeout:      relocateHealthTimeout,
+		height: func() (int64, error) {
+			rpc, err := cosmosutils.GetNodeRPCFromConfig(appHome)
+			if err != nil {
+				return 0, err
+			}
+			return cosmosutils.QueryLatestHeight(rpc)
+		},
+	}
+	return r.run(newPath)
+}
+
+type dataRelocation struct {
+	srv     service.Service
+	appHome string
+	keepOld bool
+	// height returns the latest height of the node, it is used to tell whether the node runs from the new path
+	height       func() (int64, error)
+	pollInterval time.Duration
+	timeout      time.Duration
+
+	dataPath string
+	oldPath  string
+	srcPath  string
+	newPath  string
+	// createdNewPath is set when newPath did not exist, otherwise only the copied entries are removed on rollback
+	createdNewPath bool
+	copiedEntries  []string
+}
+
+func (r *dataRelocation) run(newPath string) error {
+	r.dataPath = filepath.Join(r.appHome, "data")
+	r.oldPath = r.dataPath + ".old"
+	info, err := os.Lstat(r.dataPath)
+	if err != nil {
+		return fmt.Errorf("failed to find data directory %s: %v", r.dataPath, err)
+	}
+	wasSymlink := info.Mode()&os.ModeSymlink != 0
+	if r.srcPath, err = filepath.EvalSymlinks(r.dataPath); err != nil {
+		return fmt.Errorf("failed to resolve data directory %s: %v", r.dataPath, err)
+	}
+	if io.FileOrFolderExists(r.oldPath) {
+		return fmt.Errorf("%s already exists, please remove it first", r.oldPath)
+	}
+	if r.newPath, err = filepath.Abs(newPath); err != nil {
+		return fmt.Errorf("failed to resolve %s: %v", newPath, err)
+	}
+	if rel, err := filepath.Rel(r.srcPath, r.newPath); err == nil && (rel == "." || filepath.IsLocal(rel)) {
+		return fmt.Errorf("new data path %s must not be inside the current data directory %s", r.newPath, r.srcPath)
+	}
+	if _, err = os.Stat(r.newPath); os.IsNotExist(err) {
+		r.createdNewPath = true
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s: %v", r.newPath, err)
+	} else {
+		entries, err := os.ReadDir(r.newPath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", r.newPath, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("new data path %s is not empty", r.newPath)
+		}
+	}
+	if err = os.MkdirAll(r.newPath, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create %s: %v", r.newPath, err)
+	}
+
+	size, err := io.DirectorySize(r.srcPath)
+	if err != nil {
+		return r.removeCopy(err)
+	}
+	free, err := io.GetFreeSpace(r.newPath)
+	if err != nil {
+		return r.removeCopy(err)
+	}
+	if uint64(size) > free {
+		return r.removeCopy(fmt.Errorf("not enough space at %s: need %s, only %s available", r.newPath, io.FormatBytes(size), io.FormatBytes(int64(free))))
+	}
+	entries, err := os.ReadDir(r.srcPath)
+	if err != nil {
+		return r.removeCopy(fmt.Errorf("failed to read %s: %v", r.srcPath, err))
+	}
+	for _, entry := range entries {
+		r.copiedEntries = append(r.copiedEntries, entry.Name())
+	}
+
+	// the node must come back at least at the height it had, a node that was not running only has to answer
+	minHeight, _ := r.height()
+
+	fmt.Println("Stopping the service...")
+	if err = r.srv.Stop(); err != nil {
+		return r.removeCopy(fmt.Errorf("failed to stop service: %v", err))
+	}
+
+	fmt.Printf("Copying %s to %s\n", r.srcPath, r.newPath)
+	err = io.CopyDirectoryWithProgress(r.srcPath, r.newPath, func(copied, total int64) {
+		fmt.Printf("\r%s / %s", io.FormatBytes(copied), io.FormatBytes(total))
+	})
+	fmt.Println()
+	if err != nil {
+		return r.rollback(fmt.Errorf("failed to copy data: %v", err))
+	}
+
+	fmt.Println("Verifying the copy...")
+	if err = io.VerifyDirectoryCopy(r.srcPath, r.newPath); err != nil {
+		return r.rollback(fmt.Errorf("failed to verify copied data: %v", err))
+	}
+
+	if err = os.Rename(r.dataPath, r.oldPath); err != nil {
+		return r.rollback(fmt.Errorf("failed to move aside the old data directory: %v", err))
+	}
+	if err = os.Symlink(r.newPath, r.dataPath); err != nil {
+		return r.rollback(fmt.Errorf("failed to link the new data directory: %v", err))
+	}
+
+	fmt.Println("Starting the service...")
+	if err = r.srv.Start(); err != nil {
+		_ = r.srv.Stop()
+		return r.rollback(fmt.Errorf("failed to start service: %v", err))
+	}
+	fmt.Printf("Waiting for the node to serve height %d from %s...\n", minHeight, r.newPath)
+	if err = r.waitForNode(minHeight); err != nil {
+		_ = r.srv.Stop()
+		return r.rollback(err)
+	}
+
+	// the previous data lives behind oldPath, either as the original directory or as the old link's target
+	toRemove := r.oldPath
+	if wasSymlink {
+		if err = os.Remove(r.oldPath); err != nil {
+			return fmt.Errorf("data relocated, but failed to remove the previous link %s: %v", r.oldPath, err)
+		}
+		toRemove = r.srcPath
+	}
+	if r.keepOld {
+		fmt.Printf("Previous data is kept at %s\n", toRemove)
+		return nil
+	}
+	if err = os.RemoveAll(toRemove); err != nil {
+		return fmt.Errorf("data relocated, but failed to remove old data at %s: %v", toRemove, err)
+	}
+
+	return nil
+}
+
+// waitForNode waits for the node to serve at least minHeight
+func (r *dataRelocation) waitForNode(minHeight int64) error {
+	deadline := time.Now().Add(r.timeout)
+	var lastErr error
+	for {
+		height, err := r.height()
+		switch {
+		case err != nil:
+			lastErr = err
+		case height >= minHeight:
+			return nil
+		default:
+			lastErr = fmt.Errorf("the node serves height %d, below the height %d it had before the move", height, minHeight)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the node did not come back from %s within %s: %v", r.newPath, r.timeout, lastErr)
+		}
+		time.Sleep(r.pollInterval)
+	}
+}
+
+// removeCopy removes what was written to newPath, leaving a directory that existed before in place
+func (r *dataRelocation) removeCopy(cause error) error {
+	if r.createdNewPath {
+		_ = os.RemoveAll(r.newPath)
+		return cause
+	}
+	for _, name := range r.copiedEntries {
+		_ = os.RemoveAll(filepath.Join(r.newPath, name))
+	}
+	return cause
+}
+
+// rollback restores the original data directory and brings the node back up
+func (r *dataRelocation) rollback(cause error) error {
+	if io.FileOrFolderExists(r.oldPath) {
+		_ = os.Remove(r.dataPath)
+		_ = os.Rename(r.oldPath, r.dataPath)
+	}
+	cause = r.removeCopy(cause)
+	if err := r.srv.Start(); err != nil {
+		return fmt.Errorf("%v (rolled back, but failed to restart the service: %v)", cause, err)
+	}
+	return fmt.Errorf("%v (rolled back to %s)", cause, r.srcPath)
+}
@@ -0,0 +1,101 @@
+package initia
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	started, stopped int
+}
+
+func (s *fakeService) Create(string, string) error { return nil }
+func (s *fakeService) Log(int) error               { return nil }
+func (s *fakeService) Start() error                { s.started++; return nil }
+func (s *fakeService) Stop() error                 { s.stopped++; return nil }
+func (s *fakeService) Restart() error              { return nil }
+func (s *fakeService) PruneLogs() error            { return nil }
+
+func writeNodeData(t *testing.T) string {
+	home := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(home, "data", "application.db"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "data", "application.db", "000001.ldb"), []byte("state"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "data", "priv_validator_state.json"), []byte("{}"), 0600))
+	return home
+}
+
+func newTestRelocation(home string, heights ...int64) (*dataRelocation, *fakeService) {
+	srv := &fakeService{}
+	calls := 0
+	return &dataRelocation{
+		srv:          srv,
+		appHome:      home,
+		pollInterval: time.Millisecond,
+		timeout:      20 * time.Millisecond,
+		height: func() (int64, error) {
+			if calls >= len(heights) {
+				return heights[len(heights)-1], nil
+			}
+			calls++
+			if heights[calls-1] < 0 {
+				return 0, errors.New("connection refused")
+			}
+			return heights[calls-1], nil
+		},
+	}, srv
+}
+
+func TestRelocateNodeData(t *testing.T) {
+	home := writeNodeData(t)
+	newPath := filepath.Join(t.TempDir(), "initia-data")
+	// the node serves height 100 before the move, is not up yet right after the start, then comes back
+	r, srv := newTestRelocation(home, 100, -1, 100)
+
+	assert.NoError(t, r.run(newPath))
+	assert.Equal(t, 1, srv.stopped)
+	assert.Equal(t, 1, srv.started)
+
+	target, err := os.Readlink(filepath.Join(home, "data"))
+	assert.NoError(t, err)
+	assert.Equal(t, newPath, target)
+	content, err := os.ReadFile(filepath.Join(home, "data", "application.db", "000001.ldb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "state", string(content))
+	assert.NoFileExists(t, filepath.Join(home, "data.old"))
+}
+
+func TestRelocateNodeDataRollsBackWhenTheNodeDoesNotComeBack(t *testing.T) {
+	home := writeNodeData(t)
+	mountPoint := t.TempDir()
+	r, srv := newTestRelocation(home, 100, 42)
+
+	err := r.run(mountPoint)
+	assert.ErrorContains(t, err, "the node did not come back")
+	assert.ErrorContains(t, err, "below the height 100")
+	assert.Equal(t, 2, srv.started, "the node is restarted on the original data")
+
+	info, err := os.Lstat(filepath.Join(home, "data"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.FileExists(t, filepath.Join(home, "data", "application.db", "000001.ldb"))
+	assert.DirExists(t, mountPoint, "a directory that existed before the move is kept")
+	entries, err := os.ReadDir(mountPoint)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRelocateNodeDataRejectsNonEmptyPath(t *testing.T) {
+	home := writeNodeData(t)
+	mountPoint := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(mountPoint, "other.txt"), []byte("keep"), 0644))
+	r, srv := newTestRelocation(home, 100)
+
+	assert.ErrorContains(t, r.run(mountPoint), "is not empty")
+	assert.Equal(t, 0, srv.stopped)
+	assert.FileExists(t, filepath.Join(mountPoint, "other.txt"))
+}
@@ -0,0 +1,71 @@
+package minitia
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+// AdoptExistingRollup puts a minitiad home that was set up by hand under weave management.
+// Only binaries and service definitions are touched, the config and data directories are left as they are.
+func AdoptExistingRollup(minitiaHome, vm, version string) error {
+	if !io.FileOrFolderExists(filepath.Join(minitiaHome, "config", "config.toml")) {
+		return fmt.Errorf("no rollup node found at %s", minitiaHome)
+	}
+
+	units, err := service.FindExistingSystemdUnits([]string{AppName}, minitiaHome, common.MinitiaDirectory)
+	if err != nil {
+		return err
+	}
+
+	if vm == "" {
+		vm = inferVMFromUnits(units)
+		if vm == "" {
+			return fmt.Errorf("could not detect the VM of the rollup at %s, please provide it with --vm", minitiaHome)
+		}
+	}
+
+	if version == "" {
+		var candidates []string
+		for _, unit := range units {
+			candidates = append(candidates, unit.ExecPath)
+		}
+		version, err = cosmosutils.DetectNodeVersion(minitiaHome, candidates)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Detected mini%s %s\n", vm, version)
+
+	url, err := cosmosutils.GetMinitiaBinaryURL(vm, version)
+	if err != nil {
+		return err
+	}
+	binaryPath, err := cosmosutils.GetMinitiaBinaryPath(vm, version)
+	if err != nil {
+		return err
+	}
+	if _, err = cosmosutils.InstallMinitiaBinary(vm, version, url, binaryPath); err != nil {
+		return fmt.Errorf("failed to install minitia binary: %v", err)
+	}
+
+	return service.TakeOverExistingUnits(service.Minitia, units, func(srv service.Service) error {
+		return srv.Create(fmt.Sprintf("mini%s@%s", vm, version), minitiaHome)
+	})
+}
+
+func inferVMFromUnits(units []service.ExistingUnit) string {
+	for _, unit := range units {
+		for _, vm := range []string{"move", "wasm", "evm"} {
+			if strings.Contains(unit.ExecPath, "mini"+vm) {
+				return vm
+			}
+		}
+	}
+	return ""
+}
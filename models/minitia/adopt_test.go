@@ -0,0 +1,51 @@
+package minitia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/service"
+)
+
+func TestInferVMFromUnits(t *testing.T) {
+	tests := []struct {
+		name       string
+		units      []service.ExistingUnit
+		expectedVM string
+	}{
+		{
+			name:       "Move",
+			units:      []service.ExistingUnit{{Name: "rollup.service", ExecPath: "/home/ubuntu/.weave/data/minimove@v0.6.4/minitiad"}},
+			expectedVM: "move",
+		},
+		{
+			name:       "Wasm",
+			units:      []service.ExistingUnit{{Name: "rollup.service", ExecPath: "/opt/miniwasm/bin/minitiad"}},
+			expectedVM: "wasm",
+		},
+		{
+			name: "FirstUnitWithAVM",
+			units: []service.ExistingUnit{
+				{Name: "rollup.service", ExecPath: "/usr/local/bin/minitiad"},
+				{Name: "rollup-evm.service", ExecPath: "/opt/minievm/minitiad"},
+			},
+			expectedVM: "evm",
+		},
+		{
+			name:       "Unknown",
+			units:      []service.ExistingUnit{{Name: "rollup.service", ExecPath: "/usr/local/bin/minitiad"}},
+			expectedVM: "",
+		},
+		{
+			name:       "NoUnit",
+			expectedVM: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedVM, inferVMFromUnits(tc.units))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package minitia
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/types"
+)
+
+const LaunchCheckpointFilename = "launch.checkpoint.json"
+
+type LaunchStep string
+
+const (
+	LaunchStepSystemKeys     LaunchStep = "system_keys"
+	LaunchStepCelestiaFunded LaunchStep = "celestia_funded"
+	LaunchStepL1Funded       LaunchStep = "l1_funded"
+	LaunchStepRollupLaunched LaunchStep = "rollup_launched"
+	LaunchStepServiceCreated LaunchStep = "service_created"
+)
+
+// LaunchCheckpoint records the progress of a launch once the system keys exist, so that `weave rollup launch
+// --resume` can continue after a failure without funding the keys again. It holds the system key mnemonics, so the
+// file is only readable by the current user and is deleted once the launch completes.
+type LaunchCheckpoint struct {
+	MinitiaHome           string               `json:"minitia_home"`
+	VM                    string               `json:"vm"`
+	MinitiadVersion       string               `json:"minitiad_version"`
+	MinitiadEndpoint      string               `json:"minitiad_endpoint"`
+	ConfigPath            string               `json:"config_path,omitempty"`
+	Config                *types.MinitiaConfig `json:"config"`
+	L1Balances            LaunchL1Balances     `json:"l1_balances"`
+	FeeWhitelistAccounts  string               `json:"fee_whitelist_accounts,omitempty"`
+	CompletedSteps        []LaunchStep         `json:"completed_steps"`
+	L1FundingTxHash       string               `json:"l1_funding_tx_hash,omitempty"`
+	CelestiaFundingTxHash string               `json:"celestia_funding_tx_hash,omitempty"`
+	BridgeId              string               `json:"bridge_id,omitempty"`
+	UpdatedAt             time.Time            `json:"updated_at"`
+}
+
+// LaunchL1Balances are the amounts the system keys are funded with on the L1, or on Celestia for the batch submitter
+type LaunchL1Balances struct {
+	BridgeExecutor  string `json:"bridge_executor"`
+	OutputSubmitter string `json:"output_submitter"`
+	BatchSubmitter  string `json:"batch_submitter"`
+	Challenger      string `json:"challenger"`
+}
+
+func GetLaunchCheckpointPath() (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return filepath.Join(userHome, common.WeaveDataDirectory, LaunchCheckpointFilename), nil
+}
+
+// LoadLaunchCheckpoint reads the checkpoint of an unfinished launch, or returns nil when there is none
+func LoadLaunchCheckpoint() (*LaunchCheckpoint, error) {
+	path, err := GetLaunchCheckpointPath()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read launch checkpoint: %v", err)
+	}
+	var checkpoint LaunchCheckpoint
+	if err = json.Unmarshal(bz, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse launch checkpoint %s: %v", path, err)
+	}
+	if checkpoint.Config == nil || checkpoint.Config.L1Config == nil || checkpoint.Config.L2Config == nil || checkpoint.Config.SystemKeys == nil {
+		return nil, fmt.Errorf("launch checkpoint %s is incomplete", path)
+	}
+	return &checkpoint, nil
+}
+
+// DeleteLaunchCheckpoint removes the checkpoint, overwriting the mnemonics it holds
+func DeleteLaunchCheckpoint() error {
+	path, err := GetLaunchCheckpointPath()
+	if err != nil {
+		return err
+	}
+	if err = io.SecureDelete(path); err != nil {
+		return fmt.Errorf("failed to delete launch checkpoint %s: %v", path, err)
+	}
+	return nil
+}
+
+func (cp *LaunchCheckpoint) Save() error {
+	path, err := GetLaunchCheckpointPath()
+	if err != nil {
+		return err
+	}
+	cp.UpdatedAt = time.Now().UTC()
+	bz, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal launch checkpoint: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err = io.WriteSecretFile(path, bz); err != nil {
+		return fmt.Errorf("failed to write launch checkpoint: %v", err)
+	}
+	return nil
+}
+
+func (cp *LaunchCheckpoint) IsDone(step LaunchStep) bool {
+	return slices.Contains(cp.CompletedSteps, step)
+}
+
+// LastStep returns the latest completed step
+func (cp *LaunchCheckpoint) LastStep() LaunchStep {
+	if len(cp.CompletedSteps) == 0 {
+		return ""
+	}
+	return cp.CompletedSteps[len(cp.CompletedSteps)-1]
+}
+
+func (cp *LaunchCheckpoint) complete(step LaunchStep) {
+	if !cp.IsDone(step) {
+		cp.CompletedSteps = append(cp.CompletedSteps, step)
+	}
+}
+
+func (cp *LaunchCheckpoint) undo(step LaunchStep) {
+	cp.CompletedSteps = slices.DeleteFunc(cp.CompletedSteps, func(s LaunchStep) bool { return s == step })
+}
+
+// startCheckpoint records the answers and system keys of the launch before anything is broadcast
+func (ls *LaunchState) startCheckpoint(minitiaHome string) error {
+	minitiaConfig := ls.existingConfig
+	if !ls.launchFromExistingConfig {
+		minitiaConfig = ls.BuildMinitiaConfig()
+	}
+	ls.checkpoint = &LaunchCheckpoint{
+		MinitiaHome:      minitiaHome,
+		VM:               strings.ToLower(ls.vmType),
+		MinitiadVersion:  ls.minitiadVersion,
+		MinitiadEndpoint: ls.minitiadEndpoint,
+		Config:           minitiaConfig,
+		L1Balances: LaunchL1Balances{
+			BridgeExecutor:  ls.systemKeyL1BridgeExecutorBalance,
+			OutputSubmitter: ls.systemKeyL1OutputSubmitterBalance,
+			BatchSubmitter:  ls.systemKeyL1BatchSubmitterBalance,
+			Challenger:      ls.systemKeyL1ChallengerBalance,
+		},
+		FeeWhitelistAccounts: ls.feeWhitelistAccounts,
+		CompletedSteps:       []LaunchStep{LaunchStepSystemKeys},
+	}
+	if ls.launchFromExistingConfig {
+		ls.checkpoint.ConfigPath = ls.existingConfigPath
+	}
+	return ls.checkpoint.Save()
+}
+
+// completeCheckpointStep marks step as done together with the tx hashes and bridge ID known so far
+func (ls *LaunchState) completeCheckpointStep(step LaunchStep, bridgeId string) error {
+	if ls.checkpoint == nil {
+		return nil
+	}
+	ls.checkpoint.complete(step)
+	ls.checkpoint.L1FundingTxHash = ls.systemKeyL1FundingTxHash
+	ls.checkpoint.CelestiaFundingTxHash = ls.systemKeyCelestiaFundingTxHash
+	if bridgeId != "" {
+		ls.checkpoint.BridgeId = bridgeId
+	}
+	if err := ls.checkpoint.Save(); err != nil {
+		return fmt.Errorf("%v, the launch cannot be resumed past the %s step", err, step)
+	}
+	return nil
+}
+
+// recordFundingTxHashes saves the funding tx hashes as soon as they are broadcast, before their step is complete
+func (ls *LaunchState) recordFundingTxHashes() error {
+	if ls.checkpoint == nil {
+		return nil
+	}
+	ls.checkpoint.L1FundingTxHash = ls.systemKeyL1FundingTxHash
+	ls.checkpoint.CelestiaFundingTxHash = ls.systemKeyCelestiaFundingTxHash
+	if err := ls.checkpoint.Save(); err != nil {
+		return fmt.Errorf("%v, the launch cannot be resumed without funding the system keys again", err)
+	}
+	return nil
+}
+
+// RestoreFromCheckpoint fills the state with the answers, system keys and progress of an unfinished launch
+func (ls *LaunchState) RestoreFromCheckpoint(cp *LaunchCheckpoint) error {
+	vmType, err := ParseVMType(cp.VM)
+	if err != nil {
+		return err
+	}
+	cfg := cp.Config
+	ls.vmType = string(vmType)
+	ls.minitiadVersion = cp.MinitiadVersion
+	ls.minitiadEndpoint = cp.MinitiadEndpoint
+	ls.l1ChainId = cfg.L1Config.ChainID
+	ls.l1RPC = cfg.L1Config.RpcUrl
+	ls.chainId = cfg.L2Config.ChainID
+	ls.gasDenom = cfg.L2Config.Denom
+	ls.moniker = cfg.L2Config.Moniker
+	if cfg.OpBridge != nil {
+		ls.opBridgeSubmissionInterval = cfg.OpBridge.OutputSubmissionInterval
+		ls.opBridgeOutputFinalizationPeriod = cfg.OpBridge.OutputFinalizationPeriod
+		ls.opBridgeBatchSubmissionTarget = cfg.OpBridge.BatchSubmissionTarget
+		ls.batchSubmissionIsCelestia = cfg.OpBridge.BatchSubmissionTarget == BatchSubmissionTargetCelestia
+		ls.enableOracle = cfg.OpBridge.EnableOracle
+	}
+	if cfg.GenesisAccounts != nil {
+		ls.genesisAccounts = append(types.GenesisAccounts{}, *cfg.GenesisAccounts...)
+	}
+
+	keys := cfg.SystemKeys
+	for _, key := range []struct {
+		account           *types.SystemAccount
+		mnemonic, address *string
+	}{
+		{keys.Validator, &ls.systemKeyOperatorMnemonic, &ls.systemKeyOperatorAddress},
+		{keys.BridgeExecutor, &ls.systemKeyBridgeExecutorMnemonic, &ls.systemKeyBridgeExecutorAddress},
+		{keys.OutputSubmitter, &ls.systemKeyOutputSubmitterMnemonic, &ls.systemKeyOutputSubmitterAddress},
+		{keys.BatchSubmitter, &ls.systemKeyBatchSubmitterMnemonic, &ls.systemKeyBatchSubmitterAddress},
+		{keys.Challenger, &ls.systemKeyChallengerMnemonic, &ls.systemKeyChallengerAddress},
+	} {
+		if key.account == nil {
+			return fmt.Errorf("launch checkpoint is missing a system key")
+		}
+		*key.mnemonic = key.account.Mnemonic
+		*key.address = key.account.L1Address
+		if *key.address == "" {
+			*key.address = key.account.DAAddress
+		}
+	}
+
+	ls.systemKeyL1BridgeExecutorBalance = cp.L1Balances.BridgeExecutor
+	ls.systemKeyL1OutputSubmitterBalance = cp.L1Balances.OutputSubmitter
+	ls.systemKeyL1BatchSubmitterBalance = cp.L1Balances.BatchSubmitter
+	ls.systemKeyL1ChallengerBalance = cp.L1Balances.Challenger
+	ls.feeWhitelistAccounts = cp.FeeWhitelistAccounts
+	ls.systemKeyL1FundingTxHash = cp.L1FundingTxHash
+	ls.systemKeyCelestiaFundingTxHash = cp.CelestiaFundingTxHash
+
+	if cp.ConfigPath != "" {
+		ls.launchFromExistingConfig = true
+		ls.existingConfigPath = cp.ConfigPath
+		ls.existingConfig = cfg
+	}
+	ls.generateKeys = false
+	ls.checkpoint = cp
+	return nil
+}
+
+// fundingDone reports whether every funding transaction of the launch has been sent
+func (ls *LaunchState) fundingDone() bool {
+	return ls.systemKeyL1FundingTxHash != "" && (!ls.batchSubmissionIsCelestia || ls.systemKeyCelestiaFundingTxHash != "")
+}
@@ -0,0 +1,119 @@
+package minitia
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/types"
+)
+
+func newCheckpointTestState() *LaunchState {
+	state := NewLaunchState()
+	state.vmType = string(Move)
+	state.minitiadVersion = "v1.0.0"
+	state.minitiadEndpoint = "https://example.com/minimove.tar.gz"
+	state.l1ChainId = "initiation-2"
+	state.l1RPC = "https://rpc.example.com"
+	state.chainId = "mini-1"
+	state.gasDenom = "umin"
+	state.moniker = "operator"
+	state.opBridgeSubmissionInterval = "1m"
+	state.opBridgeOutputFinalizationPeriod = "168h"
+	state.opBridgeBatchSubmissionTarget = BatchSubmissionTargetCelestia
+	state.batchSubmissionIsCelestia = true
+	state.genesisAccounts = types.GenesisAccounts{{Address: genesisAddress1, Coins: "100umin"}}
+	state.systemKeyOperatorMnemonic = "operator mnemonic"
+	state.systemKeyOperatorAddress = "init1operator"
+	state.systemKeyBridgeExecutorMnemonic = "bridge executor mnemonic"
+	state.systemKeyBridgeExecutorAddress = "init1bridgeexecutor"
+	state.systemKeyOutputSubmitterMnemonic = "output submitter mnemonic"
+	state.systemKeyOutputSubmitterAddress = "init1outputsubmitter"
+	state.systemKeyBatchSubmitterMnemonic = "batch submitter mnemonic"
+	state.systemKeyBatchSubmitterAddress = "celestia1batchsubmitter"
+	state.systemKeyChallengerMnemonic = "challenger mnemonic"
+	state.systemKeyChallengerAddress = "init1challenger"
+	state.systemKeyL1BridgeExecutorBalance = "1000000uinit"
+	state.systemKeyL1OutputSubmitterBalance = "2000000uinit"
+	state.systemKeyL1BatchSubmitterBalance = "3000000utia"
+	state.systemKeyL1ChallengerBalance = "4000000uinit"
+	return state
+}
+
+func TestLaunchCheckpointRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	checkpoint, err := LoadLaunchCheckpoint()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	state := newCheckpointTestState()
+	assert.NoError(t, state.startCheckpoint("/tmp/minitia"))
+	state.systemKeyCelestiaFundingTxHash = "CELESTIA"
+	assert.NoError(t, state.completeCheckpointStep(LaunchStepCelestiaFunded, ""))
+	state.systemKeyL1FundingTxHash = "L1"
+	assert.NoError(t, state.completeCheckpointStep(LaunchStepL1Funded, ""))
+	assert.NoError(t, state.completeCheckpointStep(LaunchStepRollupLaunched, "7"))
+
+	path, err := GetLaunchCheckpointPath()
+	assert.NoError(t, err)
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	checkpoint, err = LoadLaunchCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, []LaunchStep{LaunchStepSystemKeys, LaunchStepCelestiaFunded, LaunchStepL1Funded, LaunchStepRollupLaunched}, checkpoint.CompletedSteps)
+	assert.Equal(t, LaunchStepRollupLaunched, checkpoint.LastStep())
+	assert.Equal(t, "7", checkpoint.BridgeId)
+
+	restored := NewLaunchState()
+	assert.NoError(t, restored.RestoreFromCheckpoint(checkpoint))
+	assert.Equal(t, state.vmType, restored.vmType)
+	assert.Equal(t, state.chainId, restored.chainId)
+	assert.Equal(t, state.l1RPC, restored.l1RPC)
+	assert.Equal(t, state.genesisAccounts, restored.genesisAccounts)
+	assert.Equal(t, state.systemKeyOperatorAddress, restored.systemKeyOperatorAddress)
+	assert.Equal(t, state.systemKeyBatchSubmitterAddress, restored.systemKeyBatchSubmitterAddress)
+	assert.Equal(t, state.systemKeyChallengerMnemonic, restored.systemKeyChallengerMnemonic)
+	assert.Equal(t, state.systemKeyL1BatchSubmitterBalance, restored.systemKeyL1BatchSubmitterBalance)
+	assert.True(t, restored.batchSubmissionIsCelestia)
+	assert.False(t, restored.generateKeys)
+	assert.False(t, restored.launchFromExistingConfig)
+	assert.True(t, restored.fundingDone())
+
+	assert.NoError(t, DeleteLaunchCheckpoint())
+	checkpoint, err = LoadLaunchCheckpoint()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestLaunchCheckpointUndo(t *testing.T) {
+	checkpoint := &LaunchCheckpoint{CompletedSteps: []LaunchStep{LaunchStepSystemKeys, LaunchStepL1Funded}}
+	checkpoint.undo(LaunchStepL1Funded)
+	assert.False(t, checkpoint.IsDone(LaunchStepL1Funded))
+	assert.Equal(t, LaunchStepSystemKeys, checkpoint.LastStep())
+
+	// a checkpoint without the system keys cannot be resumed
+	t.Setenv("HOME", t.TempDir())
+	checkpoint.Config = &types.MinitiaConfig{}
+	assert.NoError(t, checkpoint.Save())
+	_, err := LoadLaunchCheckpoint()
+	assert.ErrorContains(t, err, "incomplete")
+}
+
+func TestRecordFundingTxHashes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	state := newCheckpointTestState()
+	assert.NoError(t, state.startCheckpoint("/tmp/minitia"))
+	state.systemKeyL1FundingTxHash = "L1"
+	assert.NoError(t, state.recordFundingTxHashes())
+
+	// a broadcast tx is saved before its inclusion, so a resumed launch checks the balances instead of funding again
+	checkpoint, err := LoadLaunchCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, "L1", checkpoint.L1FundingTxHash)
+	assert.False(t, checkpoint.IsDone(LaunchStepL1Funded))
+}
@@ -0,0 +1,183 @@
+package minitia
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/registry"
+)
+
+const (
+	// DefaultFundingRunwayDays is the runway the suggested system key amounts are sized for
+	DefaultFundingRunwayDays int = 30
+	// DefaultBatchSubmissionInterval matches the max submission time of the OPinit executor, a batch is submitted at
+	// least this often
+	DefaultBatchSubmissionInterval = time.Hour
+	// DefaultBatchSize is the expected size in bytes of one batch of a moderately busy rollup
+	DefaultBatchSize int64 = 100_000
+
+	DefaultCelestiaGasPrices = "0.04" + DefaultCelestiaGasDenom
+
+	// Typical gas per message, measured on the Initia L1 and Celestia
+	proposeOutputGas           int64 = 150_000
+	recordBatchBaseGas         int64 = 100_000
+	recordBatchGasPerByte      int64 = 10
+	celestiaPayForBlobsBaseGas int64 = 75_000
+	celestiaGasPerBlobByte     int64 = 8
+	// The bridge executor and challenger only send L1 transactions occasionally, they are budgeted one a day
+	occasionalTxGas int64 = 200_000
+)
+
+// FundingAssumptions is what the funding estimate of the system keys is based on
+type FundingAssumptions struct {
+	OutputSubmissionInterval  time.Duration
+	BatchSubmissionInterval   time.Duration
+	BatchSize                 int64
+	BatchSubmissionIsCelestia bool
+	L1GasPrices               string
+	DAGasPrices               string
+}
+
+// NewFundingAssumptions uses the current gas prices from the registries of the L1 and the DA layer, falling back to
+// the defaults when a registry cannot be reached
+func NewFundingAssumptions(l1ChainId, outputSubmissionInterval string, batchSubmissionIsCelestia bool) (FundingAssumptions, error) {
+	interval, err := time.ParseDuration(outputSubmissionInterval)
+	if err != nil || interval <= 0 {
+		return FundingAssumptions{}, fmt.Errorf("invalid output submission interval: %s", outputSubmissionInterval)
+	}
+	assumptions := FundingAssumptions{
+		OutputSubmissionInterval:  interval,
+		BatchSubmissionInterval:   DefaultBatchSubmissionInterval,
+		BatchSize:                 DefaultBatchSize,
+		BatchSubmissionIsCelestia: batchSubmissionIsCelestia,
+		L1GasPrices:               DefaultL1GasPrices,
+	}
+	if l1Registry, err := registry.GetL1ChainRegistry(l1ChainId); err == nil {
+		if gasPrices, err := l1Registry.GetGasPriceByDenom(DefaultL1GasDenom); err == nil {
+			assumptions.L1GasPrices = gasPrices
+		}
+	}
+	assumptions.DAGasPrices = assumptions.L1GasPrices
+	if batchSubmissionIsCelestia {
+		assumptions.DAGasPrices = DefaultCelestiaGasPrices
+		if celestiaRegistry, err := registry.GetCelestiaChainRegistry(l1ChainId); err == nil {
+			if gasPrices, err := celestiaRegistry.GetGasPriceByDenom(DefaultCelestiaGasDenom); err == nil {
+				assumptions.DAGasPrices = gasPrices
+			}
+		}
+	}
+	return assumptions, nil
+}
+
+// FundingEstimate is the expected spending of one system key on one chain
+type FundingEstimate struct {
+	Role      string
+	Chain     string
+	Denom     string
+	dailyCost *big.Rat
+}
+
+// EstimateFunding returns the expected daily spending of the bridge executor, output submitter, batch submitter and
+// challenger
+func EstimateFunding(a FundingAssumptions) ([]FundingEstimate, error) {
+	if a.OutputSubmissionInterval <= 0 || a.BatchSubmissionInterval <= 0 {
+		return nil, fmt.Errorf("submission intervals must be positive")
+	}
+	l1GasPrice, l1Denom, err := parseGasPrice(a.L1GasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid L1 gas prices: %v", err)
+	}
+	daGasPrice, daDenom, err := parseGasPrice(a.DAGasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DA gas prices: %v", err)
+	}
+
+	batchGas := recordBatchBaseGas + recordBatchGasPerByte*a.BatchSize
+	batchChain := "L1"
+	if a.BatchSubmissionIsCelestia {
+		batchGas = celestiaPayForBlobsBaseGas + celestiaGasPerBlobByte*a.BatchSize
+		batchChain = "DA"
+	}
+
+	return []FundingEstimate{
+		{Role: "Bridge Executor", Chain: "L1", Denom: l1Denom, dailyCost: dailyCost(time.Hour*24, occasionalTxGas, l1GasPrice)},
+		{Role: "Output Submitter", Chain: "L1", Denom: l1Denom, dailyCost: dailyCost(a.OutputSubmissionInterval, proposeOutputGas, l1GasPrice)},
+		{Role: "Batch Submitter", Chain: batchChain, Denom: daDenom, dailyCost: dailyCost(a.BatchSubmissionInterval, batchGas, daGasPrice)},
+		{Role: "Challenger", Chain: "L1", Denom: l1Denom, dailyCost: dailyCost(time.Hour*24, occasionalTxGas, l1GasPrice)},
+	}, nil
+}
+
+func parseGasPrice(gasPrices string) (*big.Rat, string, error) {
+	amount, denom, err := common.ParseDecCoin(gasPrices)
+	if err != nil {
+		return nil, "", err
+	}
+	price, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid amount: %s", amount)
+	}
+	return price, denom, nil
+}
+
+// dailyCost is the fee of sending a transaction using gas at gasPrice once every interval, over a day
+func dailyCost(interval time.Duration, gas int64, gasPrice *big.Rat) *big.Rat {
+	txsPerDay := new(big.Rat).SetFrac64(int64(24*time.Hour), int64(interval))
+	cost := new(big.Rat).Mul(gasPrice, new(big.Rat).SetInt64(gas))
+	return cost.Mul(cost, txsPerDay)
+}
+
+func ceilRat(r *big.Rat) *big.Int {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
+
+// DailyCost returns the expected spending per day, rounded up
+func (e FundingEstimate) DailyCost() *big.Int {
+	return ceilRat(e.dailyCost)
+}
+
+// SuggestAmount returns the amount that lasts for the given number of days
+func (e FundingEstimate) SuggestAmount(days int) *big.Int {
+	return ceilRat(new(big.Rat).Mul(e.dailyCost, new(big.Rat).SetInt64(int64(days))))
+}
+
+// Runway returns how long balance lasts at the expected spending
+func (e FundingEstimate) Runway(balance *big.Int) time.Duration {
+	if e.dailyCost.Sign() <= 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	days, _ := new(big.Rat).Quo(new(big.Rat).SetInt(balance), e.dailyCost).Float64()
+	if days*24 >= float64((1<<63-1)/int64(time.Hour)) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(days * float64(24*time.Hour))
+}
+
+// FormatRunway describes a runway in whole days, or hours when it is shorter than a day
+func FormatRunway(runway time.Duration) string {
+	switch {
+	case runway < time.Hour:
+		return "less than an hour"
+	case runway < 24*time.Hour:
+		return fmt.Sprintf("~%d hours", int(runway.Hours()))
+	case runway > 3650*24*time.Hour:
+		return "more than 10 years"
+	default:
+		return fmt.Sprintf("~%d days", int(runway.Hours()/24))
+	}
+}
+
+// FindFundingEstimate returns the estimate for the role, if any
+func FindFundingEstimate(estimates []FundingEstimate, role string) (FundingEstimate, bool) {
+	for _, estimate := range estimates {
+		if estimate.Role == role {
+			return estimate, true
+		}
+	}
+	return FundingEstimate{}, false
+}
@@ -0,0 +1,89 @@
+package minitia
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/cosmosutils"
+)
+
+func TestEstimateFunding(t *testing.T) {
+	assumptions := FundingAssumptions{
+		OutputSubmissionInterval: time.Hour,
+		BatchSubmissionInterval:  time.Hour,
+		BatchSize:                100_000,
+		L1GasPrices:              "0.015uinit",
+		DAGasPrices:              "0.015uinit",
+	}
+	estimates, err := EstimateFunding(assumptions)
+	assert.NoError(t, err)
+	assert.Len(t, estimates, 4)
+
+	outputSubmitter, found := FindFundingEstimate(estimates, "Output Submitter")
+	assert.True(t, found)
+	// 24 outputs of 150000 gas at 0.015uinit
+	assert.Equal(t, "54000", outputSubmitter.DailyCost().String())
+	assert.Equal(t, "1620000", outputSubmitter.SuggestAmount(30).String())
+	assert.Equal(t, 10*24*time.Hour, outputSubmitter.Runway(big.NewInt(540000)))
+
+	batchSubmitter, _ := FindFundingEstimate(estimates, "Batch Submitter")
+	assert.Equal(t, "L1", batchSubmitter.Chain)
+	// 24 batches of 100000 + 10 * 100000 gas at 0.015uinit
+	assert.Equal(t, "396000", batchSubmitter.DailyCost().String())
+
+	assumptions.BatchSubmissionIsCelestia = true
+	assumptions.DAGasPrices = "0.02utia"
+	estimates, err = EstimateFunding(assumptions)
+	assert.NoError(t, err)
+	batchSubmitter, _ = FindFundingEstimate(estimates, "Batch Submitter")
+	assert.Equal(t, "DA", batchSubmitter.Chain)
+	assert.Equal(t, "utia", batchSubmitter.Denom)
+	// 24 blobs of 75000 + 8 * 100000 gas at 0.02utia
+	assert.Equal(t, "420000", batchSubmitter.DailyCost().String())
+
+	assumptions.L1GasPrices = "uinit"
+	_, err = EstimateFunding(assumptions)
+	assert.ErrorContains(t, err, "invalid L1 gas prices")
+}
+
+func TestNewFundingAssumptions(t *testing.T) {
+	assumptions, err := NewFundingAssumptions("unknown-1", "5m", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, assumptions.OutputSubmissionInterval)
+	assert.Equal(t, DefaultBatchSubmissionInterval, assumptions.BatchSubmissionInterval)
+	assert.Equal(t, DefaultL1GasPrices, assumptions.L1GasPrices)
+	assert.NotEmpty(t, assumptions.DAGasPrices)
+
+	_, err = NewFundingAssumptions("unknown-1", "", false)
+	assert.ErrorContains(t, err, "invalid output submission interval")
+}
+
+func TestFormatRunway(t *testing.T) {
+	assert.Equal(t, "less than an hour", FormatRunway(30*time.Minute))
+	assert.Equal(t, "~5 hours", FormatRunway(5*time.Hour+10*time.Minute))
+	assert.Equal(t, "~12 days", FormatRunway(12*24*time.Hour+time.Hour))
+	assert.Equal(t, "more than 10 years", FormatRunway(time.Duration(1<<63-1)))
+}
+
+func TestRollupInfoRunways(t *testing.T) {
+	info, err := readRollupInfo(writeRollupHome(t))
+	assert.NoError(t, err)
+	info.outputSubmissionInterval = "1h"
+	for idx := range info.SystemKeys {
+		info.SystemKeys[idx].Balances = cosmosutils.Coins{{Denom: "uinit", Amount: "100000000"}}
+	}
+	info.estimateRunways()
+
+	for _, key := range info.SystemKeys {
+		switch key.Role {
+		case "Operator":
+			assert.Empty(t, key.Runway)
+		default:
+			assert.NotEmpty(t, key.Runway, key.Role)
+		}
+	}
+	assert.Contains(t, info.String(), "runway")
+}
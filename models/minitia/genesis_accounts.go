@@ -0,0 +1,264 @@
+package minitia
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	goio "io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/types"
+)
+
+const (
+	// MaxGenesisAccountErrors caps how many invalid rows of a genesis accounts file are reported
+	MaxGenesisAccountErrors int = 20
+	// MaxListedGenesisAccounts caps how many genesis accounts are listed in the launch summary
+	MaxListedGenesisAccounts int = 10
+
+	maxDecimalPlaces int = 18
+)
+
+type genesisAccountRow struct {
+	location string
+	account  types.GenesisAccount
+}
+
+// LoadGenesisAccountsFile reads genesis accounts from a CSV file with `address,coins` rows or from a JSON list of
+// {"address", "coins"} objects. Every row is validated, all invalid rows are reported at once, and rows of the same
+// address are merged into a single account.
+func LoadGenesisAccountsFile(path string) (types.GenesisAccounts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis accounts file: %v", err)
+	}
+
+	var rows []genesisAccountRow
+	if isJSONGenesisAccounts(path, data) {
+		rows, err = parseGenesisAccountsJSON(data)
+	} else {
+		rows, err = parseGenesisAccountsCSV(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s contains no genesis accounts", path)
+	}
+
+	var errs []string
+	accounts := make(types.GenesisAccounts, 0, len(rows))
+	for _, row := range rows {
+		if err = validateGenesisAccount(row.account); err != nil {
+			errs = append(errs, fmt.Sprintf("  - %s: %v", row.location, err))
+			continue
+		}
+		accounts = append(accounts, row.account)
+	}
+	if len(errs) > 0 {
+		total := len(errs)
+		if total > MaxGenesisAccountErrors {
+			errs = append(errs[:MaxGenesisAccountErrors], fmt.Sprintf("  ... and %d more", total-MaxGenesisAccountErrors))
+		}
+		return nil, fmt.Errorf("%d invalid genesis accounts in %s:\n%s", total, path, strings.Join(errs, "\n"))
+	}
+
+	return MergeGenesisAccounts(accounts)
+}
+
+func isJSONGenesisAccounts(path string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return true
+	case ".csv":
+		return false
+	}
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{')
+}
+
+// parseGenesisAccountsJSON accepts either a list of accounts or an object with a `genesis_accounts` list, so the
+// genesis accounts of a rollup config file can be imported as is
+func parseGenesisAccountsJSON(data []byte) ([]genesisAccountRow, error) {
+	var accounts types.GenesisAccounts
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			GenesisAccounts types.GenesisAccounts `json:"genesis_accounts"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, err
+		}
+		accounts = wrapper.GenesisAccounts
+	} else if err := json.Unmarshal(trimmed, &accounts); err != nil {
+		return nil, err
+	}
+
+	rows := make([]genesisAccountRow, len(accounts))
+	for idx, account := range accounts {
+		rows[idx] = genesisAccountRow{location: fmt.Sprintf("entry %d", idx+1), account: account}
+	}
+	return rows, nil
+}
+
+// parseGenesisAccountsCSV reads `address,coins` rows. An optional header row and `#` comments are skipped, and any
+// columns after the address are treated as coins, so `init1...,100uinit,5uusdc` does not need quoting.
+func parseGenesisAccountsCSV(data []byte) ([]genesisAccountRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []genesisAccountRow
+	for idx := 0; ; idx++ {
+		record, err := reader.Read()
+		if err == goio.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if idx == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		location := fmt.Sprintf("line %d", line)
+
+		var coins []string
+		for _, coin := range record[1:] {
+			if coin = strings.TrimSpace(coin); coin != "" {
+				coins = append(coins, coin)
+			}
+		}
+		rows = append(rows, genesisAccountRow{
+			location: location,
+			account: types.GenesisAccount{
+				Address: strings.TrimSpace(record[0]),
+				Coins:   strings.Join(coins, ","),
+			},
+		})
+	}
+	return rows, nil
+}
+
+func validateGenesisAccount(account types.GenesisAccount) error {
+	if account.Address == "" {
+		return errors.New("address is required")
+	}
+	if err := common.IsValidAddress(account.Address); err != nil {
+		return fmt.Errorf("%v: %s", err, account.Address)
+	}
+	if account.Coins == "" {
+		return errors.New("coins are required")
+	}
+	for _, coin := range strings.Split(account.Coins, ",") {
+		if err := common.ValidateDecCoin(coin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type coinAmounts map[string]*big.Rat
+
+func (c coinAmounts) add(coins string) error {
+	for _, coin := range strings.Split(coins, ",") {
+		amount, denom, err := common.ParseDecCoin(coin)
+		if err != nil {
+			return err
+		}
+		value, ok := new(big.Rat).SetString(amount)
+		if !ok {
+			return fmt.Errorf("invalid amount: %s", amount)
+		}
+		if existing, found := c[denom]; found {
+			existing.Add(existing, value)
+		} else {
+			c[denom] = value
+		}
+	}
+	return nil
+}
+
+// coins returns the amounts sorted by denom in the `<amount><denom>` notation
+func (c coinAmounts) coins() []string {
+	denoms := make([]string, 0, len(c))
+	for denom := range c {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	coins := make([]string, len(denoms))
+	for idx, denom := range denoms {
+		coins[idx] = formatDecAmount(c[denom]) + denom
+	}
+	return coins
+}
+
+func formatDecAmount(amount *big.Rat) string {
+	if amount.IsInt() {
+		return amount.Num().String()
+	}
+	return strings.TrimRight(strings.TrimRight(amount.FloatString(maxDecimalPlaces), "0"), ".")
+}
+
+// MergeGenesisAccounts combines accounts with the same address by adding up their coins per denom, keeping the
+// order in which the addresses first appear
+func MergeGenesisAccounts(accounts types.GenesisAccounts) (types.GenesisAccounts, error) {
+	var order []string
+	balances := make(map[string]coinAmounts)
+	for _, account := range accounts {
+		balance, ok := balances[account.Address]
+		if !ok {
+			balance = make(coinAmounts)
+			balances[account.Address] = balance
+			order = append(order, account.Address)
+		}
+		if err := balance.add(account.Coins); err != nil {
+			return nil, fmt.Errorf("invalid coins for %s: %v", account.Address, err)
+		}
+	}
+
+	merged := make(types.GenesisAccounts, len(order))
+	for idx, address := range order {
+		merged[idx] = types.GenesisAccount{
+			Address: address,
+			Coins:   strings.Join(balances[address].coins(), ","),
+		}
+	}
+	return merged, nil
+}
+
+// GenesisAccountTotals adds up the coins of all accounts per denom, sorted by denom
+func GenesisAccountTotals(accounts types.GenesisAccounts) ([]string, error) {
+	totals := make(coinAmounts)
+	for _, account := range accounts {
+		if err := totals.add(account.Coins); err != nil {
+			return nil, fmt.Errorf("invalid coins for %s: %v", account.Address, err)
+		}
+	}
+	return totals.coins(), nil
+}
+
+// GenesisAccountsSummary describes the genesis accounts in a few lines, listing at most MaxListedGenesisAccounts
+// of them followed by the totals per denom
+func GenesisAccountsSummary(accounts types.GenesisAccounts) string {
+	var b strings.Builder
+	for idx, account := range accounts {
+		if idx == MaxListedGenesisAccounts {
+			b.WriteString(fmt.Sprintf("  ... and %d more\n", len(accounts)-MaxListedGenesisAccounts))
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %s\tInitial Balance: %s\n", account.Address, account.Coins))
+	}
+	if totals, err := GenesisAccountTotals(accounts); err == nil {
+		b.WriteString(fmt.Sprintf("  Total of %d accounts: %s\n", len(accounts), strings.Join(totals, ", ")))
+	}
+	return b.String()
+}
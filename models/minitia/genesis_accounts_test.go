@@ -0,0 +1,125 @@
+package minitia
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/types"
+)
+
+var (
+	genesisAddress1 = "init1" + strings.Repeat("a", 38)
+	genesisAddress2 = "init1" + strings.Repeat("b", 38)
+	genesisAddress3 = "init1" + strings.Repeat("c", 38)
+)
+
+func writeGenesisAccountsFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadGenesisAccountsFileCSV(t *testing.T) {
+	path := writeGenesisAccountsFile(t, "airdrop.csv", strings.Join([]string{
+		"address,coins",
+		"# team allocation",
+		genesisAddress1 + ",100umin",
+		genesisAddress2 + ", \"50umin,7uusdc\"",
+		genesisAddress1 + ",0.5umin,3uusdc",
+		genesisAddress3 + ",1.25umin",
+	}, "\n"))
+
+	accounts, err := LoadGenesisAccountsFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, types.GenesisAccounts{
+		{Address: genesisAddress1, Coins: "100.5umin,3uusdc"},
+		{Address: genesisAddress2, Coins: "50umin,7uusdc"},
+		{Address: genesisAddress3, Coins: "1.25umin"},
+	}, accounts)
+
+	totals, err := GenesisAccountTotals(accounts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"151.75umin", "10uusdc"}, totals)
+}
+
+func TestLoadGenesisAccountsFileJSON(t *testing.T) {
+	list := `[{"address": "` + genesisAddress1 + `", "coins": "10umin"}, {"address": "` + genesisAddress1 + `", "coins": "5umin"}]`
+	accounts, err := LoadGenesisAccountsFile(writeGenesisAccountsFile(t, "airdrop.json", list))
+	assert.NoError(t, err)
+	assert.Equal(t, types.GenesisAccounts{{Address: genesisAddress1, Coins: "15umin"}}, accounts)
+
+	wrapped := `{"genesis_accounts": [{"address": "` + genesisAddress2 + `", "coins": "1umin"}]}`
+	accounts, err = LoadGenesisAccountsFile(writeGenesisAccountsFile(t, "accounts", wrapped))
+	assert.NoError(t, err)
+	assert.Equal(t, types.GenesisAccounts{{Address: genesisAddress2, Coins: "1umin"}}, accounts)
+}
+
+func TestLoadGenesisAccountsFileInvalidRows(t *testing.T) {
+	path := writeGenesisAccountsFile(t, "airdrop.csv", strings.Join([]string{
+		genesisAddress1 + ",100umin",
+		"cosmos1invalid,100umin",
+		genesisAddress2,
+		genesisAddress3 + ",-5umin",
+	}, "\n"))
+
+	_, err := LoadGenesisAccountsFile(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "3 invalid genesis accounts")
+	assert.Contains(t, err.Error(), "line 2: invalid address format: cosmos1invalid")
+	assert.Contains(t, err.Error(), "line 3: coins are required")
+	assert.Contains(t, err.Error(), "line 4: invalid decimal coin expression")
+
+	_, err = LoadGenesisAccountsFile(writeGenesisAccountsFile(t, "empty.csv", "address,coins\n"))
+	assert.ErrorContains(t, err, "contains no genesis accounts")
+}
+
+func TestGenesisAccountsSummary(t *testing.T) {
+	var accounts types.GenesisAccounts
+	for idx := 0; idx < MaxListedGenesisAccounts+5; idx++ {
+		accounts = append(accounts, types.GenesisAccount{Address: genesisAddress1, Coins: "2umin"})
+	}
+
+	summary := GenesisAccountsSummary(accounts)
+	assert.Equal(t, MaxListedGenesisAccounts, strings.Count(summary, genesisAddress1))
+	assert.Contains(t, summary, "... and 5 more")
+	assert.Contains(t, summary, "Total of 15 accounts: 30umin")
+}
+
+func TestGenesisAccountsFileInput_Update(t *testing.T) {
+	state := NewLaunchState()
+	state.genesisAccounts = types.GenesisAccounts{{Address: genesisAddress1, Coins: "1umin"}}
+	ctx := weavecontext.NewAppContext(*state)
+	path := writeGenesisAccountsFile(t, "airdrop.csv", genesisAddress1+",2umin\n"+genesisAddress2+",3umin\n")
+
+	input := NewGenesisAccountsFileInput(ctx)
+	nextModel, _ := input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(path)})
+	finalModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	model, ok := finalModel.(*AddGenesisAccountsSelect)
+	assert.True(t, ok)
+	finalState := weavecontext.GetCurrentState[LaunchState](model.Ctx)
+	assert.Equal(t, types.GenesisAccounts{
+		{Address: genesisAddress1, Coins: "3umin"},
+		{Address: genesisAddress2, Coins: "3umin"},
+	}, finalState.genesisAccounts)
+	assert.Contains(t, finalState.weave.PreviousResponse[0], "(2 accounts)")
+}
+
+func TestGenesisAccountsFileInput_UpdateInvalid(t *testing.T) {
+	ctx := weavecontext.NewAppContext(*NewLaunchState())
+	path := writeGenesisAccountsFile(t, "airdrop.csv", "init1invalid,2umin\n")
+
+	input := NewGenesisAccountsFileInput(ctx)
+	nextModel, _ := input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(path)})
+	finalModel, _ := nextModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	model, ok := finalModel.(*GenesisAccountsFileInput)
+	assert.True(t, ok)
+	assert.Contains(t, model.View(), "line 1: invalid address format")
+}
@@ -0,0 +1,379 @@
+package minitia
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/types"
+)
+
+// RollupInfo describes a launched rollup, gathered from its artifacts, node config and live queries
+type RollupInfo struct {
+	ChainId      string                `json:"chain_id"`
+	VM           string                `json:"vm,omitempty"`
+	Version      string                `json:"version,omitempty"`
+	BridgeId     string                `json:"bridge_id,omitempty"`
+	L1ChainId    string                `json:"l1_chain_id"`
+	DATarget     string                `json:"da_target,omitempty"`
+	Endpoints    []RollupEndpoint      `json:"endpoints"`
+	SystemKeys   []RollupSystemKey     `json:"system_keys"`
+	LatestHeight int64                 `json:"latest_height,omitempty"`
+	LatestOutput *RollupOutputProposal `json:"latest_output,omitempty"`
+	Warnings     []string              `json:"warnings,omitempty"`
+
+	outputSubmissionInterval string
+}
+
+type RollupEndpoint struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Enabled bool   `json:"enabled"`
+}
+
+// RollupSystemKey is a system key address on one chain together with its balances on that chain
+type RollupSystemKey struct {
+	Role     string            `json:"role"`
+	Chain    string            `json:"chain"`
+	Address  string            `json:"address"`
+	Balances cosmosutils.Coins `json:"balances"`
+	Runway   string            `json:"runway,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+type RollupOutputProposal struct {
+	OutputIndex   string `json:"output_index"`
+	L2BlockNumber string `json:"l2_block_number"`
+	L1BlockNumber string `json:"l1_block_number"`
+	L1BlockTime   string `json:"l1_block_time"`
+	OutputRoot    string `json:"output_root"`
+}
+
+type appTomlEndpoints struct {
+	Api struct {
+		Enable  bool   `toml:"enable"`
+		Address string `toml:"address"`
+	} `toml:"api"`
+	Grpc struct {
+		Enable  bool   `toml:"enable"`
+		Address string `toml:"address"`
+	} `toml:"grpc"`
+	JsonRpc struct {
+		Enable  bool   `toml:"enable"`
+		Address string `toml:"address"`
+	} `toml:"json-rpc"`
+}
+
+type configTomlEndpoints struct {
+	Rpc struct {
+		Laddr string `toml:"laddr"`
+	} `toml:"rpc"`
+	P2P struct {
+		Laddr string `toml:"laddr"`
+	} `toml:"p2p"`
+}
+
+// GetRollupInfo collects the details of the rollup at minitiaHome. Failing live queries do not fail the whole
+// command, they are reported as warnings instead.
+func GetRollupInfo(minitiaHome string) (*RollupInfo, error) {
+	info, err := readRollupInfo(minitiaHome)
+	if err != nil {
+		return nil, err
+	}
+
+	if vm, version, err := DetectRollupService(); err == nil {
+		info.VM, info.Version = vm, version
+	}
+	if rpc := info.endpoint("RPC"); rpc != nil {
+		if height, err := queryLatestHeight(rpc.Address); err != nil {
+			info.warn("failed to query the latest height from %s: %v", rpc.Address, err)
+		} else {
+			info.LatestHeight = height
+		}
+		if info.Version == "" {
+			if abciInfo, err := cosmosutils.QueryABCIInfo(rpc.Address); err == nil {
+				info.Version = cosmosutils.NormalizeVersion(abciInfo.Result.Response.Version)
+			}
+		}
+	}
+
+	l1Lcd := info.l1Lcd()
+	info.queryBalances(l1Lcd)
+	info.estimateRunways()
+	if l1Lcd != "" && info.BridgeId != "" {
+		output, err := cosmosutils.QueryLastOutputProposal(l1Lcd, info.BridgeId)
+		if err != nil {
+			info.warn("failed to query the latest output from %s: %v", l1Lcd, err)
+		} else if output != nil {
+			info.LatestOutput = &RollupOutputProposal{
+				OutputIndex:   output.OutputIndex,
+				L2BlockNumber: output.OutputProposal.L2BlockNumber,
+				L1BlockNumber: output.OutputProposal.L1BlockNumber,
+				L1BlockTime:   output.OutputProposal.L1BlockTime,
+				OutputRoot:    output.OutputProposal.OutputRoot,
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// readRollupInfo reads everything that is known without querying a node: the launch artifacts and the endpoints
+// configured in app.toml and config.toml
+func readRollupInfo(minitiaHome string) (*RollupInfo, error) {
+	configBz, err := os.ReadFile(filepath.Join(minitiaHome, common.MinitiaArtifactsConfigJson))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the rollup artifacts, launch a rollup first: %v", err)
+	}
+	var config types.MinitiaConfig
+	if err = json.Unmarshal(configBz, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", common.MinitiaArtifactsConfigJson, err)
+	}
+	if config.L1Config == nil || config.L2Config == nil {
+		return nil, fmt.Errorf("%s is missing the l1_config or l2_config", common.MinitiaArtifactsConfigJson)
+	}
+
+	info := &RollupInfo{
+		ChainId:   config.L2Config.ChainID,
+		L1ChainId: config.L1Config.ChainID,
+	}
+	if config.OpBridge != nil {
+		info.DATarget = config.OpBridge.BatchSubmissionTarget
+		info.outputSubmissionInterval = config.OpBridge.OutputSubmissionInterval
+	}
+	if config.L2Config.BridgeID != 0 {
+		info.BridgeId = strconv.FormatUint(config.L2Config.BridgeID, 10)
+	}
+	var artifacts types.Artifacts
+	if artifactsBz, err := os.ReadFile(filepath.Join(minitiaHome, common.MinitiaArtifactsJson)); err == nil {
+		if err = json.Unmarshal(artifactsBz, &artifacts); err == nil && artifacts.BridgeID != "" {
+			info.BridgeId = artifacts.BridgeID
+		}
+	}
+
+	var appToml appTomlEndpoints
+	if _, err = toml.DecodeFile(filepath.Join(minitiaHome, "config", "app.toml"), &appToml); err != nil {
+		return nil, fmt.Errorf("failed to read app.toml: %v", err)
+	}
+	var configToml configTomlEndpoints
+	if _, err = toml.DecodeFile(filepath.Join(minitiaHome, "config", "config.toml"), &configToml); err != nil {
+		return nil, fmt.Errorf("failed to read config.toml: %v", err)
+	}
+	info.Endpoints = []RollupEndpoint{
+		{Name: "RPC", Address: common.ListenAddressToEndpoint(configToml.Rpc.Laddr, "http://", DefaultMinitiaRPC), Enabled: true},
+		{Name: "REST API", Address: common.ListenAddressToEndpoint(appToml.Api.Address, "http://", DefaultMinitiaLCD), Enabled: appToml.Api.Enable},
+		{Name: "gRPC", Address: common.ListenAddressToEndpoint(appToml.Grpc.Address, "", "localhost:9090"), Enabled: appToml.Grpc.Enable},
+		{Name: "P2P", Address: common.ListenAddressToEndpoint(configToml.P2P.Laddr, "", "localhost:26656"), Enabled: true},
+	}
+	if appToml.JsonRpc.Address != "" {
+		info.Endpoints = append(info.Endpoints, RollupEndpoint{
+			Name:    "JSON-RPC",
+			Address: common.ListenAddressToEndpoint(appToml.JsonRpc.Address, "http://", DefaultMinitiaJsonRPC),
+			Enabled: appToml.JsonRpc.Enable,
+		})
+	}
+
+	if config.SystemKeys != nil {
+		for _, key := range []struct {
+			role    string
+			account *types.SystemAccount
+		}{
+			{"Operator", config.SystemKeys.Validator},
+			{"Bridge Executor", config.SystemKeys.BridgeExecutor},
+			{"Output Submitter", config.SystemKeys.OutputSubmitter},
+			{"Batch Submitter", config.SystemKeys.BatchSubmitter},
+			{"Challenger", config.SystemKeys.Challenger},
+		} {
+			if key.account == nil {
+				continue
+			}
+			for _, address := range []struct{ chain, address string }{
+				{"L1", key.account.L1Address},
+				{"L2", key.account.L2Address},
+				{"DA", key.account.DAAddress},
+			} {
+				if address.address != "" {
+					info.SystemKeys = append(info.SystemKeys, RollupSystemKey{Role: key.role, Chain: address.chain, Address: address.address})
+				}
+			}
+		}
+	}
+
+	return info, nil
+}
+
+func (i *RollupInfo) warn(format string, args ...interface{}) {
+	i.Warnings = append(i.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (i *RollupInfo) endpoint(name string) *RollupEndpoint {
+	for idx := range i.Endpoints {
+		if i.Endpoints[idx].Name == name && i.Endpoints[idx].Enabled {
+			return &i.Endpoints[idx]
+		}
+	}
+	return nil
+}
+
+func (i *RollupInfo) l1Lcd() string {
+	l1Registry, err := registry.GetL1ChainRegistry(i.L1ChainId)
+	if err != nil {
+		i.warn("failed to find the L1 %s: %v", i.L1ChainId, err)
+		return ""
+	}
+	lcd, err := l1Registry.GetActiveLcd()
+	if err != nil {
+		i.warn("failed to reach the L1 %s: %v", i.L1ChainId, err)
+		return ""
+	}
+	return lcd
+}
+
+func (i *RollupInfo) daLcd(l1Lcd string) string {
+	if i.DATarget != BatchSubmissionTargetCelestia {
+		return l1Lcd
+	}
+	celestiaRegistry, err := registry.GetCelestiaChainRegistry(i.L1ChainId)
+	if err != nil {
+		i.warn("failed to load the Celestia registry: %v", err)
+		return ""
+	}
+	lcd, err := celestiaRegistry.GetActiveLcd()
+	if err != nil {
+		i.warn("failed to reach Celestia: %v", err)
+		return ""
+	}
+	return lcd
+}
+
+func (i *RollupInfo) queryBalances(l1Lcd string) {
+	lcds := map[string]string{"L1": l1Lcd}
+	if api := i.endpoint("REST API"); api != nil {
+		lcds["L2"] = api.Address
+	}
+	for _, key := range i.SystemKeys {
+		if key.Chain == "DA" {
+			lcds["DA"] = i.daLcd(l1Lcd)
+			break
+		}
+	}
+
+	for idx := range i.SystemKeys {
+		key := &i.SystemKeys[idx]
+		lcd := lcds[key.Chain]
+		if lcd == "" {
+			key.Error = fmt.Sprintf("no reachable %s REST API", key.Chain)
+			continue
+		}
+		balances, err := cosmosutils.QueryBankBalances(lcd, key.Address)
+		if err != nil {
+			key.Error = err.Error()
+			continue
+		}
+		key.Balances = *balances
+	}
+}
+
+// estimateRunways projects how long the L1 and DA balances of the OPinit bot keys last at their estimated spending
+func (i *RollupInfo) estimateRunways() {
+	if i.outputSubmissionInterval == "" {
+		return
+	}
+	assumptions, err := NewFundingAssumptions(i.L1ChainId, i.outputSubmissionInterval, i.DATarget == BatchSubmissionTargetCelestia)
+	if err != nil {
+		i.warn("failed to estimate the runway of the system keys: %v", err)
+		return
+	}
+	estimates, err := EstimateFunding(assumptions)
+	if err != nil {
+		i.warn("failed to estimate the runway of the system keys: %v", err)
+		return
+	}
+	for idx := range i.SystemKeys {
+		key := &i.SystemKeys[idx]
+		estimate, found := FindFundingEstimate(estimates, key.Role)
+		if !found || key.Chain == "L2" || key.Error != "" {
+			continue
+		}
+		balance := big.NewInt(0)
+		for _, coin := range key.Balances {
+			if coin.Denom == estimate.Denom {
+				balance.SetString(coin.Amount, 10)
+			}
+		}
+		key.Runway = FormatRunway(estimate.Runway(balance))
+	}
+}
+
+func (i *RollupInfo) String() string {
+	var b strings.Builder
+
+	b.WriteString(styles.BoldText("Rollup\n", styles.Cyan))
+	for _, field := range [][2]string{
+		{"Chain ID", i.ChainId},
+		{"VM", i.VM},
+		{"Version", i.Version},
+		{"Bridge ID", i.BridgeId},
+		{"L1 chain ID", i.L1ChainId},
+		{"DA target", i.DATarget},
+	} {
+		if field[1] == "" {
+			field[1] = "unknown"
+		}
+		b.WriteString(fmt.Sprintf("  %-12s %s\n", field[0]+":", styles.BoldText(field[1], styles.White)))
+	}
+	if i.LatestHeight > 0 {
+		b.WriteString(fmt.Sprintf("  %-12s %s\n", "Height:", styles.BoldText(strconv.FormatInt(i.LatestHeight, 10), styles.White)))
+	}
+
+	b.WriteString("\n" + styles.BoldText("Endpoints\n", styles.Cyan))
+	for _, endpoint := range i.Endpoints {
+		status := ""
+		if !endpoint.Enabled {
+			status = styles.Text(" (disabled)", styles.Gray)
+		}
+		b.WriteString(fmt.Sprintf("  %-9s %s%s\n", endpoint.Name+":", endpoint.Address, status))
+	}
+
+	b.WriteString("\n" + styles.BoldText("System keys\n", styles.Cyan))
+	for _, key := range i.SystemKeys {
+		balances := styles.Text(cosmosutils.NoBalancesText, styles.Gray)
+		if key.Error != "" {
+			balances = styles.Text(fmt.Sprintf("unknown: %s", key.Error), styles.Yellow)
+		} else if len(key.Balances) > 0 {
+			var coins []string
+			for _, coin := range key.Balances {
+				coins = append(coins, coin.Amount+coin.Denom)
+			}
+			balances = styles.BoldText(strings.Join(coins, ", "), styles.White)
+		}
+		if key.Runway != "" {
+			balances += styles.Text(fmt.Sprintf(" (runway %s)", key.Runway), styles.Gray)
+		}
+		b.WriteString(fmt.Sprintf("  [%s] %s %s: %s\n", key.Chain, key.Role, styles.Text(fmt.Sprintf("(%s)", key.Address), styles.Gray), balances))
+	}
+
+	b.WriteString("\n" + styles.BoldText("Latest output on L1\n", styles.Cyan))
+	if i.LatestOutput == nil {
+		b.WriteString("  none\n")
+	} else {
+		b.WriteString(fmt.Sprintf("  Output #%s for L2 block %s, submitted at L1 block %s (%s)\n",
+			i.LatestOutput.OutputIndex, i.LatestOutput.L2BlockNumber, i.LatestOutput.L1BlockNumber, i.LatestOutput.L1BlockTime))
+	}
+
+	for _, warning := range i.Warnings {
+		b.WriteString("\n" + styles.Text("i "+warning, styles.Yellow))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
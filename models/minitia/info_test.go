@@ -0,0 +1,79 @@
+package minitia
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/cosmosutils"
+)
+
+func writeRollupHome(t *testing.T) string {
+	home := t.TempDir()
+	files := map[string]string{
+		"artifacts/config.json": `{
+  "l1_config": {"chain_id": "initiation-2", "rpc_url": "https://rpc.testnet.initia.xyz"},
+  "l2_config": {"chain_id": "minimove-1", "denom": "umin", "moniker": "operator"},
+  "op_bridge": {"batch_submission_target": "INITIA", "enable_oracle": true},
+  "system_keys": {
+    "validator": {"l1_address": "init1validator", "l2_address": "init1validator", "mnemonic": "secret words"},
+    "batch_submitter": {"da_address": "init1batch", "mnemonic": "secret words"}
+  }
+}`,
+		"artifacts/artifacts.json": `{"BRIDGE_ID": "42"}`,
+		"config/app.toml":          "[api]\nenable = true\naddress = \"tcp://0.0.0.0:1318\"\n\n[grpc]\nenable = false\naddress = \"0.0.0.0:9090\"\n",
+		"config/config.toml":       "[rpc]\nladdr = \"tcp://127.0.0.1:26657\"\n\n[p2p]\nladdr = \"tcp://0.0.0.0:26656\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(home, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return home
+}
+
+func TestReadRollupInfo(t *testing.T) {
+	info, err := readRollupInfo(writeRollupHome(t))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "minimove-1", info.ChainId)
+	assert.Equal(t, "initiation-2", info.L1ChainId)
+	assert.Equal(t, "42", info.BridgeId)
+	assert.Equal(t, BatchSubmissionTargetInitia, info.DATarget)
+	assert.Equal(t, []RollupEndpoint{
+		{Name: "RPC", Address: "http://127.0.0.1:26657", Enabled: true},
+		{Name: "REST API", Address: "http://localhost:1318", Enabled: true},
+		{Name: "gRPC", Address: "localhost:9090", Enabled: false},
+		{Name: "P2P", Address: "localhost:26656", Enabled: true},
+	}, info.Endpoints)
+	assert.Equal(t, []RollupSystemKey{
+		{Role: "Operator", Chain: "L1", Address: "init1validator"},
+		{Role: "Operator", Chain: "L2", Address: "init1validator"},
+		{Role: "Batch Submitter", Chain: "DA", Address: "init1batch"},
+	}, info.SystemKeys)
+}
+
+func TestRollupInfoOutput(t *testing.T) {
+	info, err := readRollupInfo(writeRollupHome(t))
+	assert.NoError(t, err)
+	info.SystemKeys[0].Balances = cosmosutils.Coins{{Denom: "uinit", Amount: "100"}}
+	info.SystemKeys[1].Error = "connection refused"
+
+	text := info.String()
+	assert.Contains(t, text, "minimove-1")
+	assert.Contains(t, text, "100uinit")
+	assert.Contains(t, text, "unknown: connection refused")
+	assert.Contains(t, text, "(disabled)")
+
+	bz, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(bz), "secret words")
+}
+
+func TestReadRollupInfoWithoutArtifacts(t *testing.T) {
+	_, err := readRollupInfo(t.TempDir())
+	assert.ErrorContains(t, err, "launch a rollup first")
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -82,7 +84,8 @@ func (m *ExistingMinitiaChecker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Ctx = m.Loading.EndContext
 		state := weavecontext.PushPageAndGetState[LaunchState](m)
 
-		if !state.existingMinitiaApp {
+		// a dry run never deletes anything, the existing folder is reported in the launch plan instead
+		if !state.existingMinitiaApp || state.dryRun {
 			if state.launchFromExistingConfig {
 				model := NewDownloadMinitiaBinaryLoading(weavecontext.SetCurrentState(m.Ctx, state))
 				return model, model.Init()
@@ -188,6 +191,8 @@ func (n NetworkSelectOption) ToChainType() (registry.ChainType, error) {
 		return registry.InitiaL1Mainnet, nil
 	case Testnet:
 		return registry.InitiaL1Testnet, nil
+	case LocalL1:
+		return registry.InitiaL1Local, nil
 	default:
 		return 0, fmt.Errorf("invalid case for NetworkSelectOption: %v", n)
 	}
@@ -198,20 +203,22 @@ var (
 	Mainnet NetworkSelectOption = ""
 )
 
+const LocalL1 NetworkSelectOption = "Local L1 (running on this machine)"
+
 func NewNetworkSelect(ctx context.Context) (*NetworkSelect, error) {
-	testnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
-	if err != nil {
-		return nil, err
+	var options []NetworkSelectOption
+	// Without access to the registry, e.g. on an offline machine, only the local L1 can be used
+	if testnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet); err == nil {
+		//mainnetRegistry := registry.MustGetChainRegistry(registry.InitiaL1Mainnet)
+		Testnet = NetworkSelectOption(fmt.Sprintf("Testnet (%s)", testnetRegistry.GetChainId()))
+		//Mainnet = NetworkSelectOption(fmt.Sprintf("Mainnet (%s)", mainnetRegistry.GetChainId()))
+		options = append(options, Testnet)
 	}
-	//mainnetRegistry := registry.MustGetChainRegistry(registry.InitiaL1Mainnet)
-	Testnet = NetworkSelectOption(fmt.Sprintf("Testnet (%s)", testnetRegistry.GetChainId()))
-	//Mainnet = NetworkSelectOption(fmt.Sprintf("Mainnet (%s)", mainnetRegistry.GetChainId()))
+	options = append(options, LocalL1)
+
 	return &NetworkSelect{
 		Selector: ui.Selector[NetworkSelectOption]{
-			Options: []NetworkSelectOption{
-				Testnet,
-				//Mainnet,
-			},
+			Options:    options,
 			CannotBack: true,
 		},
 		BaseModel:  weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
@@ -241,6 +248,10 @@ func (m *NetworkSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		state := weavecontext.PushPageAndGetState[LaunchState](m)
 
 		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), m.highlights, string(*selected)))
+		if *selected == LocalL1 {
+			return NewLocalL1ChainIdInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		}
+
 		chainType, err := selected.ToChainType()
 		if err != nil {
 			return m, m.HandlePanic(err)
@@ -1308,18 +1319,46 @@ type AccountsFundingPresetOption string
 
 var DefaultPreset AccountsFundingPresetOption = ""
 
-const ManuallyFill AccountsFundingPresetOption = "○ Fill in an amount for each account manually"
+const (
+	RunwayPreset AccountsFundingPresetOption = "○ Estimate the amounts for a target runway"
+	ManuallyFill AccountsFundingPresetOption = "○ Fill in an amount for each account manually"
+)
 
 func (p *AccountsFundingPresetOption) toString() string {
 	switch *p {
 	case DefaultPreset:
 		return "default"
+	case RunwayPreset:
+		return "runway"
 	case ManuallyFill:
 		return "manually"
 	}
 	return ""
 }
 
+// fundingEstimates estimates the spending of the system keys from the launch answers, nil when it cannot
+func (ls *LaunchState) fundingEstimates() []FundingEstimate {
+	assumptions, err := NewFundingAssumptions(ls.l1ChainId, ls.opBridgeSubmissionInterval, ls.batchSubmissionIsCelestia)
+	if err != nil {
+		return nil
+	}
+	estimates, err := EstimateFunding(assumptions)
+	if err != nil {
+		return nil
+	}
+	return estimates
+}
+
+// runwayNote describes how long amount lasts for the role, or nothing when there is no estimate
+func runwayNote(estimates []FundingEstimate, role, amount string) string {
+	estimate, found := FindFundingEstimate(estimates, role)
+	balance, ok := new(big.Int).SetString(amount, 10)
+	if !found || !ok {
+		return ""
+	}
+	return styles.Text(fmt.Sprintf(" (lasts %s)", FormatRunway(estimate.Runway(balance))), styles.Gray)
+}
+
 func NewAccountsFundingPresetSelect(ctx context.Context) (*AccountsFundingPresetSelect, error) {
 	state := weavecontext.GetCurrentState[LaunchState](ctx)
 	tooltips := ui.NewTooltipSlice(
@@ -1336,24 +1375,11 @@ func NewAccountsFundingPresetSelect(ctx context.Context) (*AccountsFundingPreset
 		batchSubmitterDenom = DefaultCelestiaGasDenom
 		batchSubmitterText = " on Celestia"
 		initiaNeededBalance = DefaultL1InitiaNeededBalanceIfCelestiaDA
-		var celestiaChainId string
-		l1Registry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+		celestiaRegistry, err := registry.GetCelestiaChainRegistry(state.l1ChainId)
 		if err != nil {
 			return nil, err
 		}
-		if state.l1ChainId == l1Registry.GetChainId() {
-			celestiaRegistry, err := registry.GetChainRegistry(registry.CelestiaTestnet)
-			if err != nil {
-				return nil, err
-			}
-			celestiaChainId = celestiaRegistry.GetChainId()
-		} else {
-			celestiaRegistry, err := registry.GetChainRegistry(registry.CelestiaMainnet)
-			if err != nil {
-				return nil, err
-			}
-			celestiaChainId = celestiaRegistry.GetChainId()
-		}
+		celestiaChainId := celestiaRegistry.GetChainId()
 		celestiaGasStationAddress, err := crypto.MnemonicToBech32Address("celestia", gasStationMnemonic)
 		if err != nil {
 			return nil, fmt.Errorf("cannot recover gas station for celestia: %v", err)
@@ -1364,20 +1390,25 @@ func NewAccountsFundingPresetSelect(ctx context.Context) (*AccountsFundingPreset
 		batchSubmitterText = " on L1"
 		initiaNeededBalance = DefaultL1InitiaNeededBalanceIfInitiaDA
 	}
+	estimates := state.fundingEstimates()
 	separator := styles.Text("------------------------------------------------------------------------------------", styles.Gray)
 	DefaultPreset = AccountsFundingPresetOption(fmt.Sprintf(
-		"○ Use the default preset\n    %s\n    %s\n    %s %s on L1\n    %s %s on L1\n    %s %s%s\n    %s %s on L1\n    %s\n    %s\n    %s %s (%s)\n    %s%s\n",
+		"○ Use the default preset\n    %s\n    %s\n    %s %s on L1%s\n    %s %s on L1%s\n    %s %s%s%s\n    %s %s on L1%s\n    %s\n    %s\n    %s %s (%s)\n    %s%s\n",
 		separator,
 		styles.BoldText("• Executor", styles.Cyan),
 		styles.BoldText("  • Bridge Executor:", styles.Cyan),
 		styles.BoldText(fmt.Sprintf("%s%s", DefaultL1BridgeExecutorBalance, DefaultL1GasDenom), styles.White),
+		runwayNote(estimates, "Bridge Executor", DefaultL1BridgeExecutorBalance),
 		styles.BoldText("  • Output Submitter:", styles.Cyan),
 		styles.BoldText(fmt.Sprintf("%s%s", DefaultL1OutputSubmitterBalance, DefaultL1GasDenom), styles.White),
+		runwayNote(estimates, "Output Submitter", DefaultL1OutputSubmitterBalance),
 		styles.BoldText("  • Batch Submitter:", styles.Cyan),
 		styles.BoldText(fmt.Sprintf("%s%s", DefaultL1BatchSubmitterBalance, batchSubmitterDenom), styles.White),
 		batchSubmitterText,
+		runwayNote(estimates, "Batch Submitter", DefaultL1BatchSubmitterBalance),
 		styles.BoldText("• Challenger:", styles.Cyan),
 		styles.BoldText(fmt.Sprintf("%s%s", DefaultL1ChallengerBalance, DefaultL1GasDenom), styles.White),
+		runwayNote(estimates, "Challenger", DefaultL1ChallengerBalance),
 		separator,
 		styles.Text("Total amount required from the Gas Station account:", styles.Ivory),
 		styles.Text(fmt.Sprintf("• L1 (%s):", state.l1ChainId), styles.Cyan),
@@ -1386,12 +1417,14 @@ func NewAccountsFundingPresetSelect(ctx context.Context) (*AccountsFundingPreset
 		celestiaNeededBalance,
 		separator,
 	))
+	options := []AccountsFundingPresetOption{DefaultPreset}
+	if estimates != nil {
+		options = append(options, RunwayPreset)
+	}
+	options = append(options, ManuallyFill)
 	return &AccountsFundingPresetSelect{
 		Selector: ui.Selector[AccountsFundingPresetOption]{
-			Options: []AccountsFundingPresetOption{
-				DefaultPreset,
-				ManuallyFill,
-			},
+			Options:    options,
 			CannotBack: true,
 			Tooltips:   &tooltips,
 		},
@@ -1423,6 +1456,9 @@ func (m *AccountsFundingPresetSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			state.FillDefaultBalances()
 			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, "Use the default preset"))
 			return NewFeeWhitelistAccountsInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		case RunwayPreset:
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, "Estimate the amounts for a target runway"))
+			return NewFundingRunwayInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
 		case ManuallyFill:
 			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, "Fill in an amount for each account manually"))
 			return NewSystemKeyL1BridgeExecutorBalanceInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
@@ -1456,6 +1492,76 @@ func (m *AccountsFundingPresetSelect) View() string {
 		) + m.Selector.View())
 }
 
+type FundingRunwayInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+func NewFundingRunwayInput(ctx context.Context) *FundingRunwayInput {
+	model := &FundingRunwayInput{
+		TextInput:  ui.NewTextInput(false),
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		question:   "Specify how many days the system accounts should be funded for",
+		highlights: []string{"days"},
+	}
+	model.WithPlaceholder(fmt.Sprintf("Press tab to use “%d”", DefaultFundingRunwayDays))
+	model.WithDefaultValue(strconv.Itoa(DefaultFundingRunwayDays))
+	model.WithValidatorFn(validateRunwayDays)
+	return model
+}
+
+func validateRunwayDays(s string) error {
+	days, err := strconv.Atoi(s)
+	if err != nil || days <= 0 {
+		return fmt.Errorf("runway must be a positive number of days")
+	}
+	return nil
+}
+
+func (m *FundingRunwayInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *FundingRunwayInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *FundingRunwayInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		days, _ := strconv.Atoi(input.Text)
+		estimates := state.fundingEstimates()
+		if estimates == nil {
+			return m, m.HandlePanic(fmt.Errorf("failed to estimate the spending of the system accounts"))
+		}
+		state.FillEstimatedBalances(estimates, days)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, input.Text))
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, fmt.Sprintf(
+			"Estimated amounts: bridge executor %s, output submitter %s, batch submitter %s, challenger %s",
+			state.systemKeyL1BridgeExecutorBalance+DefaultL1GasDenom,
+			state.systemKeyL1OutputSubmitterBalance+DefaultL1GasDenom,
+			state.systemKeyL1BatchSubmitterBalance+state.batchSubmitterDenom(),
+			state.systemKeyL1ChallengerBalance+DefaultL1GasDenom,
+		), []string{}, ""))
+		return NewFeeWhitelistAccountsInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *FundingRunwayInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() +
+		styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
+
 type SystemKeyL1BridgeExecutorBalanceInput struct {
 	ui.TextInput
 	weavecontext.BaseModel
@@ -1573,7 +1679,7 @@ func NewSystemKeyL1BatchSubmitterBalanceInput(ctx context.Context) *SystemKeyL1B
 	var denom, network string
 	if state.batchSubmissionIsCelestia {
 		denom = DefaultCelestiaGasDenom
-		network = "Celestia Testnet"
+		network = state.celestiaNetworkName()
 	} else {
 		denom = DefaultL1GasDenom
 		network = "L1"
@@ -1583,7 +1689,7 @@ func NewSystemKeyL1BatchSubmitterBalanceInput(ctx context.Context) *SystemKeyL1B
 		TextInput:  ui.NewTextInput(false),
 		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
 		question:   fmt.Sprintf("Specify the amount to fund the batch submitter on %s (%s)", network, denom),
-		highlights: []string{"batch submitter", "L1", "Celestia Testnet"},
+		highlights: []string{"batch submitter", "L1", network},
 	}
 	model.WithPlaceholder("Enter a positive amount")
 	model.WithValidatorFn(common.IsValidInteger)
@@ -1829,8 +1935,9 @@ type AddGenesisAccountsSelect struct {
 type AddGenesisAccountsOption string
 
 const (
-	Yes AddGenesisAccountsOption = "Yes"
-	No  AddGenesisAccountsOption = "No"
+	Yes            AddGenesisAccountsOption = "Yes"
+	No             AddGenesisAccountsOption = "No"
+	ImportFromFile AddGenesisAccountsOption = "Import from a CSV or JSON file"
 )
 
 func NewAddGenesisAccountsSelect(recurring bool, ctx context.Context) *AddGenesisAccountsSelect {
@@ -1840,7 +1947,7 @@ func NewAddGenesisAccountsSelect(recurring bool, ctx context.Context) *AddGenesi
 	}
 
 	tooltips := ui.NewTooltipSlice(
-		tooltip.GenesisAccountSelectTooltip, 2,
+		tooltip.GenesisAccountSelectTooltip, 3,
 	)
 
 	return &AddGenesisAccountsSelect{
@@ -1848,6 +1955,7 @@ func NewAddGenesisAccountsSelect(recurring bool, ctx context.Context) *AddGenesi
 			Options: []AddGenesisAccountsOption{
 				Yes,
 				No,
+				ImportFromFile,
 			},
 			CannotBack: true,
 			Tooltips:   &tooltips,
@@ -1885,6 +1993,10 @@ func (m *AddGenesisAccountsSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			question, highlight := m.GetQuestionAndHighlight()
 			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, question, []string{highlight}, string(*selected)))
 			return NewGenesisAccountsAddressInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		case ImportFromFile:
+			question, highlight := m.GetQuestionAndHighlight()
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, question, []string{highlight}, string(*selected)))
+			return NewGenesisAccountsFileInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
 		case No:
 			question := m.firstTimeQuestion
 			highlight := "genesis accounts"
@@ -1892,9 +2004,7 @@ func (m *AddGenesisAccountsSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				state.weave.PreviousResponse = state.weave.PreviousResponse[:state.preGenesisAccountsResponsesCount]
 				state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, question, []string{highlight}, string(Yes)))
 				currentResponse := "  List of extra Genesis Accounts (excluding OPinit bots)\n"
-				for _, account := range state.genesisAccounts {
-					currentResponse += styles.Text(fmt.Sprintf("  %s\tInitial Balance: %s\n", account.Address, account.Coins), styles.Gray)
-				}
+				currentResponse += styles.Text(GenesisAccountsSummary(state.genesisAccounts), styles.Gray)
 				state.weave.PushPreviousResponse(currentResponse)
 			} else {
 				state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, question, []string{highlight}, string(No)))
@@ -1912,7 +2022,7 @@ func (m *AddGenesisAccountsSelect) View() string {
 	m.Selector.ViewTooltip(m.Ctx)
 	preText := ""
 	if !m.recurring {
-		preText += "\n" + styles.RenderPrompt("You can add extra genesis accounts by first entering the addresses, then assigning the initial balance one by one.", []string{"genesis accounts"}, styles.Information) + "\n"
+		preText += "\n" + styles.RenderPrompt("You can add extra genesis accounts by first entering the addresses, then assigning the initial balance one by one, or import them from a CSV or JSON file.", []string{"genesis accounts"}, styles.Information) + "\n"
 	}
 	question, highlight := m.GetQuestionAndHighlight()
 	return m.WrapView(state.weave.Render() + preText + styles.RenderPrompt(
@@ -2081,6 +2191,70 @@ func (m *GenesisAccountsBalanceInput) View() string {
 	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{m.address}, styles.Question) + m.TextInput.View())
 }
 
+type GenesisAccountsFileInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question   string
+	accounts   types.GenesisAccounts
+	loadedPath string
+	loadErr    error
+}
+
+func NewGenesisAccountsFileInput(ctx context.Context) *GenesisAccountsFileInput {
+	model := &GenesisAccountsFileInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  "Specify the path to the genesis accounts file",
+	}
+	model.WithPlaceholder("Enter a CSV file with address,coins rows or a JSON list of {\"address\", \"coins\"}")
+	model.WithValidatorFn(model.loadAccounts)
+	return model
+}
+
+// loadAccounts parses and validates the file on submit so the invalid rows are shown below the input. The result
+// is kept for the path, as the validator also runs on every render after submitting.
+func (m *GenesisAccountsFileInput) loadAccounts(path string) error {
+	path = strings.TrimSpace(path)
+	if path == m.loadedPath {
+		return m.loadErr
+	}
+	m.loadedPath = path
+	m.accounts, m.loadErr = LoadGenesisAccountsFile(path)
+	return m.loadErr
+}
+
+func (m *GenesisAccountsFileInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *GenesisAccountsFileInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *GenesisAccountsFileInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		if err := state.ImportGenesisAccounts(m.accounts); err != nil {
+			return m, m.HandlePanic(err)
+		}
+
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"genesis accounts file"}, fmt.Sprintf("%s (%d accounts)", input.Text, len(m.accounts))))
+		return NewAddGenesisAccountsSelect(true, weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *GenesisAccountsFileInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{"genesis accounts file"}, styles.Question) + m.TextInput.View())
+}
+
 type DownloadMinitiaBinaryLoading struct {
 	ui.Loading
 	weavecontext.BaseModel
@@ -2090,7 +2264,7 @@ func NewDownloadMinitiaBinaryLoading(ctx context.Context) *DownloadMinitiaBinary
 	state := weavecontext.GetCurrentState[LaunchState](ctx)
 	latest := map[bool]string{true: "latest ", false: ""}
 	return &DownloadMinitiaBinaryLoading{
-		Loading:   ui.NewLoading(fmt.Sprintf("Downloading %sMini%s binary <%s>", latest[state.launchFromExistingConfig], strings.ToLower(state.vmType), state.minitiadVersion), downloadMinitiaApp(ctx)),
+		Loading:   ui.NewLoading(fmt.Sprintf("%s %sMini%s binary <%s>", downloadAction(state), latest[state.launchFromExistingConfig], strings.ToLower(state.vmType), state.minitiadVersion), downloadMinitiaApp(ctx)),
 		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
 	}
 }
@@ -2103,50 +2277,25 @@ func downloadMinitiaApp(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[LaunchState](ctx)
 
-		userHome, err := os.UserHomeDir()
+		vm := strings.ToLower(state.vmType)
+		binaryPath, err := cosmosutils.GetMinitiaBinaryPath(vm, state.minitiadVersion)
 		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get user home directory: %v", err)}
-		}
-		weaveDataPath := filepath.Join(userHome, common.WeaveDataDirectory)
-		tarballPath := filepath.Join(weaveDataPath, "minitia.tar.gz")
-		extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("mini%s@%s", strings.ToLower(state.vmType), state.minitiadVersion))
-
-		var binaryPath string
-		switch runtime.GOOS {
-		case "linux":
-			binaryPath = filepath.Join(extractedPath, fmt.Sprintf("mini%s_%s", strings.ToLower(state.vmType), state.minitiadVersion), AppName)
-		case "darwin":
-			binaryPath = filepath.Join(extractedPath, AppName)
-		default:
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("unsupported OS: %v", runtime.GOOS)}
+			return ui.NonRetryableErrorLoading{Err: err}
 		}
 		state.binaryPath = binaryPath
-
-		if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
-			if _, err := os.Stat(extractedPath); os.IsNotExist(err) {
-				err := os.MkdirAll(extractedPath, os.ModePerm)
-				if err != nil {
-					return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to create weave data directory: %v", err)}
-				}
-			}
-
-			if err = io.DownloadAndExtractTarGz(state.minitiadEndpoint, tarballPath, extractedPath); err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to download and extract binary: %v", err)}
-			}
-
-			err = os.Chmod(binaryPath, 0755)
-			if err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to set permissions for binary: %v", err)}
+		// a dry run only reports the binary in the launch plan
+		if state.dryRun {
+			return ui.EndLoading{
+				Ctx: weavecontext.SetCurrentState(ctx, state),
 			}
-
-			state.downloadedNewBinary = true
 		}
 
-		if state.vmType == string(Move) || state.vmType == string(Wasm) {
-			err = io.SetLibraryPaths(filepath.Dir(binaryPath))
-			if err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to set library path: %v", err)}
-			}
+		downloaded, err := cosmosutils.InstallMinitiaBinary(vm, state.minitiadVersion, state.minitiadEndpoint, binaryPath)
+		if err != nil {
+			return ui.NonRetryableErrorLoading{Err: err}
+		}
+		if downloaded {
+			state.downloadedNewBinary = true
 		}
 
 		return ui.EndLoading{
@@ -2174,6 +2323,15 @@ func (m *DownloadMinitiaBinaryLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd)
 		}
 
 		if state.launchFromExistingConfig {
+			if state.checkpoint == nil {
+				minitiaHome, err := weavecontext.GetMinitiaHome(m.Ctx)
+				if err != nil {
+					return m, m.HandlePanic(err)
+				}
+				if err = state.startCheckpoint(minitiaHome); err != nil {
+					return m, m.HandlePanic(err)
+				}
+			}
 			model := NewLaunchingNewMinitiaLoading(weavecontext.SetCurrentState(m.Ctx, state))
 			return model, model.Init()
 		}
@@ -2186,6 +2344,11 @@ func (m *DownloadMinitiaBinaryLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd)
 			return model, model.Init()
 		}
 
+		// a resumed launch already has its system keys
+		if state.checkpoint != nil {
+			model := NewVerifyLaunchFundingLoading(weavecontext.SetCurrentState(m.Ctx, state))
+			return model, model.Init()
+		}
 		model := NewGenerateOrRecoverSystemKeysLoading(weavecontext.SetCurrentState(m.Ctx, state))
 		return model, model.Init()
 	}
@@ -2203,15 +2366,29 @@ type DownloadCelestiaBinaryLoading struct {
 }
 
 func NewDownloadCelestiaBinaryLoading(ctx context.Context) (*DownloadCelestiaBinaryLoading, error) {
-	celestiaMainnetRegistry, err := registry.GetChainRegistry(registry.CelestiaMainnet)
+	state := weavecontext.GetCurrentState[LaunchState](ctx)
+	version, binaryUrl, err := getCelestiaBinaryRelease(state.l1ChainId)
 	if err != nil {
 		return nil, err
 	}
+	return &DownloadCelestiaBinaryLoading{
+		Loading:   ui.NewLoading(fmt.Sprintf("%s Celestia binary <%s>", downloadAction(state), version), downloadCelestiaApp(ctx, version, binaryUrl)),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}, nil
+}
+
+// getCelestiaBinaryRelease returns the celestia-app version run by the Celestia network paired with the L1 and the
+// URL of its release for this machine
+func getCelestiaBinaryRelease(l1ChainId string) (version, binaryUrl string, err error) {
+	celestiaRegistry, err := registry.GetCelestiaChainRegistry(l1ChainId)
+	if err != nil {
+		return "", "", err
+	}
 	httpClient := client.NewHTTPClient()
 
-	activeLcd, err := celestiaMainnetRegistry.GetActiveLcd()
+	activeLcd, err := celestiaRegistry.GetActiveLcd()
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 	var result map[string]interface{}
 	_, err = httpClient.Get(
@@ -2221,24 +2398,60 @@ func NewDownloadCelestiaBinaryLoading(ctx context.Context) (*DownloadCelestiaBin
 		&result,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch node info: %v", err)
+		return "", "", fmt.Errorf("failed to fetch node info: %v", err)
 	}
 
 	applicationVersion, ok := result["application_version"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("failed to get node version")
+		return "", "", fmt.Errorf("failed to get node version")
+	}
+	version, ok = applicationVersion["version"].(string)
+	if !ok {
+		return "", "", fmt.Errorf("failed to get node version")
 	}
-	version := applicationVersion["version"].(string)
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
-	binaryUrl, err := getCelestiaBinaryURL(version, goos, goarch)
+	binaryUrl, err = getCelestiaBinaryURL(version, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get celestia binary url: %v", err)
+		return "", "", fmt.Errorf("failed to get celestia binary url: %v", err)
 	}
-	return &DownloadCelestiaBinaryLoading{
-		Loading:   ui.NewLoading(fmt.Sprintf("Downloading Celestia binary <%s>", version), downloadCelestiaApp(ctx, version, binaryUrl)),
-		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
-	}, nil
+	return version, binaryUrl, nil
+}
+
+// InstallCelestiaBinary downloads the celestia-appd of the Celestia network paired with the L1 unless it is already
+// installed, and returns its path
+func InstallCelestiaBinary(l1ChainId string) (string, error) {
+	version, binaryUrl, err := getCelestiaBinaryRelease(l1ChainId)
+	if err != nil {
+		return "", err
+	}
+	binaryPath, _, err := installCelestiaBinary(version, binaryUrl)
+	return binaryPath, err
+}
+
+// installCelestiaBinary extracts the release at binaryUrl under the weave data directory, downloaded tells whether it
+// was not installed yet
+func installCelestiaBinary(version, binaryUrl string) (binaryPath string, downloaded bool, err error) {
+	binaryPath, err = getCelestiaBinaryPath(version)
+	if err != nil {
+		return "", false, err
+	}
+	extractedPath := filepath.Dir(binaryPath)
+	tarballPath := filepath.Join(filepath.Dir(extractedPath), "celestia.tar.gz")
+
+	if _, err := os.Stat(binaryPath); !os.IsNotExist(err) {
+		return binaryPath, false, nil
+	}
+	if _, err := os.Stat(extractedPath); os.IsNotExist(err) {
+		if err = os.MkdirAll(extractedPath, os.ModePerm); err != nil {
+			return "", false, fmt.Errorf("failed to create weave data directory: %v", err)
+		}
+	}
+	if err = io.DownloadAndExtractTarGz(binaryUrl, tarballPath, extractedPath); err != nil {
+		return "", false, fmt.Errorf("failed to download and extract binary: %v", err)
+	}
+	if err = os.Chmod(binaryPath, 0755); err != nil {
+		return "", false, fmt.Errorf("failed to set permissions for binary: %v", err)
+	}
+	return binaryPath, true, nil
 }
 
 func getCelestiaBinaryURL(version, os, arch string) (string, error) {
@@ -2265,37 +2478,151 @@ func (m *DownloadCelestiaBinaryLoading) Init() tea.Cmd {
 	return m.Loading.Init()
 }
 
+// getCelestiaBinaryPath returns where the celestia-appd of version is installed
+func getCelestiaBinaryPath(version string) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	return filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("celestia@%s", version), CelestiaAppName), nil
+}
+
+// downloadAction describes what the download steps of the launch do, a dry run only looks the binaries up
+func downloadAction(state LaunchState) string {
+	if state.dryRun {
+		return "Looking up"
+	}
+	return "Downloading"
+}
+
 func downloadCelestiaApp(ctx context.Context, version, binaryUrl string) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[LaunchState](ctx)
-		userHome, err := os.UserHomeDir()
+		state.celestiaVersion = version
+		state.celestiaEndpoint = binaryUrl
+		// a dry run only reports the binary in the launch plan
+		if state.dryRun {
+			binaryPath, err := getCelestiaBinaryPath(version)
+			if err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+			state.celestiaBinaryPath = binaryPath
+			return ui.EndLoading{
+				Ctx: weavecontext.SetCurrentState(ctx, state),
+			}
+		}
+		binaryPath, downloaded, err := installCelestiaBinary(version, binaryUrl)
 		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get user home directory: %v", err)}
+			return ui.NonRetryableErrorLoading{Err: err}
 		}
-		weaveDataPath := filepath.Join(userHome, common.WeaveDataDirectory)
-		tarballPath := filepath.Join(weaveDataPath, "celestia.tar.gz")
-		extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("celestia@%s", version))
-		binaryPath := filepath.Join(extractedPath, CelestiaAppName)
 		state.celestiaBinaryPath = binaryPath
+		state.downloadedNewCelestiaBinary = downloaded
 
-		if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
-			if _, err := os.Stat(extractedPath); os.IsNotExist(err) {
-				err := os.MkdirAll(extractedPath, os.ModePerm)
-				if err != nil {
-					return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to create weave data directory: %v", err)}
-				}
-			}
+		return ui.EndLoading{
+			Ctx: weavecontext.SetCurrentState(ctx, state),
+		}
+	}
+}
+
+func (m *DownloadCelestiaBinaryLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	loader, cmd := m.Loading.Update(msg)
+	m.Loading = loader
+	if m.Loading.NonRetryableErr != nil {
+		return m, m.HandlePanic(m.Loading.NonRetryableErr)
+	}
+	if m.Loading.Completing {
+		m.Ctx = m.Loading.EndContext
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+
+		if state.downloadedNewCelestiaBinary {
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "Celestia binary has been successfully downloaded.", []string{}, ""))
+		}
+		if state.checkpoint != nil {
+			model := NewVerifyLaunchFundingLoading(weavecontext.SetCurrentState(m.Ctx, state))
+			return model, model.Init()
+		}
+		model := NewGenerateOrRecoverSystemKeysLoading(weavecontext.SetCurrentState(m.Ctx, state))
+		return model, model.Init()
+	}
+	return m, cmd
+}
+
+func (m *DownloadCelestiaBinaryLoading) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + "\n" + m.Loading.View())
+}
+
+// VerifyLaunchFundingLoading checks that the system keys hold the amounts of the funding transactions recorded by the
+// checkpoint of a resumed launch, so they are only funded again when a transaction never made it on chain
+type VerifyLaunchFundingLoading struct {
+	ui.Loading
+	weavecontext.BaseModel
+}
+
+func NewVerifyLaunchFundingLoading(ctx context.Context) *VerifyLaunchFundingLoading {
+	return &VerifyLaunchFundingLoading{
+		Loading:   ui.NewLoading("Verifying the funding of the system keys...", verifyLaunchFunding(ctx)),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}
+}
+
+func (m *VerifyLaunchFundingLoading) Init() tea.Cmd {
+	return m.Loading.Init()
+}
+
+func verifyLaunchFunding(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		state := weavecontext.GetCurrentState[LaunchState](ctx)
+		// the rollup is already launched, the funding txs may have been pruned by the nodes since
+		if state.checkpoint.IsDone(LaunchStepRollupLaunched) {
+			return ui.EndLoading{Ctx: ctx}
+		}
 
-			if err = io.DownloadAndExtractTarGz(binaryUrl, tarballPath, extractedPath); err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to download and extract binary: %v", err)}
+		// a tx that is not found may only have been pruned, or may still be included after a failed wait, so the
+		// funding is verified through the balances of the system keys
+		systemKeys := state.l1SystemKeys()
+		if state.systemKeyCelestiaFundingTxHash != "" {
+			celestiaRegistry, err := registry.GetCelestiaChainRegistry(state.l1ChainId)
+			if err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+			celestiaRpc, err := celestiaRegistry.GetActiveRpc()
+			if err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
 			}
+			funded, err := systemKeys.FundedOnCelestia(state.celestiaBinaryPath, celestiaRpc)
+			if err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+			if funded {
+				state.checkpoint.complete(LaunchStepCelestiaFunded)
+			} else {
+				state.systemKeyCelestiaFundingTxHash = ""
+				state.checkpoint.undo(LaunchStepCelestiaFunded)
+			}
+		}
 
-			err = os.Chmod(binaryPath, 0755)
+		if state.systemKeyL1FundingTxHash != "" {
+			funded, err := systemKeys.FundedOnL1(state.binaryPath, state.l1RPC, !state.batchSubmissionIsCelestia)
 			if err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to set permissions for binary: %v", err)}
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+			if funded {
+				state.checkpoint.complete(LaunchStepL1Funded)
+			} else {
+				state.systemKeyL1FundingTxHash = ""
+				state.checkpoint.undo(LaunchStepL1Funded)
 			}
+		}
 
-			state.downloadedNewCelestiaBinary = true
+		state.checkpoint.L1FundingTxHash = state.systemKeyL1FundingTxHash
+		state.checkpoint.CelestiaFundingTxHash = state.systemKeyCelestiaFundingTxHash
+		if err := state.checkpoint.Save(); err != nil {
+			return ui.NonRetryableErrorLoading{Err: err}
 		}
 
 		return ui.EndLoading{
@@ -2304,7 +2631,7 @@ func downloadCelestiaApp(ctx context.Context, version, binaryUrl string) tea.Cmd
 	}
 }
 
-func (m *DownloadCelestiaBinaryLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m *VerifyLaunchFundingLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
 		return model, cmd
 	}
@@ -2318,20 +2645,39 @@ func (m *DownloadCelestiaBinaryLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd)
 		m.Ctx = m.Loading.EndContext
 		state := weavecontext.PushPageAndGetState[LaunchState](m)
 
-		if state.downloadedNewCelestiaBinary {
-			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "Celestia binary has been successfully downloaded.", []string{}, ""))
+		if !state.fundingDone() {
+			model, err := NewFundGasStationConfirmationInput(weavecontext.SetCurrentState(m.Ctx, state))
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
+			return model, nil
 		}
-		model := NewGenerateOrRecoverSystemKeysLoading(weavecontext.SetCurrentState(m.Ctx, state))
+
+		if state.systemKeyCelestiaFundingTxHash != "" {
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, "Batch Submitter on Celestia already funded, with Tx Hash", []string{}, state.systemKeyCelestiaFundingTxHash))
+		}
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, "System keys on Initia L1 already funded, with Tx Hash", []string{}, state.systemKeyL1FundingTxHash))
+		model := NewLaunchingNewMinitiaLoading(weavecontext.SetCurrentState(m.Ctx, state))
 		return model, model.Init()
 	}
 	return m, cmd
 }
 
-func (m *DownloadCelestiaBinaryLoading) View() string {
+func (m *VerifyLaunchFundingLoading) View() string {
 	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
 	return m.WrapView(state.weave.Render() + "\n" + m.Loading.View())
 }
 
+// NewResumeLaunch continues the launch recorded by the checkpoint restored into the state of ctx
+func NewResumeLaunch(ctx context.Context) (tea.Model, error) {
+	state := weavecontext.GetCurrentState[LaunchState](ctx)
+	if state.checkpoint == nil {
+		return nil, fmt.Errorf("there is no launch to resume")
+	}
+	state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, fmt.Sprintf("Resuming the launch of %s after step", state.chainId), []string{state.chainId}, string(state.checkpoint.LastStep())))
+	return NewDownloadMinitiaBinaryLoading(weavecontext.SetCurrentState(ctx, state)), nil
+}
+
 type GenerateOrRecoverSystemKeysLoading struct {
 	ui.Loading
 	weavecontext.BaseModel
@@ -2358,7 +2704,11 @@ func (m *GenerateOrRecoverSystemKeysLoading) Init() tea.Cmd {
 func generateOrRecoverSystemKeys(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[LaunchState](ctx)
-		if state.generateKeys {
+		if state.dryRun {
+			if err := deriveSystemKeys(&state); err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+		} else if state.generateKeys {
 			operatorKey, err := cosmosutils.GenerateNewKeyInfo(state.binaryPath, OperatorKeyName)
 			if err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to generate operator key: %v", err)}
@@ -2442,6 +2792,40 @@ func generateOrRecoverSystemKeys(ctx context.Context) tea.Cmd {
 	}
 }
 
+// deriveSystemKeys generates or recovers the system keys without the binaries, so that a dry run neither installs
+// them nor opens the keyring
+func deriveSystemKeys(state *LaunchState) error {
+	batchSubmitterHrp := "init"
+	if state.batchSubmissionIsCelestia {
+		batchSubmitterHrp = "celestia"
+	}
+	keys := []struct {
+		hrp               string
+		mnemonic, address *string
+	}{
+		{"init", &state.systemKeyOperatorMnemonic, &state.systemKeyOperatorAddress},
+		{"init", &state.systemKeyBridgeExecutorMnemonic, &state.systemKeyBridgeExecutorAddress},
+		{"init", &state.systemKeyOutputSubmitterMnemonic, &state.systemKeyOutputSubmitterAddress},
+		{batchSubmitterHrp, &state.systemKeyBatchSubmitterMnemonic, &state.systemKeyBatchSubmitterAddress},
+		{"init", &state.systemKeyChallengerMnemonic, &state.systemKeyChallengerAddress},
+	}
+	for _, key := range keys {
+		if state.generateKeys {
+			mnemonic, err := crypto.GenerateMnemonic()
+			if err != nil {
+				return err
+			}
+			*key.mnemonic = mnemonic
+		}
+		address, err := crypto.MnemonicToBech32Address(key.hrp, *key.mnemonic)
+		if err != nil {
+			return fmt.Errorf("failed to derive system key address: %v", err)
+		}
+		*key.address = address
+	}
+	return nil
+}
+
 func (m *GenerateOrRecoverSystemKeysLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
 		return model, cmd
@@ -2456,6 +2840,19 @@ func (m *GenerateOrRecoverSystemKeysLoading) Update(msg tea.Msg) (tea.Model, tea
 		m.Ctx = m.Loading.EndContext
 		state := weavecontext.PushPageAndGetState[LaunchState](m)
 
+		if state.dryRun {
+			model := NewLaunchPlanLoading(weavecontext.SetCurrentState(m.Ctx, state))
+			return model, model.Init()
+		}
+
+		minitiaHome, err := weavecontext.GetMinitiaHome(m.Ctx)
+		if err != nil {
+			return m, m.HandlePanic(err)
+		}
+		if err = state.startCheckpoint(minitiaHome); err != nil {
+			return m, m.HandlePanic(err)
+		}
+
 		if state.generateKeys {
 			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "System keys have been successfully generated.", []string{}, ""))
 			model := NewSystemKeysMnemonicDisplayInput(weavecontext.SetCurrentState(m.Ctx, state))
@@ -2585,7 +2982,7 @@ func (m *SystemKeysMnemonicDisplayInput) View() string {
 	configFilePath := filepath.Join(userHome, common.WeaveDataDirectory, LaunchConfigFilename)
 	viewText := m.WrapView(state.weave.Render() + "\n" +
 		styles.BoldUnderlineText("Important", styles.Yellow) + "\n" +
-		styles.Text(fmt.Sprintf("Write down these mnemonic phrases and store them in a safe place. \nIt is the only way to recover your system keys.\n\nNote that before launching, these mnemonic phrases along with other configuration details will be stored\nin %s. You can revisit them anytime.", configFilePath), styles.Yellow) + "\n\n" +
+		styles.Text(fmt.Sprintf("Write down these mnemonic phrases and store them in a safe place. \nIt is the only way to recover your system keys.\n\nNote that these mnemonic phrases are only written to %s while `minitiad launch` runs\nand the file is deleted afterwards. You can save them into an encrypted key file at the end of the launch.", configFilePath), styles.Yellow) + "\n\n" +
 		mnemonicText + styles.RenderPrompt(m.GetQuestion(), []string{"`continue`"}, styles.Question) + m.TextInput.View())
 	//err = m.Clickable.ClickableUpdatePositions(viewText)
 	if err != nil {
@@ -2670,21 +3067,27 @@ func (m *FundGasStationConfirmationInput) View() string {
 		false: formatSendMsg(state.systemKeyL1BatchSubmitterBalance, "uinit", "Batch Submitter on Initia L1", state.systemKeyBatchSubmitterAddress),
 	}
 	celestiaText := map[bool]string{
-		true:  fmt.Sprintf("\nSending tokens from the Gas Station account on Celestia Testnet %s ⛽️\n%s", styles.Text(fmt.Sprintf("(%s)", m.celestiaGasStationAddress), styles.Gray), formatSendMsg(state.systemKeyL1BatchSubmitterBalance, DefaultCelestiaGasDenom, "Batch Submitter on Celestia Testnet", state.systemKeyBatchSubmitterAddress)),
+		true:  fmt.Sprintf("\nSending tokens from the Gas Station account on %s %s ⛽️\n%s", state.celestiaNetworkName(), styles.Text(fmt.Sprintf("(%s)", m.celestiaGasStationAddress), styles.Gray), formatSendMsg(state.systemKeyL1BatchSubmitterBalance, DefaultCelestiaGasDenom, "Batch Submitter on "+state.celestiaNetworkName(), state.systemKeyBatchSubmitterAddress)),
 		false: "",
 	}
+	// a resumed launch only broadcasts the funding that is not confirmed on chain yet
+	pendingCelestia := state.batchSubmissionIsCelestia && state.systemKeyCelestiaFundingTxHash == ""
+	var l1Text string
+	if state.systemKeyL1FundingTxHash == "" {
+		l1Text = fmt.Sprintf("Sending tokens from the Gas Station account on Initia L1 %s ⛽️\n", styles.Text(fmt.Sprintf("(%s)", m.initiaGasStationAddress), styles.Gray)) +
+			formatSendMsg(state.systemKeyL1BridgeExecutorBalance, "uinit", "Bridge Executor on Initia L1", state.systemKeyBridgeExecutorAddress) +
+			formatSendMsg(state.systemKeyL1OutputSubmitterBalance, "uinit", "Output Submitter on Initia L1", state.systemKeyOutputSubmitterAddress) +
+			batchSubmitterText[state.batchSubmissionIsCelestia] +
+			formatSendMsg(state.systemKeyL1ChallengerBalance, "uinit", "Challenger on Initia L1", state.systemKeyChallengerAddress)
+	}
 	return m.WrapView(state.weave.Render() + "\n" +
 		styles.Text("i ", styles.Yellow) +
 		styles.RenderPrompt(
-			styles.BoldUnderlineText(headerText[state.batchSubmissionIsCelestia], styles.Yellow),
+			styles.BoldUnderlineText(headerText[pendingCelestia && l1Text != ""], styles.Yellow),
 			[]string{}, styles.Empty,
 		) + "\n\n" +
-		fmt.Sprintf("Sending tokens from the Gas Station account on Initia L1 %s ⛽️\n", styles.Text(fmt.Sprintf("(%s)", m.initiaGasStationAddress), styles.Gray)) +
-		formatSendMsg(state.systemKeyL1BridgeExecutorBalance, "uinit", "Bridge Executor on Initia L1", state.systemKeyBridgeExecutorAddress) +
-		formatSendMsg(state.systemKeyL1OutputSubmitterBalance, "uinit", "Output Submitter on Initia L1", state.systemKeyOutputSubmitterAddress) +
-		batchSubmitterText[state.batchSubmissionIsCelestia] +
-		formatSendMsg(state.systemKeyL1ChallengerBalance, "uinit", "Challenger on Initia L1", state.systemKeyChallengerAddress) +
-		celestiaText[state.batchSubmissionIsCelestia] +
+		l1Text +
+		celestiaText[pendingCelestia] +
 		styles.RenderPrompt(m.GetQuestion(), []string{"`continue`"}, styles.Question) + m.TextInput.View())
 }
 
@@ -2704,28 +3107,32 @@ func (m *FundGasStationBroadcastLoading) Init() tea.Cmd {
 	return m.Loading.Init()
 }
 
+// l1SystemKeys returns the system keys with the amounts they are funded with by the gas station
+func (ls *LaunchState) l1SystemKeys() *L1SystemKeys {
+	return NewL1SystemKeys(
+		&types.GenesisAccount{
+			Address: ls.systemKeyBridgeExecutorAddress,
+			Coins:   ls.systemKeyL1BridgeExecutorBalance,
+		},
+		&types.GenesisAccount{
+			Address: ls.systemKeyOutputSubmitterAddress,
+			Coins:   ls.systemKeyL1OutputSubmitterBalance,
+		},
+		&types.GenesisAccount{
+			Address: ls.systemKeyBatchSubmitterAddress,
+			Coins:   ls.systemKeyL1BatchSubmitterBalance,
+		},
+		&types.GenesisAccount{
+			Address: ls.systemKeyChallengerAddress,
+			Coins:   ls.systemKeyL1ChallengerBalance,
+		},
+	)
+}
+
 func broadcastFundingFromGasStation(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[LaunchState](ctx)
-		systemKeys := NewL1SystemKeys(
-			&types.GenesisAccount{
-				Address: state.systemKeyBridgeExecutorAddress,
-				Coins:   state.systemKeyL1BridgeExecutorBalance,
-			},
-			&types.GenesisAccount{
-				Address: state.systemKeyOutputSubmitterAddress,
-				Coins:   state.systemKeyL1OutputSubmitterBalance,
-			},
-			&types.GenesisAccount{
-				Address: state.systemKeyBatchSubmitterAddress,
-				Coins:   state.systemKeyL1BatchSubmitterBalance,
-			},
-			&types.GenesisAccount{
-				Address: state.systemKeyChallengerAddress,
-				Coins:   state.systemKeyL1ChallengerBalance,
-			},
-		)
-		txResult, err := systemKeys.FundAccountsWithGasStation(&state)
+		txResult, err := state.l1SystemKeys().FundAccountsWithGasStation(&state)
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: err}
 		}
@@ -2733,7 +3140,12 @@ func broadcastFundingFromGasStation(ctx context.Context) tea.Cmd {
 		if txResult.CelestiaTx != nil {
 			state.systemKeyCelestiaFundingTxHash = txResult.CelestiaTx.TxHash
 		}
-		state.systemKeyL1FundingTxHash = txResult.InitiaTx.TxHash
+		if txResult.InitiaTx != nil {
+			state.systemKeyL1FundingTxHash = txResult.InitiaTx.TxHash
+			if err = state.completeCheckpointStep(LaunchStepL1Funded, ""); err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
+			}
+		}
 		time.Sleep(1500 * time.Millisecond)
 
 		return ui.EndLoading{
@@ -2822,6 +3234,25 @@ func isJSONLog(line string) bool {
 	return timestampRegex.MatchString(line) || initPrefixRegex.MatchString(line)
 }
 
+// WriteLaunchConfig writes the config passed to `minitiad launch --with-config` into the weave data directory.
+// The file holds plaintext mnemonics, so it is only readable by the current user and is deleted after the launch.
+func WriteLaunchConfig(minitiaConfig *types.MinitiaConfig) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	configBz, err := json.MarshalIndent(minitiaConfig, "", " ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %v", err)
+	}
+
+	configFilePath := filepath.Join(userHome, common.WeaveDataDirectory, LaunchConfigFilename)
+	if err = io.WriteSecretFile(configFilePath, configBz); err != nil {
+		return "", fmt.Errorf("failed to write config file: %v", err)
+	}
+	return configFilePath, nil
+}
+
 func launchingMinitia(ctx context.Context, streamingLogs *[]string) tea.Cmd {
 	return func() tea.Msg {
 		state := weavecontext.GetCurrentState[LaunchState](ctx)
@@ -2829,116 +3260,27 @@ func launchingMinitia(ctx context.Context, streamingLogs *[]string) tea.Cmd {
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get user home directory: %v", err)}
 		}
-		var configFilePath string
-		if state.launchFromExistingConfig {
-			configFilePath = state.existingConfigPath
-		} else {
-
-			minitiaConfig := &types.MinitiaConfig{
-				L1Config: &types.L1Config{
-					ChainID:   state.l1ChainId,
-					RpcUrl:    state.l1RPC,
-					GasPrices: DefaultL1GasPrices,
-				},
-				L2Config: &types.L2Config{
-					ChainID: state.chainId,
-					Denom:   state.gasDenom,
-					Moniker: state.moniker,
-				},
-				OpBridge: &types.OpBridge{
-					OutputSubmissionInterval:    state.opBridgeSubmissionInterval,
-					OutputFinalizationPeriod:    state.opBridgeOutputFinalizationPeriod,
-					OutputSubmissionStartHeight: 1,
-					BatchSubmissionTarget:       state.opBridgeBatchSubmissionTarget,
-					EnableOracle:                state.enableOracle,
-				},
-				SystemKeys: &types.SystemKeys{
-					Validator: types.NewSystemAccount(
-						state.systemKeyOperatorMnemonic,
-						state.systemKeyOperatorAddress,
-					),
-					BridgeExecutor: types.NewSystemAccount(
-						state.systemKeyBridgeExecutorMnemonic,
-						state.systemKeyBridgeExecutorAddress,
-					),
-					OutputSubmitter: types.NewSystemAccount(
-						state.systemKeyOutputSubmitterMnemonic,
-						state.systemKeyOutputSubmitterAddress,
-					),
-					BatchSubmitter: types.NewBatchSubmitterAccount(
-						state.systemKeyBatchSubmitterMnemonic,
-						state.systemKeyBatchSubmitterAddress,
-					),
-					Challenger: types.NewSystemAccount(
-						state.systemKeyChallengerMnemonic,
-						state.systemKeyChallengerAddress,
-					),
-				},
-				GenesisAccounts: &state.genesisAccounts,
-			}
-
-			configBz, err := json.MarshalIndent(minitiaConfig, "", " ")
-			if err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to marshal config: %v", err)}
-			}
-
-			configFilePath = filepath.Join(userHome, common.WeaveDataDirectory, LaunchConfigFilename)
-			if err = os.WriteFile(configFilePath, configBz, 0600); err != nil {
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to write config file: %v", err)}
-			}
-		}
-
 		minitiaHome, err := weavecontext.GetMinitiaHome(ctx)
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get minitia home directory: %v", err)}
 		}
-		launchCmd := exec.Command(state.binaryPath, "launch", "--with-config", configFilePath, "--home", minitiaHome)
 
-		stdout, err := launchCmd.StdoutPipe()
-		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to capture stdout: %v", err)}
-		}
-		stderr, err := launchCmd.StderrPipe()
-		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to capture stderr: %v", err)}
-		}
-
-		if err = launchCmd.Start(); err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to start command: %v", err)}
-		}
-
-		go func() {
-			scanner := bufio.NewScanner(stdout)
-			for scanner.Scan() {
-				line := scanner.Text()
-				if !isJSONLog(line) {
-					*streamingLogs = append(*streamingLogs, line)
-					if len(*streamingLogs) > 10 {
-						*streamingLogs = (*streamingLogs)[1:]
-					}
-				}
+		// `minitiad launch` creates the bridge on the L1, so a resumed launch never runs it twice
+		if state.checkpoint == nil || !state.checkpoint.IsDone(LaunchStepRollupLaunched) {
+			if err = runMinitiadLaunch(state, minitiaHome, streamingLogs); err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
 			}
-		}()
-
-		go func() {
-			scanner := bufio.NewScanner(stderr)
-			for scanner.Scan() {
-				line := scanner.Text()
-				if !isJSONLog(line) {
-					*streamingLogs = append(*streamingLogs, line)
-					if len(*streamingLogs) > 10 {
-						*streamingLogs = (*streamingLogs)[1:]
-					}
-				}
+			var artifacts types.Artifacts
+			if artifactsBz, err := os.ReadFile(filepath.Join(minitiaHome, common.MinitiaArtifactsJson)); err == nil {
+				_ = json.Unmarshal(artifactsBz, &artifacts)
 			}
-		}()
-
-		if err = launchCmd.Wait(); err != nil {
-			if err != nil {
-				*streamingLogs = append(*streamingLogs, fmt.Sprintf("Launch command finished with error: %v", err))
-				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("command execution failed: %v", err)}
+			if err = state.completeCheckpointStep(LaunchStepRollupLaunched, artifacts.BridgeID); err != nil {
+				return ui.NonRetryableErrorLoading{Err: err}
 			}
 		}
+		if err = MoveOperatorKey(state.binaryPath, minitiaHome, state.launchConfig().SystemKeys.Validator.Mnemonic); err != nil {
+			return ui.NonRetryableErrorLoading{Err: err}
+		}
 
 		appConfigPath := filepath.Join(userHome, common.MinitiaConfigPath, "app.toml")
 		if err = config.UpdateTomlValue(appConfigPath, "inter-block-cache", "false"); err != nil {
@@ -2956,6 +3298,9 @@ func launchingMinitia(ctx context.Context, streamingLogs *[]string) tea.Cmd {
 		if err = srv.Create(fmt.Sprintf("mini%s@%s", strings.ToLower(state.vmType), state.minitiadVersion), minitiaHome); err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to create service: %v", err)}
 		}
+		if err = state.completeCheckpointStep(LaunchStepServiceCreated, ""); err != nil {
+			return ui.NonRetryableErrorLoading{Err: err}
+		}
 
 		// prune existing logs, ignore error
 		_ = srv.PruneLogs()
@@ -2966,6 +3311,68 @@ func launchingMinitia(ctx context.Context, streamingLogs *[]string) tea.Cmd {
 	}
 }
 
+// runMinitiadLaunch runs `minitiad launch` with the launch config, streaming its output into streamingLogs
+func runMinitiadLaunch(state LaunchState, minitiaHome string, streamingLogs *[]string) error {
+	// the config given to --with-config may be YAML/TOML or reference its secrets, so minitiad always reads
+	// a resolved JSON copy
+	configFilePath, err := WriteLaunchConfig(state.launchConfig())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = io.SecureDelete(configFilePath) }()
+
+	launchCmd := exec.Command(state.binaryPath, "launch", "--with-config", configFilePath, "--home", minitiaHome)
+
+	stdout, err := launchCmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to capture stdout: %v", err)
+	}
+	stderr, err := launchCmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to capture stderr: %v", err)
+	}
+
+	if err = launchCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %v", err)
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !isJSONLog(line) {
+				*streamingLogs = append(*streamingLogs, line)
+				if len(*streamingLogs) > 10 {
+					*streamingLogs = (*streamingLogs)[1:]
+				}
+			}
+		}
+	}()
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !isJSONLog(line) {
+				*streamingLogs = append(*streamingLogs, line)
+				if len(*streamingLogs) > 10 {
+					*streamingLogs = (*streamingLogs)[1:]
+				}
+			}
+		}
+	}()
+
+	waitErr := launchCmd.Wait()
+	if err = io.SecureDelete(configFilePath); err != nil {
+		return fmt.Errorf("failed to delete launch config %s: %v", configFilePath, err)
+	}
+	if waitErr != nil {
+		*streamingLogs = append(*streamingLogs, fmt.Sprintf("Launch command finished with error: %v", waitErr))
+		return fmt.Errorf("command execution failed: %v", waitErr)
+	}
+	return nil
+}
+
 func (m *LaunchingNewMinitiaLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
 		return model, cmd
@@ -3080,16 +3487,34 @@ func (m *LaunchingNewMinitiaLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.HandlePanic(fmt.Errorf("failed to create update params message: %v", err))
 			}
 
-			runCmd := exec.Command(state.binaryPath, "tx", "opchild", "execute-messages", messageJsonPath,
-				"--from", "Validator", "--keyring-backend", "test",
+			minitiaHome, err := weavecontext.GetMinitiaHome(m.Ctx)
+			if err != nil {
+				return m, m.HandlePanic(fmt.Errorf("failed to get minitia home directory: %v", err))
+			}
+			keyring := operatorKeyring(state.binaryPath, minitiaHome)
+			input, err := keyring.Input(state.binaryPath)
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
+			runCmd := exec.Command(state.binaryPath, append([]string{"tx", "opchild", "execute-messages", messageJsonPath,
+				"--from", LaunchOperatorKeyName, "--home", minitiaHome,
 				"--chain-id", state.chainId, "-y",
-			)
+			}, keyring.Args()...)...)
+			runCmd.Stdin = strings.NewReader(input)
 			if err := runCmd.Run(); err != nil {
 				return m, m.HandlePanic(fmt.Errorf("failed to update params message: %v", err))
 			}
 		}
 
-		return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+		if err = DeleteLaunchCheckpoint(); err != nil {
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "Failed to delete the launch checkpoint", []string{}, err.Error()))
+		}
+		state.checkpoint = nil
+
+		if state.launchFromExistingConfig {
+			return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+		}
+		return NewSaveTemplateSelect(weavecontext.SetCurrentState(m.Ctx, state)), nil
 	}
 	return m, cmd
 }
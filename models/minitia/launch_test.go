@@ -170,12 +170,9 @@ func TestNetworkSelect_Update_Selection(t *testing.T) {
 	state.weave = types.WeaveState{}
 	model, _ := NewNetworkSelect(ctx)
 
-	msg := tea.KeyMsg{Type: tea.KeyDown}
+	msg := tea.KeyMsg{Type: tea.KeyEnter}
 	updatedModel, cmd := model.Update(msg)
 
-	msg = tea.KeyMsg{Type: tea.KeyEnter}
-	updatedModel, cmd = updatedModel.Update(msg)
-
 	assert.IsType(t, &VMTypeSelect{}, updatedModel, "Expected model to transition to VMTypeSelect after network selection")
 	assert.Nil(t, cmd, "Expected no command after network selection")
 }
@@ -1983,3 +1980,23 @@ func TestTerminalState_View(t *testing.T) {
 	state := weavecontext.GetCurrentState[LaunchState](terminalState.Ctx)
 	assert.Contains(t, view, state.weave.Render(), "Expected view to contain the rendered output from the weave")
 }
+
+func TestDeriveSystemKeys(t *testing.T) {
+	state := NewLaunchState()
+	state.generateKeys = true
+	state.batchSubmissionIsCelestia = true
+	assert.NoError(t, deriveSystemKeys(state))
+	assert.True(t, strings.HasPrefix(state.systemKeyOperatorAddress, "init1"))
+	assert.True(t, strings.HasPrefix(state.systemKeyBatchSubmitterAddress, "celestia1"))
+	assert.NotEqual(t, state.systemKeyOperatorMnemonic, state.systemKeyChallengerMnemonic)
+
+	// recovered keys keep their mnemonics
+	operatorMnemonic := state.systemKeyOperatorMnemonic
+	operatorAddress := state.systemKeyOperatorAddress
+	state.generateKeys = false
+	state.batchSubmissionIsCelestia = false
+	assert.NoError(t, deriveSystemKeys(state))
+	assert.Equal(t, operatorMnemonic, state.systemKeyOperatorMnemonic)
+	assert.Equal(t, operatorAddress, state.systemKeyOperatorAddress)
+	assert.True(t, strings.HasPrefix(state.systemKeyBatchSubmitterAddress, "init1"))
+}
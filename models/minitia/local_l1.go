@@ -0,0 +1,199 @@
+package minitia
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/initia-labs/weave/common"
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/ui"
+)
+
+// detectLocalL1Config prefills the local L1 questions with the local L1 saved by a previous launch, or with the
+// values read from the initia home of the node running on this machine
+func detectLocalL1Config(ctx context.Context) registry.LocalL1Config {
+	if saved, ok := registry.GetSavedLocalL1Config(); ok {
+		return saved
+	}
+
+	initiaHome, err := weavecontext.GetInitiaHome(ctx)
+	if err != nil {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return registry.LocalL1Config{}
+		}
+		initiaHome = filepath.Join(userHome, common.InitiaDirectory)
+	}
+	detected, err := registry.ReadLocalL1Config(initiaHome)
+	if err != nil {
+		return registry.LocalL1Config{}
+	}
+	return detected
+}
+
+type LocalL1ChainIdInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question string
+}
+
+func NewLocalL1ChainIdInput(ctx context.Context) *LocalL1ChainIdInput {
+	state := weavecontext.GetCurrentState[LaunchState](ctx)
+	state.localL1 = detectLocalL1Config(ctx)
+
+	model := &LocalL1ChainIdInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: weavecontext.SetCurrentState(ctx, state)},
+		question:  "Specify the chain ID of the local L1",
+	}
+	model.WithPlaceholder("Enter the chain ID")
+	model.WithPrefillValue(state.localL1.ChainId)
+	model.WithValidatorFn(common.ValidateNonEmptyAndLengthString("Chain ID", MaxChainIDLength))
+	return model
+}
+
+func (m *LocalL1ChainIdInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *LocalL1ChainIdInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LocalL1ChainIdInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		state.localL1.ChainId = input.Text
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"chain ID", "local L1"}, input.Text))
+		return NewLocalL1RpcInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *LocalL1ChainIdInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{"chain ID", "local L1"}, styles.Question) + m.TextInput.View())
+}
+
+type LocalL1RpcInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question string
+}
+
+func NewLocalL1RpcInput(ctx context.Context) *LocalL1RpcInput {
+	state := weavecontext.GetCurrentState[LaunchState](ctx)
+	model := &LocalL1RpcInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  "Specify the RPC endpoint of the local L1",
+	}
+	model.WithPlaceholder("Enter the RPC endpoint, e.g. http://localhost:26657")
+	model.WithPrefillValue(state.localL1.Rpc)
+	model.WithValidatorFn(common.ValidateURL)
+	return model
+}
+
+func (m *LocalL1RpcInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *LocalL1RpcInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LocalL1RpcInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		state.localL1.Rpc = input.Text
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"RPC endpoint", "local L1"}, input.Text))
+		return NewLocalL1LcdInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *LocalL1RpcInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{"RPC endpoint", "local L1"}, styles.Question) + m.TextInput.View())
+}
+
+type LocalL1LcdInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question string
+}
+
+func NewLocalL1LcdInput(ctx context.Context) *LocalL1LcdInput {
+	state := weavecontext.GetCurrentState[LaunchState](ctx)
+	model := &LocalL1LcdInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  "Specify the REST API (LCD) endpoint of the local L1",
+	}
+	model.WithPlaceholder("Enter the REST API endpoint, e.g. http://localhost:1317")
+	model.WithPrefillValue(state.localL1.Lcd)
+	model.WithValidatorFn(common.ValidateURL)
+	return model
+}
+
+func (m *LocalL1LcdInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *LocalL1LcdInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LocalL1LcdInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		state.localL1.Lcd = input.Text
+		if state.localL1.Grpc == "" {
+			state.localL1.Grpc = registry.LocalL1DefaultGrpc
+		}
+		if state.localL1.GasPrices == "" {
+			state.localL1.GasPrices = registry.LocalL1DefaultGasPrices
+		}
+		useLocalL1 := registry.SaveLocalL1
+		if state.dryRun {
+			useLocalL1 = registry.UseLocalL1
+		}
+		if err := useLocalL1(state.localL1); err != nil {
+			return m, m.HandlePanic(err)
+		}
+		state.l1ChainId = state.localL1.ChainId
+		state.l1RPC = state.localL1.Rpc
+
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{"REST API (LCD) endpoint", "local L1"}, input.Text))
+		return NewVMTypeSelect(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *LocalL1LcdInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{"REST API (LCD) endpoint", "local L1"}, styles.Question) + m.TextInput.View())
+}
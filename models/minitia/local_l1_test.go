@@ -0,0 +1,38 @@
+package minitia
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/registry"
+)
+
+func TestLocalL1Inputs_Update(t *testing.T) {
+	InitializeViperForTest(t)
+	t.Cleanup(func() { delete(registry.LoadedChainRegistry, registry.InitiaL1Local) })
+
+	initiaHome := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(initiaHome, "config"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(initiaHome, "config", "client.toml"), []byte(`chain-id = "local-1"`), 0644))
+
+	state := NewLaunchState()
+	state.EnableDryRun()
+	ctx := weavecontext.SetInitiaHome(weavecontext.NewAppContext(*state), initiaHome)
+
+	var model tea.Model = NewLocalL1ChainIdInput(ctx)
+	for idx := 0; idx < 3; idx++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	vmTypeSelect, ok := model.(*VMTypeSelect)
+	assert.True(t, ok)
+	finalState := weavecontext.GetCurrentState[LaunchState](vmTypeSelect.Ctx)
+	assert.Equal(t, "local-1", finalState.l1ChainId)
+	assert.Equal(t, registry.LocalL1DefaultRpc, finalState.l1RPC)
+	assert.True(t, registry.IsLocalL1("local-1"))
+}
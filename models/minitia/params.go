@@ -0,0 +1,338 @@
+package minitia
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/styles"
+)
+
+const (
+	// LaunchOperatorKeyName is the name `minitiad launch` stores the operator key under in the rollup home keyring
+	LaunchOperatorKeyName string = "Validator"
+
+	paramsConfirmTimeout  = 30 * time.Second
+	paramsConfirmInterval = 2 * time.Second
+)
+
+// OPChildParamsUpdate holds the opchild params to change. Fields left nil keep their current value.
+type OPChildParamsUpdate struct {
+	MinGasPrices    *string
+	FeeWhitelist    *[]string
+	BridgeExecutors *[]string
+	MaxValidators   *uint32
+	HookMaxGas      *string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u OPChildParamsUpdate) IsEmpty() bool {
+	return u.MinGasPrices == nil && u.FeeWhitelist == nil && u.BridgeExecutors == nil && u.MaxValidators == nil && u.HookMaxGas == nil
+}
+
+// Validate checks every field of the update and reports all problems at once
+func (u OPChildParamsUpdate) Validate() error {
+	var errs []string
+	if u.MinGasPrices != nil {
+		if _, err := parseMinGasPrices(*u.MinGasPrices); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for name, addresses := range map[string]*[]string{"fee whitelist": u.FeeWhitelist, "bridge executors": u.BridgeExecutors} {
+		if addresses == nil {
+			continue
+		}
+		for _, address := range *addresses {
+			if err := common.IsValidAddress(address); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v: %s", name, err, address))
+			}
+		}
+	}
+	if u.BridgeExecutors != nil && len(*u.BridgeExecutors) == 0 {
+		errs = append(errs, "bridge executors: at least one bridge executor is required")
+	}
+	if u.MaxValidators != nil && *u.MaxValidators == 0 {
+		errs = append(errs, "max validators must be greater than 0")
+	}
+	if u.HookMaxGas != nil {
+		if gas, err := strconv.ParseUint(*u.HookMaxGas, 10, 64); err != nil || gas == 0 {
+			errs = append(errs, fmt.Sprintf("hook max gas must be a positive integer: %s", *u.HookMaxGas))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid params update:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return nil
+}
+
+// Apply returns params with the fields of the update replaced
+func (u OPChildParamsUpdate) Apply(params cosmosutils.OPChildParams) (cosmosutils.OPChildParams, error) {
+	if u.MinGasPrices != nil {
+		minGasPrices, err := parseMinGasPrices(*u.MinGasPrices)
+		if err != nil {
+			return params, err
+		}
+		params.MinGasPrices = minGasPrices
+	}
+	if u.FeeWhitelist != nil {
+		params.FeeWhitelist = *u.FeeWhitelist
+	}
+	if u.BridgeExecutors != nil {
+		params.BridgeExecutors = *u.BridgeExecutors
+	}
+	if u.MaxValidators != nil {
+		params.MaxValidators = *u.MaxValidators
+	}
+	if u.HookMaxGas != nil {
+		params.HookMaxGas = *u.HookMaxGas
+	}
+	return params, nil
+}
+
+// AppliedTo reports whether params already hold every field of the update
+func (u OPChildParamsUpdate) AppliedTo(params cosmosutils.OPChildParams) bool {
+	if u.MinGasPrices != nil {
+		want, err := parseMinGasPrices(*u.MinGasPrices)
+		if err != nil || !equalDecCoins(want, params.MinGasPrices) {
+			return false
+		}
+	}
+	if u.FeeWhitelist != nil && !equalAddressSets(*u.FeeWhitelist, params.FeeWhitelist) {
+		return false
+	}
+	if u.BridgeExecutors != nil && !equalAddressSets(*u.BridgeExecutors, params.BridgeExecutors) {
+		return false
+	}
+	if u.MaxValidators != nil && *u.MaxValidators != params.MaxValidators {
+		return false
+	}
+	if u.HookMaxGas != nil && *u.HookMaxGas != params.HookMaxGas {
+		return false
+	}
+	return true
+}
+
+// parseMinGasPrices parses comma separated decimal coins like 0.15umin,0.01uusdc. An empty string clears them.
+func parseMinGasPrices(minGasPrices string) (cosmosutils.DecCoins, error) {
+	coins := cosmosutils.DecCoins{}
+	if strings.TrimSpace(minGasPrices) == "" {
+		return coins, nil
+	}
+	for _, coin := range strings.Split(minGasPrices, ",") {
+		amount, denom, err := common.ParseDecCoin(strings.TrimSpace(coin))
+		if err != nil {
+			return nil, fmt.Errorf("min gas prices: %v", err)
+		}
+		coins = append(coins, cosmosutils.DecCoin{Denom: denom, Amount: amount})
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i].Denom < coins[j].Denom })
+	return coins, nil
+}
+
+func equalDecCoins(a, b cosmosutils.DecCoins) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	amounts := make(map[string]*big.Rat, len(a))
+	for _, coin := range a {
+		amount, ok := new(big.Rat).SetString(coin.Amount)
+		if !ok {
+			return false
+		}
+		amounts[coin.Denom] = amount
+	}
+	for _, coin := range b {
+		want, found := amounts[coin.Denom]
+		amount, ok := new(big.Rat).SetString(coin.Amount)
+		if !found || !ok || want.Cmp(amount) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func equalAddressSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, address := range a {
+		set[address] = true
+	}
+	for _, address := range b {
+		if !set[address] {
+			return false
+		}
+	}
+	return true
+}
+
+// GetOPChildParams queries the opchild params of the rollup at minitiaHome through its REST API
+func GetOPChildParams(minitiaHome string) (cosmosutils.OPChildParams, error) {
+	info, err := readRollupInfo(minitiaHome)
+	if err != nil {
+		return cosmosutils.OPChildParams{}, err
+	}
+	lcd := info.endpoint("REST API")
+	if lcd == nil {
+		return cosmosutils.OPChildParams{}, fmt.Errorf("the REST API of the rollup is disabled in app.toml")
+	}
+	params, err := cosmosutils.QueryOPChildParams(lcd.Address)
+	if err != nil {
+		return params, fmt.Errorf("failed to query opchild params from %s: %v", lcd.Address, err)
+	}
+	return params, nil
+}
+
+// BuildOPChildUpdateParamsMsg applies update to the current params of the rollup and writes the MsgUpdateParams
+// messages file to messagesPath. The current and updated params are returned.
+func BuildOPChildUpdateParamsMsg(minitiaHome, messagesPath string, update OPChildParamsUpdate) (current, updated cosmosutils.OPChildParams, err error) {
+	if err = update.Validate(); err != nil {
+		return current, updated, err
+	}
+	current, err = GetOPChildParams(minitiaHome)
+	if err != nil {
+		return current, updated, err
+	}
+	updated, err = update.Apply(current)
+	if err != nil {
+		return current, updated, err
+	}
+	if err = cosmosutils.CreateOPChildUpdateParamsMsg(messagesPath, updated); err != nil {
+		return current, updated, fmt.Errorf("failed to create update params message: %v", err)
+	}
+	return current, updated, nil
+}
+
+// UpdateOPChildParams signs the MsgUpdateParams in messagesPath with the operator key of the rollup through
+// `minitiad tx opchild execute-messages`, then waits until the rollup reports the updated params
+func UpdateOPChildParams(minitiaHome, messagesPath string, current cosmosutils.OPChildParams, update OPChildParamsUpdate) (string, error) {
+	info, err := readRollupInfo(minitiaHome)
+	if err != nil {
+		return "", err
+	}
+	vm, version, err := DetectRollupService()
+	if err != nil {
+		return "", err
+	}
+	binaryPath, err := cosmosutils.GetMinitiaBinaryPath(vm, version)
+	if err != nil {
+		return "", err
+	}
+	if err = io.SetLibraryPaths(filepath.Dir(binaryPath)); err != nil {
+		return "", err
+	}
+
+	// the operator pays the fee at the current min gas prices, the updated ones only apply after this tx
+	var gasPrices string
+	if len(current.MinGasPrices) > 0 {
+		gasPrices = current.MinGasPrices[0].Amount + current.MinGasPrices[0].Denom
+	}
+	rpc := info.endpoint("RPC")
+	keyring := operatorKeyring(binaryPath, minitiaHome)
+	txResponse, err := cosmosutils.NewMinitiadTxExecutor(binaryPath, minitiaHome).ExecuteMessages(messagesPath, keyring, LaunchOperatorKeyName, gasPrices, rpc.Address, info.ChainId)
+	if err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(paramsConfirmTimeout)
+	for {
+		params, err := GetOPChildParams(minitiaHome)
+		if err == nil && update.AppliedTo(params) {
+			return txResponse.TxHash, nil
+		}
+		if time.Now().After(deadline) {
+			return txResponse.TxHash, fmt.Errorf("tx %s was included, but the rollup does not report the updated params after %s", txResponse.TxHash, paramsConfirmTimeout)
+		}
+		time.Sleep(paramsConfirmInterval)
+	}
+}
+
+// MoveOperatorKey moves the operator key `minitiad launch` writes to the test keyring of the rollup home into the
+// keyring of the rollup home with the configured backend. Nothing is moved with the test backend, or when the test
+// keyring holds no operator key, e.g. when it was already moved.
+func MoveOperatorKey(binaryPath, minitiaHome, mnemonic string) error {
+	keyring := cosmosutils.HomeKeyring(minitiaHome)
+	testKeyring := cosmosutils.TestKeyring(minitiaHome)
+	if keyring.Backend == cosmosutils.KeyringBackendTest || !testKeyring.KeyExists(binaryPath, LaunchOperatorKeyName) {
+		return nil
+	}
+	if _, err := keyring.RecoverKeyFromMnemonic(binaryPath, LaunchOperatorKeyName, mnemonic); err != nil {
+		return fmt.Errorf("failed to move the operator key to the %s keyring: %v", keyring.Backend, err)
+	}
+	if err := testKeyring.DeleteKey(binaryPath, LaunchOperatorKeyName); err != nil {
+		return fmt.Errorf("failed to delete the operator key from the test keyring: %v", err)
+	}
+	return nil
+}
+
+// operatorKeyring returns the keyring of the rollup home that holds the operator key, the test one for rollups
+// launched before the keyring backend was set
+func operatorKeyring(binaryPath, minitiaHome string) cosmosutils.Keyring {
+	keyring := cosmosutils.HomeKeyring(minitiaHome)
+	if keyring.Backend != cosmosutils.KeyringBackendTest && !keyring.KeyExists(binaryPath, LaunchOperatorKeyName) {
+		return cosmosutils.TestKeyring(minitiaHome)
+	}
+	return keyring
+}
+
+// CreateParamsMessagesFile creates an empty temporary file for the MsgUpdateParams messages, so that an update does
+// not overwrite the messages.json of a launch or of another update. The caller removes it.
+func CreateParamsMessagesFile() (string, error) {
+	file, err := os.CreateTemp("", "weave-update-params-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create messages file: %v", err)
+	}
+	if err = file.Close(); err != nil {
+		return "", fmt.Errorf("failed to create messages file: %v", err)
+	}
+	return file.Name(), nil
+}
+
+// RenderOPChildParams describes the opchild params in a few lines
+func RenderOPChildParams(params cosmosutils.OPChildParams) string {
+	var minGasPrices []string
+	for _, coin := range params.MinGasPrices {
+		minGasPrices = append(minGasPrices, coin.Amount+coin.Denom)
+	}
+	listOrNone := func(values []string) string {
+		if len(values) == 0 {
+			return "none"
+		}
+		return strings.Join(values, ", ")
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.BoldText("OPchild params\n", styles.Cyan))
+	for _, field := range [][2]string{
+		{"Min gas prices", listOrNone(minGasPrices)},
+		{"Fee whitelist", listOrNone(params.FeeWhitelist)},
+		{"Bridge executors", listOrNone(params.BridgeExecutors)},
+		{"Max validators", strconv.FormatUint(uint64(params.MaxValidators), 10)},
+		{"Hook max gas", params.HookMaxGas},
+		{"Admin", params.Admin},
+		{"Historical entries", strconv.FormatUint(uint64(params.HistoricalEntries), 10)},
+	} {
+		b.WriteString(fmt.Sprintf("  %-19s %s\n", field[0]+":", styles.BoldText(field[1], styles.White)))
+	}
+	return b.String()
+}
+
+// MarshalOPChildParams returns the params as indented JSON
+func MarshalOPChildParams(params cosmosutils.OPChildParams) (string, error) {
+	bz, err := json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal opchild params: %v", err)
+	}
+	return string(bz), nil
+}
@@ -0,0 +1,113 @@
+package minitia
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/cosmosutils"
+)
+
+func TestOPChildParamsUpdateValidate(t *testing.T) {
+	minGasPrices := "0.15umin,abc"
+	feeWhitelist := []string{genesisAddress1, "cosmos1invalid"}
+	bridgeExecutors := []string{}
+	maxValidators := uint32(0)
+	hookMaxGas := "-1"
+	update := OPChildParamsUpdate{
+		MinGasPrices:    &minGasPrices,
+		FeeWhitelist:    &feeWhitelist,
+		BridgeExecutors: &bridgeExecutors,
+		MaxValidators:   &maxValidators,
+		HookMaxGas:      &hookMaxGas,
+	}
+
+	err := update.Validate()
+	assert.Error(t, err)
+	for _, expected := range []string{"min gas prices", "fee whitelist", "at least one bridge executor", "max validators", "hook max gas"} {
+		assert.Contains(t, err.Error(), expected)
+	}
+
+	assert.True(t, OPChildParamsUpdate{}.IsEmpty())
+	assert.NoError(t, OPChildParamsUpdate{}.Validate())
+}
+
+func TestOPChildParamsUpdateApply(t *testing.T) {
+	current := cosmosutils.OPChildParams{
+		MaxValidators:   1,
+		MinGasPrices:    cosmosutils.DecCoins{{Denom: "umin", Amount: "0.000000000000000000"}},
+		BridgeExecutors: []string{genesisAddress1},
+		FeeWhitelist:    []string{genesisAddress1},
+		HookMaxGas:      "3000000",
+	}
+	minGasPrices := "0.15umin"
+	feeWhitelist := []string{genesisAddress1, genesisAddress2}
+	update := OPChildParamsUpdate{MinGasPrices: &minGasPrices, FeeWhitelist: &feeWhitelist}
+
+	assert.False(t, update.AppliedTo(current))
+	updated, err := update.Apply(current)
+	assert.NoError(t, err)
+	assert.Equal(t, cosmosutils.DecCoins{{Denom: "umin", Amount: "0.15"}}, updated.MinGasPrices)
+	assert.Equal(t, feeWhitelist, updated.FeeWhitelist)
+	assert.Equal(t, current.BridgeExecutors, updated.BridgeExecutors)
+	assert.Equal(t, current.HookMaxGas, updated.HookMaxGas)
+
+	// the chain reports decimals with full precision and may reorder the lists
+	updated.MinGasPrices = cosmosutils.DecCoins{{Denom: "umin", Amount: "0.150000000000000000"}}
+	updated.FeeWhitelist = []string{genesisAddress2, genesisAddress1}
+	assert.True(t, update.AppliedTo(updated))
+}
+
+func TestBuildOPChildUpdateParamsMsg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/opinit/opchild/v1/params", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"params": {"max_validators": 1, "min_gas_prices": [], "bridge_executors": ["` + genesisAddress1 + `"], "admin": "` + genesisAddress1 + `", "fee_whitelist": [], "hook_max_gas": "3000000"}}`))
+	}))
+	defer server.Close()
+
+	home := writeRollupHome(t)
+	appToml := "[api]\nenable = true\naddress = \"tcp://" + strings.TrimPrefix(server.URL, "http://") + "\"\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte(appToml), 0644))
+
+	maxValidators := uint32(3)
+	messagesPath := filepath.Join(t.TempDir(), "messages.json")
+	current, updated, err := BuildOPChildUpdateParamsMsg(home, messagesPath, OPChildParamsUpdate{MaxValidators: &maxValidators})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), current.MaxValidators)
+	assert.Equal(t, uint32(3), updated.MaxValidators)
+
+	bz, err := os.ReadFile(messagesPath)
+	assert.NoError(t, err)
+	var payload cosmosutils.JsonPayload
+	assert.NoError(t, json.Unmarshal(bz, &payload))
+	assert.Len(t, payload.Messages, 1)
+	assert.Equal(t, "/opinit.opchild.v1.MsgUpdateParams", payload.Messages[0].Type)
+	assert.Equal(t, uint32(3), payload.Messages[0].Params.MaxValidators)
+	assert.Equal(t, []string{genesisAddress1}, payload.Messages[0].Params.BridgeExecutors)
+}
+
+func TestOperatorKeyring(t *testing.T) {
+	minitiaHome := t.TempDir()
+	// the fake minitiad only knows the operator key in the test keyring, like a rollup launched before the backend was set
+	binaryPath := filepath.Join(t.TempDir(), "minitiad")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\ncase \"$*\" in *\"--keyring-backend test\"*) exit 0;; esac\nexit 1\n"), 0755))
+	t.Cleanup(func() { cosmosutils.SetKeyringBackend(cosmosutils.KeyringBackendTest, nil) })
+
+	assert.Equal(t, cosmosutils.TestKeyring(minitiaHome), operatorKeyring(binaryPath, minitiaHome))
+
+	cosmosutils.SetKeyringBackend(cosmosutils.KeyringBackendFile, func() (string, error) { return "passphrase", nil })
+	assert.Equal(t, cosmosutils.TestKeyring(minitiaHome), operatorKeyring(binaryPath, minitiaHome))
+
+	// once moved, the operator key is found with the configured backend
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\ncase \"$*\" in *\"--keyring-backend file\"*) exit 0;; esac\nexit 1\n"), 0755))
+	assert.Equal(t, cosmosutils.HomeKeyring(minitiaHome), operatorKeyring(binaryPath, minitiaHome))
+	// nothing is left in the test keyring to move
+	assert.NoError(t, MoveOperatorKey(binaryPath, minitiaHome, "operator mnemonic"))
+}
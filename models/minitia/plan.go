@@ -0,0 +1,406 @@
+package minitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/types"
+	"github.com/initia-labs/weave/ui"
+)
+
+// FundingTx is a single transfer the launch would make from the gas station
+type FundingTx struct {
+	ChainId string `json:"chain_id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Role    string `json:"role"`
+	Amount  string `json:"amount"`
+}
+
+// GasStationRequirement compares what the gas station has to spend on a chain with what it holds
+type GasStationRequirement struct {
+	ChainId   string `json:"chain_id"`
+	Address   string `json:"address"`
+	Denom     string `json:"denom"`
+	Required  string `json:"required"`
+	Available string `json:"available"`
+	Enough    bool   `json:"enough"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BinaryDownload is a binary the launch runs, downloaded unless it is already installed
+type BinaryDownload struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	Path      string `json:"path"`
+	Installed bool   `json:"installed"`
+}
+
+// LaunchPlan describes everything `weave rollup launch` would do without doing it
+type LaunchPlan struct {
+	Config     *types.MinitiaConfig    `json:"config"`
+	Binaries   []BinaryDownload        `json:"binaries"`
+	FundingTxs []FundingTx             `json:"funding_txs"`
+	GasStation []GasStationRequirement `json:"gas_station"`
+	// GenesisTotals are the genesis balances of all accounts added up per denom
+	GenesisTotals []string `json:"genesis_totals,omitempty"`
+	Files         []string `json:"files"`
+	Services      []string `json:"services"`
+	Notes         []string `json:"notes,omitempty"`
+}
+
+func (p *LaunchPlan) String() string {
+	var b strings.Builder
+
+	configBz, _ := json.MarshalIndent(p.Config, "", "  ")
+	b.WriteString(styles.BoldText("Rollup config (mnemonics redacted)\n", styles.Cyan))
+	b.WriteString(string(configBz) + "\n\n")
+
+	b.WriteString(styles.BoldText("Binaries\n", styles.Cyan))
+	for _, binary := range p.Binaries {
+		status := fmt.Sprintf("would be downloaded from %s", binary.URL)
+		if binary.Installed {
+			status = "already installed"
+		}
+		b.WriteString(fmt.Sprintf("  %s %s at %s: %s\n", binary.Name, binary.Version, binary.Path, status))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(styles.BoldText("Funding transactions\n", styles.Cyan))
+	if len(p.FundingTxs) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, tx := range p.FundingTxs {
+		b.WriteString(fmt.Sprintf("  [%s] %s -> %s %s %s\n", tx.ChainId, tx.From, tx.Role, styles.Text(fmt.Sprintf("(%s)", tx.To), styles.Gray), styles.BoldText(tx.Amount, styles.White)))
+	}
+
+	if len(p.GenesisTotals) > 0 {
+		b.WriteString("\n" + styles.BoldText("Genesis balances\n", styles.Cyan))
+		b.WriteString(fmt.Sprintf("  %d accounts, total: %s\n", len(*p.Config.GenesisAccounts), strings.Join(p.GenesisTotals, ", ")))
+	}
+
+	if len(p.GasStation) > 0 {
+		b.WriteString("\n" + styles.BoldText("Gas Station balance\n", styles.Cyan))
+	}
+	for _, req := range p.GasStation {
+		status := styles.Text("enough", styles.Green)
+		if req.Error != "" {
+			status = styles.Text(fmt.Sprintf("unknown: %s", req.Error), styles.Yellow)
+		} else if !req.Enough {
+			status = styles.BoldText("NOT enough", styles.Yellow)
+		}
+		b.WriteString(fmt.Sprintf("  [%s] %s needs %s%s, has %s%s: %s\n", req.ChainId, req.Address, req.Required, req.Denom, req.Available, req.Denom, status))
+	}
+
+	b.WriteString("\n" + styles.BoldText("Files and directories\n", styles.Cyan))
+	for _, file := range p.Files {
+		b.WriteString(fmt.Sprintf("  %s\n", file))
+	}
+	b.WriteString("\n" + styles.BoldText("Services\n", styles.Cyan))
+	for _, srv := range p.Services {
+		b.WriteString(fmt.Sprintf("  %s\n", srv))
+	}
+
+	for _, note := range p.Notes {
+		b.WriteString("\n" + styles.Text("i "+note, styles.Yellow))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// addCommonEntries lists the binary, files and services every launch creates
+func (p *LaunchPlan) addCommonEntries(vm, version, downloadURL, minitiaHome string) error {
+	binaryPath, err := cosmosutils.GetMinitiaBinaryPath(strings.ToLower(vm), version)
+	if err != nil {
+		return err
+	}
+	p.addBinary(fmt.Sprintf("mini%s", strings.ToLower(vm)), version, downloadURL, binaryPath)
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	weaveDataPath := filepath.Join(userHome, common.WeaveDataDirectory)
+	p.Files = append(p.Files,
+		filepath.Join(weaveDataPath, fmt.Sprintf("mini%s@%s", strings.ToLower(vm), version)),
+		filepath.Join(minitiaHome, "config"),
+		filepath.Join(minitiaHome, "data"),
+		filepath.Join(minitiaHome, "artifacts", "config.json"),
+		filepath.Join(minitiaHome, "artifacts", "artifacts.json"),
+	)
+
+	if io.FileOrFolderExists(minitiaHome) {
+		p.Notes = append(p.Notes, fmt.Sprintf("%s already exists and would be deleted before launching.", minitiaHome))
+	}
+
+	servicePath, err := service.GetServiceFilePath(service.Minitia)
+	if err != nil {
+		return err
+	}
+	p.Services = append(p.Services, fmt.Sprintf("%s (home: %s)", servicePath, minitiaHome))
+	return nil
+}
+
+// addBinary lists a binary the launch runs
+func (p *LaunchPlan) addBinary(name, version, url, path string) {
+	p.Binaries = append(p.Binaries, BinaryDownload{
+		Name:      name,
+		Version:   version,
+		URL:       url,
+		Path:      path,
+		Installed: io.FileOrFolderExists(path),
+	})
+}
+
+// addGenesisTotals sums up the genesis balances of the config
+func (p *LaunchPlan) addGenesisTotals() error {
+	if p.Config.GenesisAccounts == nil || len(*p.Config.GenesisAccounts) == 0 {
+		return nil
+	}
+	totals, err := GenesisAccountTotals(*p.Config.GenesisAccounts)
+	if err != nil {
+		return err
+	}
+	p.GenesisTotals = totals
+	return nil
+}
+
+// BuildLaunchPlanFromConfig describes a launch with --with-config. Weave does not fund any account in this mode.
+func BuildLaunchPlanFromConfig(vm, version, downloadURL, minitiaHome string, cfg *types.MinitiaConfig) (*LaunchPlan, error) {
+	plan := &LaunchPlan{Config: cfg.Redacted()}
+	if err := plan.addCommonEntries(vm, version, downloadURL, minitiaHome); err != nil {
+		return nil, err
+	}
+	if err := plan.addGenesisTotals(); err != nil {
+		return nil, err
+	}
+	plan.Notes = append(plan.Notes, "Weave does not fund system accounts when launching with --with-config. Make sure the bridge executor, output submitter, batch submitter and challenger hold enough L1 funds.")
+	return plan, nil
+}
+
+// BuildLaunchPlan describes the interactive launch once all answers are collected and the system keys are known
+func BuildLaunchPlan(state LaunchState, minitiaHome string) (*LaunchPlan, error) {
+	plan := &LaunchPlan{Config: state.BuildMinitiaConfig().Redacted()}
+	if err := plan.addCommonEntries(state.vmType, state.minitiadVersion, state.minitiadEndpoint, minitiaHome); err != nil {
+		return nil, err
+	}
+	if err := plan.addGenesisTotals(); err != nil {
+		return nil, err
+	}
+	if state.batchSubmissionIsCelestia {
+		plan.addBinary(CelestiaAppName, state.celestiaVersion, state.celestiaEndpoint, state.celestiaBinaryPath)
+		plan.Files = append(plan.Files, filepath.Dir(state.celestiaBinaryPath))
+	}
+	if state.generateKeys {
+		plan.Notes = append(plan.Notes, "The system keys above are generated for this plan only, the launch generates new ones.")
+	} else if strings.ToLower(state.vmType) == "evm" {
+		plan.Notes = append(plan.Notes, "The system key addresses above are derived with secp256k1. minievm derives its keys with eth_secp256k1, so the addresses of the launch may differ.")
+	}
+
+	gasStationMnemonic := config.GetGasStationMnemonic()
+	initiaGasStationAddress, err := crypto.MnemonicToBech32Address("init", gasStationMnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("cannot recover gas station for init: %v", err)
+	}
+
+	type transfer struct{ role, address, amount string }
+	l1Transfers := []transfer{
+		{"Bridge Executor", state.systemKeyBridgeExecutorAddress, state.systemKeyL1BridgeExecutorBalance},
+		{"Output Submitter", state.systemKeyOutputSubmitterAddress, state.systemKeyL1OutputSubmitterBalance},
+	}
+	if !state.batchSubmissionIsCelestia {
+		l1Transfers = append(l1Transfers, transfer{"Batch Submitter", state.systemKeyBatchSubmitterAddress, state.systemKeyL1BatchSubmitterBalance})
+	}
+	l1Transfers = append(l1Transfers, transfer{"Challenger", state.systemKeyChallengerAddress, state.systemKeyL1ChallengerBalance})
+
+	l1Fee := FundMinitiaAccountsDefaultFee
+	if state.batchSubmissionIsCelestia {
+		l1Fee = FundMinitiaAccountsWithoutBatchFee
+	}
+	l1Required := big.NewInt(l1Fee)
+	for _, transfer := range l1Transfers {
+		plan.FundingTxs = append(plan.FundingTxs, FundingTx{
+			ChainId: state.l1ChainId,
+			From:    "Gas Station",
+			To:      transfer.address,
+			Role:    transfer.role,
+			Amount:  transfer.amount + DefaultL1GasDenom,
+		})
+		amount, ok := new(big.Int).SetString(transfer.amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q for %s", transfer.amount, transfer.role)
+		}
+		l1Required.Add(l1Required, amount)
+	}
+
+	var l1Lcd string
+	l1Registry, err := registry.GetL1ChainRegistry(state.l1ChainId)
+	if err == nil {
+		l1Lcd, err = l1Registry.GetActiveLcd()
+	}
+	plan.GasStation = append(plan.GasStation, checkGasStationBalance(state.l1ChainId, l1Lcd, initiaGasStationAddress, DefaultL1GasDenom, l1Required, err))
+
+	if state.batchSubmissionIsCelestia {
+		celestiaGasStationAddress, err := crypto.MnemonicToBech32Address("celestia", gasStationMnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("cannot recover gas station for celestia: %v", err)
+		}
+		celestiaRegistry, err := registry.GetCelestiaChainRegistry(state.l1ChainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get celestia registry: %v", err)
+		}
+		plan.FundingTxs = append(plan.FundingTxs, FundingTx{
+			ChainId: celestiaRegistry.GetChainId(),
+			From:    "Gas Station",
+			To:      state.systemKeyBatchSubmitterAddress,
+			Role:    "Batch Submitter",
+			Amount:  state.systemKeyL1BatchSubmitterBalance + DefaultCelestiaGasDenom,
+		})
+		celestiaRequired, ok := new(big.Int).SetString(state.systemKeyL1BatchSubmitterBalance, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q for Batch Submitter", state.systemKeyL1BatchSubmitterBalance)
+		}
+		// the fee is simulated with celestia-appd, which a dry run does not download
+		if io.FileOrFolderExists(state.celestiaBinaryPath) {
+			celestiaFee, err := EstimateFundCelestiaBatchSubmitterFee(state.celestiaBinaryPath, celestiaRegistry, celestiaGasStationAddress, state.systemKeyBatchSubmitterAddress, state.systemKeyL1BatchSubmitterBalance)
+			if err != nil {
+				return nil, err
+			}
+			celestiaRequired.Add(celestiaRequired, celestiaFee)
+		} else {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("The Celestia gas station also pays the fee of the funding tx, which is not included above: it is simulated with %s once it is installed.", CelestiaAppName))
+		}
+		celestiaLcd, err := celestiaRegistry.GetActiveLcd()
+		plan.GasStation = append(plan.GasStation, checkGasStationBalance(celestiaRegistry.GetChainId(), celestiaLcd, celestiaGasStationAddress, DefaultCelestiaGasDenom, celestiaRequired, err))
+	}
+
+	return plan, nil
+}
+
+func checkGasStationBalance(chainId, lcd, address, denom string, required *big.Int, lcdErr error) GasStationRequirement {
+	req := GasStationRequirement{
+		ChainId:  chainId,
+		Address:  address,
+		Denom:    denom,
+		Required: required.String(),
+	}
+	if lcdErr != nil {
+		req.Error = lcdErr.Error()
+		return req
+	}
+
+	balances, err := cosmosutils.QueryBankBalances(lcd, address)
+	if err != nil {
+		req.Error = err.Error()
+		return req
+	}
+
+	available := big.NewInt(0)
+	for _, coin := range *balances {
+		if coin.Denom == denom {
+			if amount, ok := new(big.Int).SetString(coin.Amount, 10); ok {
+				available = amount
+			}
+		}
+	}
+	req.Available = available.String()
+	req.Enough = available.Cmp(required) >= 0
+	return req
+}
+
+type LaunchPlanLoading struct {
+	ui.Loading
+	weavecontext.BaseModel
+}
+
+func NewLaunchPlanLoading(ctx context.Context) *LaunchPlanLoading {
+	return &LaunchPlanLoading{
+		Loading:   ui.NewLoading("Preparing the launch plan...", buildLaunchPlan(ctx)),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}
+}
+
+func (m *LaunchPlanLoading) Init() tea.Cmd {
+	return m.Loading.Init()
+}
+
+func buildLaunchPlan(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		state := weavecontext.GetCurrentState[LaunchState](ctx)
+		minitiaHome, err := weavecontext.GetMinitiaHome(ctx)
+		if err != nil {
+			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get minitia home directory: %v", err)}
+		}
+		plan, err := BuildLaunchPlan(state, minitiaHome)
+		if err != nil {
+			return ui.NonRetryableErrorLoading{Err: err}
+		}
+		state.launchPlan = plan
+		return ui.EndLoading{Ctx: weavecontext.SetCurrentState(ctx, state)}
+	}
+}
+
+func (m *LaunchPlanLoading) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	loader, cmd := m.Loading.Update(msg)
+	m.Loading = loader
+	if m.Loading.NonRetryableErr != nil {
+		return m, m.HandlePanic(m.Loading.NonRetryableErr)
+	}
+	if m.Loading.Completing {
+		m.Ctx = m.Loading.EndContext
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		return NewLaunchPlanTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+	}
+	return m, cmd
+}
+
+func (m *LaunchPlanLoading) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + "\n" + m.Loading.View())
+}
+
+type LaunchPlanTerminalState struct {
+	weavecontext.BaseModel
+}
+
+func NewLaunchPlanTerminalState(ctx context.Context) *LaunchPlanTerminalState {
+	return &LaunchPlanTerminalState{
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}
+}
+
+func (m *LaunchPlanTerminalState) Init() tea.Cmd {
+	return nil
+}
+
+func (m *LaunchPlanTerminalState) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m *LaunchPlanTerminalState) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render()) + "\n" +
+		styles.RenderPrompt("Dry run completed. Nothing was broadcast and `minitiad launch` was not run.", []string{"`minitiad launch`"}, styles.Completed) + "\n\n" +
+		state.launchPlan.String()
+}
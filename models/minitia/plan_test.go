@@ -0,0 +1,59 @@
+package minitia
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/types"
+)
+
+func TestBuildLaunchPlanFromConfig(t *testing.T) {
+	minitiaHome := filepath.Join(t.TempDir(), ".minitia")
+	cfg := &types.MinitiaConfig{
+		L2Config: &types.L2Config{ChainID: "minimove-1", Denom: "umin"},
+		SystemKeys: &types.SystemKeys{
+			Validator: types.NewSystemAccount("secret words", "init1validator"),
+		},
+	}
+
+	plan, err := BuildLaunchPlanFromConfig("move", "v0.6.7", "https://example.com/minimove.tar.gz", minitiaHome, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, types.RedactedMnemonic, plan.Config.SystemKeys.Validator.Mnemonic)
+	assert.Equal(t, "secret words", cfg.SystemKeys.Validator.Mnemonic)
+	assert.Empty(t, plan.FundingTxs)
+	assert.Contains(t, plan.Files, filepath.Join(minitiaHome, "artifacts", "config.json"))
+	assert.Len(t, plan.Services, 1)
+	assert.Len(t, plan.Binaries, 1)
+	assert.Equal(t, "minimove", plan.Binaries[0].Name)
+	assert.Equal(t, "https://example.com/minimove.tar.gz", plan.Binaries[0].URL)
+	assert.NotContains(t, plan.String(), "secret words")
+}
+
+func TestFeeForGas(t *testing.T) {
+	fee, err := feeForGas("0.02utia", 200000)
+	assert.NoError(t, err)
+	assert.Equal(t, "4000", fee.String())
+
+	// fees are rounded up to whole utia
+	fee, err = feeForGas("0.0000123utia", 200000)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", fee.String())
+
+	_, err = feeForGas("", 200000)
+	assert.Error(t, err)
+}
+
+func TestSimulateCelestiaSendGas(t *testing.T) {
+	binaryPath := filepath.Join(t.TempDir(), "celestia-appd")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\necho 'gas estimate: 107521' >&2\n"), 0755))
+	gas, err := simulateCelestiaSendGas(binaryPath, "http://localhost:26657", "mocha-4", "celestia1from", "celestia1to", "100")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(107521), gas)
+
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\necho 'account not found' >&2\nexit 1\n"), 0755))
+	_, err = simulateCelestiaSendGas(binaryPath, "http://localhost:26657", "mocha-4", "celestia1from", "celestia1to", "100")
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package minitia
 import (
 	"fmt"
 
+	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/types"
 )
 
@@ -11,6 +12,7 @@ type LaunchState struct {
 	existingMinitiaApp bool
 	l1ChainId          string
 	l1RPC              string
+	localL1            registry.LocalL1Config
 	vmType             string
 	minitiadVersion    string
 	minitiadEndpoint   string
@@ -58,12 +60,23 @@ type LaunchState struct {
 
 	binaryPath         string
 	celestiaBinaryPath string
+	celestiaVersion    string
+	celestiaEndpoint   string
 
 	launchFromExistingConfig bool
 	existingConfigPath       string
+	existingConfig           *types.MinitiaConfig
 
 	feeWhitelistAccounts string
 	scanLink             string
+
+	dryRun     bool
+	launchPlan *LaunchPlan
+
+	templatePath        string
+	encryptTemplateKeys bool
+
+	checkpoint *LaunchCheckpoint
 }
 
 func (ls LaunchState) Clone() LaunchState {
@@ -72,6 +85,7 @@ func (ls LaunchState) Clone() LaunchState {
 		existingMinitiaApp:                ls.existingMinitiaApp,
 		l1ChainId:                         ls.l1ChainId,
 		l1RPC:                             ls.l1RPC,
+		localL1:                           ls.localL1,
 		vmType:                            ls.vmType,
 		minitiadVersion:                   ls.minitiadVersion,
 		minitiadEndpoint:                  ls.minitiadEndpoint,
@@ -111,10 +125,18 @@ func (ls LaunchState) Clone() LaunchState {
 		preL2BalancesResponsesCount:       ls.preL2BalancesResponsesCount,
 		binaryPath:                        ls.binaryPath,
 		celestiaBinaryPath:                ls.celestiaBinaryPath,
+		celestiaVersion:                   ls.celestiaVersion,
+		celestiaEndpoint:                  ls.celestiaEndpoint,
 		launchFromExistingConfig:          ls.launchFromExistingConfig,
 		existingConfigPath:                ls.existingConfigPath,
+		existingConfig:                    ls.existingConfig.Clone(),
 		feeWhitelistAccounts:              ls.feeWhitelistAccounts,
 		scanLink:                          ls.scanLink,
+		dryRun:                            ls.dryRun,
+		launchPlan:                        ls.launchPlan,
+		templatePath:                      ls.templatePath,
+		encryptTemplateKeys:               ls.encryptTemplateKeys,
+		checkpoint:                        ls.checkpoint,
 	}
 
 	copy(clone.genesisAccounts, ls.genesisAccounts)
@@ -128,6 +150,11 @@ func NewLaunchState() *LaunchState {
 	}
 }
 
+// EnableDryRun makes the launch flow stop before broadcasting anything and show the launch plan instead
+func (ls *LaunchState) EnableDryRun() {
+	ls.dryRun = true
+}
+
 func (ls *LaunchState) FillDefaultBalances() {
 	ls.systemKeyL1BridgeExecutorBalance = DefaultL1BridgeExecutorBalance
 	ls.systemKeyL1OutputSubmitterBalance = DefaultL1OutputSubmitterBalance
@@ -136,6 +163,40 @@ func (ls *LaunchState) FillDefaultBalances() {
 	ls.systemKeyL2BridgeExecutorBalance = fmt.Sprintf("%s%s", DefaultL2BridgeExecutorBalance, ls.gasDenom)
 }
 
+// FillEstimatedBalances funds the L1 and DA system keys for the given number of days of their estimated spending
+func (ls *LaunchState) FillEstimatedBalances(estimates []FundingEstimate, days int) {
+	ls.FillDefaultBalances()
+	for _, estimate := range estimates {
+		amount := estimate.SuggestAmount(days).String()
+		switch estimate.Role {
+		case "Bridge Executor":
+			ls.systemKeyL1BridgeExecutorBalance = amount
+		case "Output Submitter":
+			ls.systemKeyL1OutputSubmitterBalance = amount
+		case "Batch Submitter":
+			ls.systemKeyL1BatchSubmitterBalance = amount
+		case "Challenger":
+			ls.systemKeyL1ChallengerBalance = amount
+		}
+	}
+}
+
+func (ls *LaunchState) batchSubmitterDenom() string {
+	if ls.batchSubmissionIsCelestia {
+		return DefaultCelestiaGasDenom
+	}
+	return DefaultL1GasDenom
+}
+
+// celestiaNetworkName names the Celestia network the batches are submitted to, which follows the chosen L1 network
+func (ls *LaunchState) celestiaNetworkName() string {
+	chainType, err := registry.GetCelestiaChainType(ls.l1ChainId)
+	if err != nil {
+		return "Celestia"
+	}
+	return chainType.String()
+}
+
 func (ls *LaunchState) FinalizeGenesisAccounts() {
 	emptyCoins := fmt.Sprintf("0%s", ls.gasDenom)
 	accounts := []types.GenesisAccount{
@@ -155,6 +216,17 @@ func (ls *LaunchState) FinalizeGenesisAccounts() {
 	ls.genesisAccounts = append(ls.genesisAccounts, accounts...)
 }
 
+// ImportGenesisAccounts adds accounts to the extra genesis accounts, merging the balances of addresses that are
+// already present
+func (ls *LaunchState) ImportGenesisAccounts(accounts types.GenesisAccounts) error {
+	merged, err := MergeGenesisAccounts(append(ls.genesisAccounts, accounts...))
+	if err != nil {
+		return err
+	}
+	ls.genesisAccounts = merged
+	return nil
+}
+
 func (ls *LaunchState) PrepareLaunchingWithConfig(vm, minitiadVersion, minitiadEndpoint, configPath string, config *types.MinitiaConfig) {
 	vmType, err := ParseVMType(vm)
 	if err != nil {
@@ -165,6 +237,62 @@ func (ls *LaunchState) PrepareLaunchingWithConfig(vm, minitiadVersion, minitiadE
 	ls.minitiadEndpoint = minitiadEndpoint
 	ls.launchFromExistingConfig = true
 	ls.existingConfigPath = configPath
+	ls.existingConfig = config
 	ls.chainId = config.L2Config.ChainID
 	ls.gasDenom = config.L2Config.Denom
 }
+
+// launchConfig returns the config `minitiad launch --with-config` runs with, the given one or the one built from the
+// collected answers
+func (ls *LaunchState) launchConfig() *types.MinitiaConfig {
+	if ls.launchFromExistingConfig {
+		return ls.existingConfig
+	}
+	return ls.BuildMinitiaConfig()
+}
+
+// BuildMinitiaConfig assembles the config passed to `minitiad launch --with-config` from the collected answers
+func (ls *LaunchState) BuildMinitiaConfig() *types.MinitiaConfig {
+	return &types.MinitiaConfig{
+		L1Config: &types.L1Config{
+			ChainID:   ls.l1ChainId,
+			RpcUrl:    ls.l1RPC,
+			GasPrices: DefaultL1GasPrices,
+		},
+		L2Config: &types.L2Config{
+			ChainID: ls.chainId,
+			Denom:   ls.gasDenom,
+			Moniker: ls.moniker,
+		},
+		OpBridge: &types.OpBridge{
+			OutputSubmissionInterval:    ls.opBridgeSubmissionInterval,
+			OutputFinalizationPeriod:    ls.opBridgeOutputFinalizationPeriod,
+			OutputSubmissionStartHeight: 1,
+			BatchSubmissionTarget:       ls.opBridgeBatchSubmissionTarget,
+			EnableOracle:                ls.enableOracle,
+		},
+		SystemKeys: &types.SystemKeys{
+			Validator: types.NewSystemAccount(
+				ls.systemKeyOperatorMnemonic,
+				ls.systemKeyOperatorAddress,
+			),
+			BridgeExecutor: types.NewSystemAccount(
+				ls.systemKeyBridgeExecutorMnemonic,
+				ls.systemKeyBridgeExecutorAddress,
+			),
+			OutputSubmitter: types.NewSystemAccount(
+				ls.systemKeyOutputSubmitterMnemonic,
+				ls.systemKeyOutputSubmitterAddress,
+			),
+			BatchSubmitter: types.NewBatchSubmitterAccount(
+				ls.systemKeyBatchSubmitterMnemonic,
+				ls.systemKeyBatchSubmitterAddress,
+			),
+			Challenger: types.NewSystemAccount(
+				ls.systemKeyChallengerMnemonic,
+				ls.systemKeyChallengerAddress,
+			),
+		},
+		GenesisAccounts: &ls.genesisAccounts,
+	}
+}
@@ -0,0 +1,280 @@
+package minitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/initia-labs/weave/common"
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/types"
+	"github.com/initia-labs/weave/ui"
+)
+
+const DefaultLaunchTemplateFilename string = "minitia.template.json"
+
+// LaunchTemplateKeyFilePath returns the path of the encrypted key file stored next to a launch template
+func LaunchTemplateKeyFilePath(templatePath string) string {
+	return strings.TrimSuffix(templatePath, filepath.Ext(templatePath)) + ".keys.json"
+}
+
+// SaveLaunchTemplate writes config to templatePath with every mnemonic redacted, so it can be reviewed and fed back
+// into `weave rollup launch --with-config`. When passphrase is set, the mnemonics are encrypted into a key file next
+// to the template and its path is returned.
+func SaveLaunchTemplate(config *types.MinitiaConfig, templatePath, passphrase string) (string, error) {
+	templateBz, err := json.MarshalIndent(config.Redacted(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal template: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(templatePath), os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create template directory: %v", err)
+	}
+	if err = os.WriteFile(templatePath, templateBz, 0644); err != nil {
+		return "", fmt.Errorf("failed to write template: %v", err)
+	}
+
+	if passphrase == "" {
+		return "", nil
+	}
+
+	mnemonicsBz, err := json.Marshal(config.SystemKeys.Mnemonics())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mnemonics: %v", err)
+	}
+	encrypted, err := crypto.EncryptWithPassphrase(mnemonicsBz, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt mnemonics: %v", err)
+	}
+	keyFilePath := LaunchTemplateKeyFilePath(templatePath)
+	if err = io.WriteSecretFile(keyFilePath, encrypted); err != nil {
+		return "", fmt.Errorf("failed to write key file: %v", err)
+	}
+	return keyFilePath, nil
+}
+
+// LoadLaunchTemplateKeys decrypts a key file written by SaveLaunchTemplate and fills the redacted mnemonics of config
+func LoadLaunchTemplateKeys(config *types.MinitiaConfig, keyFilePath, passphrase string) error {
+	encrypted, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %v", err)
+	}
+	mnemonicsBz, err := crypto.DecryptWithPassphrase(encrypted, passphrase)
+	if err != nil {
+		return err
+	}
+	var mnemonics map[string]string
+	if err = json.Unmarshal(mnemonicsBz, &mnemonics); err != nil {
+		return fmt.Errorf("failed to parse key file: %v", err)
+	}
+	return config.SystemKeys.RestoreMnemonics(mnemonics)
+}
+
+// saveLaunchTemplate writes the template of the launched rollup and records the written files in the state
+func saveLaunchTemplate(state *LaunchState, passphrase string) error {
+	keyFilePath, err := SaveLaunchTemplate(state.BuildMinitiaConfig(), state.templatePath, passphrase)
+	if err != nil {
+		return err
+	}
+
+	files := []string{state.templatePath}
+	text := fmt.Sprintf("Rollup config template has been saved to %s", state.templatePath)
+	if keyFilePath != "" {
+		files = append(files, keyFilePath)
+		text += fmt.Sprintf(" with the encrypted mnemonics in %s", keyFilePath)
+	}
+	state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, text, files, ""))
+	return nil
+}
+
+type SaveTemplateSelect struct {
+	ui.Selector[SaveTemplateOption]
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+type SaveTemplateOption string
+
+const (
+	SaveTemplateNo        SaveTemplateOption = "No"
+	SaveTemplateRedacted  SaveTemplateOption = "Yes, with the mnemonics redacted"
+	SaveTemplateEncrypted SaveTemplateOption = "Yes, with the mnemonics in a passphrase encrypted key file"
+)
+
+func NewSaveTemplateSelect(ctx context.Context) *SaveTemplateSelect {
+	return &SaveTemplateSelect{
+		Selector: ui.Selector[SaveTemplateOption]{
+			Options: []SaveTemplateOption{
+				SaveTemplateNo,
+				SaveTemplateRedacted,
+				SaveTemplateEncrypted,
+			},
+			CannotBack: true,
+		},
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+		question:   "Would you like to save this rollup config as a template for `weave rollup launch --with-config`?",
+		highlights: []string{"template", "weave rollup launch --with-config"},
+	}
+}
+
+func (m *SaveTemplateSelect) GetQuestion() string {
+	return m.question
+}
+
+func (m *SaveTemplateSelect) Init() tea.Cmd {
+	return nil
+}
+
+func (m *SaveTemplateSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), m.highlights, string(*selected)))
+		switch *selected {
+		case SaveTemplateNo:
+			return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+		case SaveTemplateEncrypted:
+			state.encryptTemplateKeys = true
+		}
+		return NewTemplatePathInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+
+	return m, cmd
+}
+
+func (m *SaveTemplateSelect) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(
+		m.GetQuestion(),
+		m.highlights,
+		styles.Question,
+	) + m.Selector.View())
+}
+
+type TemplatePathInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+func NewTemplatePathInput(ctx context.Context) *TemplatePathInput {
+	model := &TemplatePathInput{
+		TextInput:  ui.NewTextInput(false),
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		question:   "Specify the path to save the template",
+		highlights: []string{"template"},
+	}
+	model.WithPlaceholder(fmt.Sprintf(`Press tab to use "%s"`, DefaultLaunchTemplateFilename))
+	model.WithDefaultValue(DefaultLaunchTemplateFilename)
+	model.WithValidatorFn(common.ValidateNonEmptyAndLengthString("Template path", 4096))
+	return model
+}
+
+func (m *TemplatePathInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *TemplatePathInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *TemplatePathInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		templatePath, err := filepath.Abs(input.Text)
+		if err != nil {
+			return m, m.HandlePanic(fmt.Errorf("failed to resolve template path: %v", err))
+		}
+		state.templatePath = templatePath
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, templatePath))
+
+		if state.encryptTemplateKeys {
+			return NewTemplatePassphraseInput(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		}
+		if err = saveLaunchTemplate(&state, ""); err != nil {
+			return m, m.HandlePanic(err)
+		}
+		return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *TemplatePathInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
+
+type TemplatePassphraseInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question   string
+	highlights []string
+}
+
+func NewTemplatePassphraseInput(ctx context.Context) *TemplatePassphraseInput {
+	model := &TemplatePassphraseInput{
+		TextInput:  ui.NewTextInput(false),
+		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
+		question:   "Specify the passphrase to encrypt the key file",
+		highlights: []string{"passphrase"},
+	}
+	model.WithPlaceholder(fmt.Sprintf("At least %d characters", crypto.MinPassphraseLength))
+	model.WithHidden()
+	model.WithValidatorFn(func(s string) error {
+		if len(s) < crypto.MinPassphraseLength {
+			return fmt.Errorf("passphrase must be at least %d characters", crypto.MinPassphraseLength)
+		}
+		return nil
+	})
+	return model
+}
+
+func (m *TemplatePassphraseInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *TemplatePassphraseInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *TemplatePassphraseInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[LaunchState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[LaunchState](m)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), m.highlights, ui.HiddenTextMask))
+		if err := saveLaunchTemplate(&state, input.Text); err != nil {
+			return m, m.HandlePanic(err)
+		}
+		return NewTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *TemplatePassphraseInput) View() string {
+	state := weavecontext.GetCurrentState[LaunchState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), m.highlights, styles.Question) + m.TextInput.View())
+}
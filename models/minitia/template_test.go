@@ -0,0 +1,56 @@
+package minitia
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/types"
+)
+
+func TestSaveLaunchTemplate(t *testing.T) {
+	cfg := newValidMinitiaConfig(t)
+	templatePath := filepath.Join(t.TempDir(), "staging.json")
+
+	keyFilePath, err := SaveLaunchTemplate(cfg, templatePath, "correct horse")
+	assert.NoError(t, err)
+	assert.Equal(t, LaunchTemplateKeyFilePath(templatePath), keyFilePath)
+
+	keyFileBz, err := os.ReadFile(keyFilePath)
+	assert.NoError(t, err)
+	assert.True(t, crypto.IsEncryptedData(keyFileBz))
+	assert.NotContains(t, string(keyFileBz), cfg.SystemKeys.Validator.Mnemonic)
+
+	info, err := os.Stat(keyFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	templateBz, err := os.ReadFile(templatePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(templateBz), cfg.SystemKeys.Validator.Mnemonic)
+
+	var template types.MinitiaConfig
+	assert.NoError(t, json.Unmarshal(templateBz, &template))
+	assert.Equal(t, types.RedactedMnemonic, template.SystemKeys.Challenger.Mnemonic)
+	assert.NotEmpty(t, ValidateMinitiaConfig("move", &template))
+
+	assert.Error(t, LoadLaunchTemplateKeys(&template, keyFilePath, "wrong horse"))
+	assert.NoError(t, LoadLaunchTemplateKeys(&template, keyFilePath, "correct horse"))
+	assert.Equal(t, cfg, &template)
+	assert.Empty(t, ValidateMinitiaConfig("move", &template))
+}
+
+func TestSaveLaunchTemplateRedactedOnly(t *testing.T) {
+	cfg := newValidMinitiaConfig(t)
+	templatePath := filepath.Join(t.TempDir(), "templates", "prod.json")
+
+	keyFilePath, err := SaveLaunchTemplate(cfg, templatePath, "")
+	assert.NoError(t, err)
+	assert.Empty(t, keyFilePath)
+	assert.NoFileExists(t, LaunchTemplateKeyFilePath(templatePath))
+	assert.NotEqual(t, types.RedactedMnemonic, cfg.SystemKeys.Validator.Mnemonic)
+}
@@ -3,9 +3,13 @@ package minitia
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/initia-labs/weave/common"
@@ -65,53 +69,17 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 			lsk.Challenger.Address,
 			lsk.Challenger.Coins,
 		)
-		_, err = cosmosutils.RecoverKeyFromMnemonic(state.celestiaBinaryPath, common.WeaveGasStationKeyName, gasStationMnemonic)
-		if err != nil {
-			return nil, fmt.Errorf("failed to recover celestia gas station key: %v", err)
-		}
-		defer func() {
-			_ = cosmosutils.DeleteKey(state.celestiaBinaryPath, common.WeaveGasStationKeyName)
-		}()
-
-		// TODO: Choose DA layer based on the chosen L1 network
-		celestiaRegistry, err := registry.GetChainRegistry(registry.CelestiaTestnet)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get celestia registry: %v", err)
-		}
-
-		celestiaRpc, err := celestiaRegistry.GetActiveRpc()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get active rpc for celestia: %v", err)
-		}
-
-		//celestiaMinGasPrice, err := celestiaRegistry.GetMinGasPriceByDenom(DefaultCelestiaGasDenom)
-		//if err != nil {
-		//	return nil, fmt.Errorf("failed to get celestia minimum gas price: %v", err)
-		//}
-
-		celestiaChainId := celestiaRegistry.GetChainId()
-		sendCmd := exec.Command(state.celestiaBinaryPath, "tx", "bank", "send", common.WeaveGasStationKeyName,
-			lsk.BatchSubmitter.Address, fmt.Sprintf("%sutia", lsk.BatchSubmitter.Coins), "--node", celestiaRpc,
-			"--chain-id", celestiaChainId, "--gas", "200000", "--gas-prices", "0.1utia", "--output", "json", "-y",
-		)
-		broadcastRes, err := sendCmd.CombinedOutput()
-		if err != nil {
-			return nil, fmt.Errorf("failed to broadcast transaction: %v", err)
-		}
-
-		var txResponse cosmosutils.InitiadTxResponse
-		err = json.Unmarshal(broadcastRes, &txResponse)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
-		}
-		if txResponse.Code != 0 {
-			return nil, fmt.Errorf("celestia tx failed with error: %v", txResponse.RawLog)
-		}
-		err = lsk.waitForTransactionInclusion(state.celestiaBinaryPath, celestiaRpc, txResponse.TxHash)
-		if err != nil {
-			return nil, err
+		// a resumed launch skips the funding that is already confirmed on chain
+		if state.systemKeyCelestiaFundingTxHash == "" {
+			txResponse, err := lsk.fundBatchSubmitterOnCelestia(state)
+			if err != nil {
+				return nil, err
+			}
+			resp.CelestiaTx = txResponse
+			if err = state.completeCheckpointStep(LaunchStepCelestiaFunded, ""); err != nil {
+				return nil, err
+			}
 		}
-		resp.CelestiaTx = &txResponse
 	} else {
 		rawTxContent = fmt.Sprintf(
 			FundMinitiaAccountsDefaultTxInterface,
@@ -127,6 +95,10 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 		)
 	}
 
+	if state.systemKeyL1FundingTxHash != "" {
+		return &resp, nil
+	}
+
 	userHome, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user home: %v", err)
@@ -141,8 +113,14 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 		}
 	}()
 
-	signCmd := exec.Command(state.binaryPath, "tx", "sign", rawTxPath, "--from", common.WeaveGasStationKeyName, "--node", state.l1RPC,
-		"--chain-id", state.l1ChainId, "--keyring-backend", "test", "--output-document", rawTxPath)
+	keyring := cosmosutils.DefaultKeyring()
+	input, err := keyring.Input(state.binaryPath)
+	if err != nil {
+		return nil, err
+	}
+	signCmd := exec.Command(state.binaryPath, append([]string{"tx", "sign", rawTxPath, "--from", common.WeaveGasStationKeyName,
+		"--node", state.l1RPC, "--chain-id", state.l1ChainId, "--output-document", rawTxPath}, keyring.Args()...)...)
+	signCmd.Stdin = strings.NewReader(input)
 	err = signCmd.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %v", err)
@@ -162,6 +140,10 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 	if txResponse.Code != 0 {
 		return nil, fmt.Errorf("initia l1 tx failed with error: %v", txResponse.RawLog)
 	}
+	state.systemKeyL1FundingTxHash = txResponse.TxHash
+	if err = state.recordFundingTxHashes(); err != nil {
+		return nil, err
+	}
 
 	err = lsk.waitForTransactionInclusion(state.binaryPath, state.l1RPC, txResponse.TxHash)
 	if err != nil {
@@ -172,8 +154,125 @@ func (lsk *L1SystemKeys) FundAccountsWithGasStation(state *LaunchState) (*FundAc
 	return &resp, nil
 }
 
+// fundBatchSubmitterOnCelestia sends the batch submitter its funds from the gas station on the Celestia network
+// paired with the L1
+func (lsk *L1SystemKeys) fundBatchSubmitterOnCelestia(state *LaunchState) (*cosmosutils.InitiadTxResponse, error) {
+	return FundOnCelestia(state.celestiaBinaryPath, state.l1ChainId, lsk.BatchSubmitter.Address, lsk.BatchSubmitter.Coins, func(txHash string) error {
+		// the tx may still be included after a failed wait, a resumed launch then checks the balance instead of sending again
+		state.systemKeyCelestiaFundingTxHash = txHash
+		return state.recordFundingTxHashes()
+	})
+}
+
+// FundOnCelestia sends amount utia from the gas station to address on the Celestia network paired with the L1.
+// broadcast is called with the hash of the tx once it is accepted, before waiting for its inclusion.
+func FundOnCelestia(celestiaBinaryPath, l1ChainId, address, amount string, broadcast func(txHash string) error) (*cosmosutils.InitiadTxResponse, error) {
+	gasStationMnemonic := config.GetGasStationMnemonic()
+	if gasStationMnemonic == "" {
+		return nil, fmt.Errorf("no gas station is set up, run `weave gas-station setup` first")
+	}
+	_, err := cosmosutils.RecoverKeyFromMnemonic(celestiaBinaryPath, common.WeaveGasStationKeyName, gasStationMnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover celestia gas station key: %v", err)
+	}
+	defer func() {
+		_ = cosmosutils.DeleteKey(celestiaBinaryPath, common.WeaveGasStationKeyName)
+	}()
+
+	celestiaRegistry, err := registry.GetCelestiaChainRegistry(l1ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get celestia registry: %v", err)
+	}
+
+	celestiaRpc, err := celestiaRegistry.GetActiveRpc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active rpc for celestia: %v", err)
+	}
+
+	celestiaGasPrices, err := celestiaRegistry.GetGasPriceByDenom(DefaultCelestiaGasDenom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get celestia gas price: %v", err)
+	}
+
+	keyring := cosmosutils.DefaultKeyring()
+	input, err := keyring.Input(celestiaBinaryPath)
+	if err != nil {
+		return nil, err
+	}
+	celestiaChainId := celestiaRegistry.GetChainId()
+	sendCmd := exec.Command(celestiaBinaryPath, append([]string{"tx", "bank", "send", common.WeaveGasStationKeyName,
+		address, fmt.Sprintf("%s%s", amount, DefaultCelestiaGasDenom), "--node", celestiaRpc,
+		"--chain-id", celestiaChainId, "--gas", "auto", "--gas-adjustment", cosmosutils.DefaultGasAdjustment,
+		"--gas-prices", celestiaGasPrices, "--output", "json", "-y",
+	}, keyring.Args()...)...)
+	sendCmd.Stdin = strings.NewReader(input)
+	broadcastRes, err := sendCmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %v", err)
+	}
+
+	var txResponse cosmosutils.InitiadTxResponse
+	err = json.Unmarshal(broadcastRes, &txResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+	if txResponse.Code != 0 {
+		return nil, fmt.Errorf("celestia tx failed with error: %v", txResponse.RawLog)
+	}
+	if err = broadcast(txResponse.TxHash); err != nil {
+		return nil, err
+	}
+	err = waitForTransactionInclusion(celestiaBinaryPath, celestiaRpc, txResponse.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	return &txResponse, nil
+}
+
+// FundedOnL1 reports whether the system keys funded on the L1 hold at least their launch amounts, the batch submitter
+// included unless it is funded on Celestia
+func (lsk *L1SystemKeys) FundedOnL1(binaryPath, rpc string, withBatchSubmitter bool) (bool, error) {
+	accounts := []*types.GenesisAccount{lsk.BridgeExecutor, lsk.OutputSubmitter, lsk.Challenger}
+	if withBatchSubmitter {
+		accounts = append(accounts, lsk.BatchSubmitter)
+	}
+	return holdAtLeast(cosmosutils.NewInitiadQuerierFromBinary(binaryPath), rpc, DefaultL1GasDenom, accounts)
+}
+
+// FundedOnCelestia reports whether the batch submitter holds at least its launch amount on Celestia
+func (lsk *L1SystemKeys) FundedOnCelestia(celestiaBinaryPath, rpc string) (bool, error) {
+	return holdAtLeast(cosmosutils.NewInitiadQuerierFromBinary(celestiaBinaryPath), rpc, DefaultCelestiaGasDenom, []*types.GenesisAccount{lsk.BatchSubmitter})
+}
+
+func holdAtLeast(querier *cosmosutils.InitiadQuerier, rpc, denom string, accounts []*types.GenesisAccount) (bool, error) {
+	for _, account := range accounts {
+		want, ok := new(big.Int).SetString(account.Coins, 10)
+		if !ok {
+			return false, fmt.Errorf("invalid amount %q for %s", account.Coins, account.Address)
+		}
+		balances, err := querier.QueryBankBalances(account.Address, rpc)
+		if err != nil {
+			return false, err
+		}
+		held := big.NewInt(0)
+		for _, coin := range *balances {
+			if amount, ok := new(big.Int).SetString(coin.Amount, 10); ok && coin.Denom == denom {
+				held = amount
+			}
+		}
+		if held.Cmp(want) < 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // waitForTransactionInclusion polls for the transaction inclusion in a block
 func (lsk *L1SystemKeys) waitForTransactionInclusion(binaryPath, rpcURL, txHash string) error {
+	return waitForTransactionInclusion(binaryPath, rpcURL, txHash)
+}
+
+func waitForTransactionInclusion(binaryPath, rpcURL, txHash string) error {
 	// Poll for transaction status until it's included in a block
 	timeout := time.After(15 * time.Second)   // Example timeout for polling
 	ticker := time.NewTicker(3 * time.Second) // Poll every 3 seconds
@@ -211,6 +310,69 @@ func (lsk *L1SystemKeys) waitForTransactionInclusion(binaryPath, rpcURL, txHash
 	}
 }
 
+const (
+	// FundMinitiaAccountsDefaultFee is the uinit fee set in FundMinitiaAccountsDefaultTxInterface
+	FundMinitiaAccountsDefaultFee int64 = 12000
+	// FundMinitiaAccountsWithoutBatchFee is the uinit fee set in FundMinitiaAccountsWithoutBatchTxInterface
+	FundMinitiaAccountsWithoutBatchFee int64 = 10500
+)
+
+// EstimateFundCelestiaBatchSubmitterFee returns the utia fee of sending amount utia from the gas station at from to
+// the batch submitter at to on the given Celestia network. The gas is simulated the way FundOnCelestia sets it and
+// priced at the registry gas price, rounded up.
+func EstimateFundCelestiaBatchSubmitterFee(celestiaBinaryPath string, celestiaRegistry *registry.ChainRegistry, from, to, amount string) (*big.Int, error) {
+	gasPrices, err := celestiaRegistry.GetGasPriceByDenom(DefaultCelestiaGasDenom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get celestia gas price: %v", err)
+	}
+	celestiaRpc, err := celestiaRegistry.GetActiveRpc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active rpc for celestia: %v", err)
+	}
+	gas, err := simulateCelestiaSendGas(celestiaBinaryPath, celestiaRpc, celestiaRegistry.GetChainId(), from, to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return feeForGas(gasPrices, gas)
+}
+
+var gasEstimateRegex = regexp.MustCompile(`gas estimate: (\d+)`)
+
+// simulateCelestiaSendGas returns the gas of a bank send on Celestia as simulated by celestia-appd and raised by
+// cosmosutils.DefaultGasAdjustment
+func simulateCelestiaSendGas(celestiaBinaryPath, rpc, chainId, from, to, amount string) (int64, error) {
+	simulateCmd := exec.Command(celestiaBinaryPath, "tx", "bank", "send", from, to,
+		fmt.Sprintf("%s%s", amount, DefaultCelestiaGasDenom), "--node", rpc, "--chain-id", chainId,
+		"--gas", "auto", "--gas-adjustment", cosmosutils.DefaultGasAdjustment, "--dry-run")
+	output, err := simulateCmd.CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate celestia tx: %v, output: %s", err, string(output))
+	}
+	match := gasEstimateRegex.FindStringSubmatch(string(output))
+	if match == nil {
+		return 0, fmt.Errorf("failed to find the gas estimate in: %s", string(output))
+	}
+	return strconv.ParseInt(match[1], 10, 64)
+}
+
+// feeForGas returns the fee of gas at gasPrices, rounded up
+func feeForGas(gasPrices string, gas int64) (*big.Int, error) {
+	amount, _, err := common.ParseDecCoin(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse celestia gas price: %v", err)
+	}
+	gasPrice, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("invalid celestia gas price: %s", gasPrices)
+	}
+	fee := new(big.Rat).Mul(gasPrice, new(big.Rat).SetInt64(gas))
+	quo, rem := new(big.Int).QuoRem(fee.Num(), fee.Denom(), new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo, nil
+}
+
 const FundMinitiaAccountsDefaultTxInterface = `
 {
   "body":{
@@ -0,0 +1,357 @@
+package minitia
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+const (
+	// DefaultUpgradeTimeout is how long an upgraded node gets to produce a new block before the upgrade is rolled back
+	DefaultUpgradeTimeout = 2 * time.Minute
+
+	upgradePollInterval = 2 * time.Second
+)
+
+var reMinitiaServiceBinary = regexp.MustCompile(`mini(move|wasm|evm)@(v?[0-9][^/\s<"]*)`)
+
+// DetectRollupService returns the VM and minitiad version the rollup service currently runs, read from the binary
+// path baked into its systemd unit or launchd plist
+func DetectRollupService() (vm, version string, err error) {
+	serviceFilePath, err := service.GetServiceFilePath(service.Minitia)
+	if err != nil {
+		return "", "", err
+	}
+	content, err := os.ReadFile(serviceFilePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the rollup service at %s, launch or adopt a rollup first: %v", serviceFilePath, err)
+	}
+
+	vm, version = parseMinitiaServiceBinary(string(content))
+	if vm == "" {
+		return "", "", fmt.Errorf("the rollup service at %s does not run a weave-managed minitiad binary", serviceFilePath)
+	}
+	return vm, version, nil
+}
+
+func parseMinitiaServiceBinary(content string) (vm, version string) {
+	matches := reMinitiaServiceBinary.FindStringSubmatch(content)
+	if matches == nil {
+		return "", ""
+	}
+	return matches[1], matches[2]
+}
+
+// UpgradeRollup moves the rollup service of minitiaHome from mini<vm>@fromVersion to toVersion. The new binary is
+// installed and checked first, then the service is stopped, the home and its data are backed up, the service is
+// recreated with the new binary and started again. If the node does not produce a new block within timeout, the
+// previous home, data and service are restored, unless the new version already wrote the store. The failed home is
+// kept for inspection.
+func UpgradeRollup(minitiaHome, vm, fromVersion, toVersion, url string, timeout time.Duration) error {
+	binaryPath, err := cosmosutils.GetMinitiaBinaryPath(vm, toVersion)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Downloading mini%s %s...\n", vm, toVersion)
+	if _, err = cosmosutils.InstallMinitiaBinary(vm, toVersion, url, binaryPath); err != nil {
+		return fmt.Errorf("failed to install minitia binary: %v", err)
+	}
+	if err = verifyMinitiaBinary(binaryPath, toVersion); err != nil {
+		return err
+	}
+
+	backup, err := newHomeBackup(minitiaHome, fromVersion)
+	if err != nil {
+		return err
+	}
+	if err = backup.checkFreeSpace(); err != nil {
+		return err
+	}
+
+	rpc, err := cosmosutils.GetNodeRPCFromConfig(minitiaHome)
+	if err != nil {
+		return err
+	}
+	startHeight, err := queryLatestHeight(rpc)
+	if err != nil {
+		startHeight = -1
+	}
+
+	srv, err := service.NewService(service.Minitia)
+	if err != nil {
+		return fmt.Errorf("failed to initialize service: %v", err)
+	}
+	fmt.Println("Stopping the service...")
+	if err = srv.Stop(); err != nil {
+		return fmt.Errorf("failed to stop service: %v", err)
+	}
+
+	// startedAt is when the new version may have started writing the store, zero until the service is started
+	var startedAt time.Time
+	// rollback brings back the previous home and service definition and starts the node again
+	rollback := func(cause error) error {
+		_ = srv.Stop()
+		if !startedAt.IsZero() {
+			// state written by the new version may already be migrated or seen by the bots, so it is not discarded
+			written, err := storeWrittenSince(backup.liveDataPath(), startedAt)
+			if err != nil {
+				return fmt.Errorf("%v (not rolled back: %v. The service is stopped, the home before the upgrade is kept at %s)", cause, err, backup.String())
+			}
+			if written {
+				return fmt.Errorf("%v (not rolled back, as mini%s %s already wrote the store. The service is stopped, the home before the upgrade is kept at %s)", cause, vm, toVersion, backup.String())
+			}
+		}
+		if backup.done {
+			failedPath, err := backup.restore(toVersion)
+			if err != nil {
+				return fmt.Errorf("%v (failed to roll back, the home before the upgrade is kept at %s: %v)", cause, backup.String(), err)
+			}
+			cause = fmt.Errorf("%v (the failed home is kept at %s)", cause, failedPath)
+		}
+		if err := srv.Create(fmt.Sprintf("mini%s@%s", vm, fromVersion), minitiaHome); err != nil {
+			return fmt.Errorf("%v (rolled back, but failed to restore the service: %v)", cause, err)
+		}
+		if err := srv.Start(); err != nil {
+			return fmt.Errorf("%v (rolled back, but failed to restart the service: %v)", cause, err)
+		}
+		return fmt.Errorf("%v (rolled back to mini%s %s)", cause, vm, fromVersion)
+	}
+
+	if err = backup.create(); err != nil {
+		return rollback(fmt.Errorf("failed to back up the rollup home: %v", err))
+	}
+
+	if err = srv.Create(fmt.Sprintf("mini%s@%s", vm, toVersion), minitiaHome); err != nil {
+		return rollback(fmt.Errorf("failed to create service: %v", err))
+	}
+	fmt.Println("Starting the service...")
+	startedAt = time.Now()
+	if err = srv.Start(); err != nil {
+		return rollback(fmt.Errorf("failed to start service: %v", err))
+	}
+
+	fmt.Printf("Waiting up to %s for the node to produce blocks...\n", timeout)
+	height, err := waitForNewBlock(rpc, startHeight, timeout, upgradePollInterval)
+	if err != nil {
+		return rollback(err)
+	}
+	fmt.Printf("The node produced block %d with mini%s %s. The previous home is kept at %s\n", height, vm, toVersion, backup.String())
+
+	return nil
+}
+
+// homeBackup is a copy of a rollup home taken before an upgrade. When the data directory was moved with
+// relocate-data, data/ is a symlink, so the data it points to is copied next to it and the backed up home links to
+// that copy.
+type homeBackup struct {
+	home       string
+	backupPath string
+	// dataTarget is where data/ points to when it is a symlink, dataBackupPath is the copy of it
+	dataTarget     string
+	dataBackupPath string
+	done           bool
+}
+
+func newHomeBackup(home, version string) (*homeBackup, error) {
+	b := &homeBackup{home: home, backupPath: fmt.Sprintf("%s.backup-%s", home, version)}
+	if io.FileOrFolderExists(b.backupPath) {
+		return nil, fmt.Errorf("%s already exists, please remove it first", b.backupPath)
+	}
+	dataPath := filepath.Join(home, "data")
+	info, err := os.Lstat(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data directory %s: %v", dataPath, err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		if b.dataTarget, err = filepath.EvalSymlinks(dataPath); err != nil {
+			return nil, fmt.Errorf("failed to resolve data directory %s: %v", dataPath, err)
+		}
+		b.dataBackupPath = fmt.Sprintf("%s.backup-%s", b.dataTarget, version)
+		if io.FileOrFolderExists(b.dataBackupPath) {
+			return nil, fmt.Errorf("%s already exists, please remove it first", b.dataBackupPath)
+		}
+	}
+	return b, nil
+}
+
+// liveDataPath is the data directory the node runs on
+func (b *homeBackup) liveDataPath() string {
+	if b.dataTarget != "" {
+		return b.dataTarget
+	}
+	return filepath.Join(b.home, "data")
+}
+
+// checkFreeSpace checks that the home, and the relocated data, fit next to themselves
+func (b *homeBackup) checkFreeSpace() error {
+	// the size of the home does not include relocated data, as symlinks are not followed
+	if err := checkCopyFits(b.home, filepath.Dir(b.backupPath)); err != nil {
+		return err
+	}
+	if b.dataTarget != "" {
+		return checkCopyFits(b.dataTarget, filepath.Dir(b.dataBackupPath))
+	}
+	return nil
+}
+
+func checkCopyFits(src, desDir string) error {
+	size, err := io.DirectorySize(src)
+	if err != nil {
+		return err
+	}
+	free, err := io.GetFreeSpace(desDir)
+	if err != nil {
+		return err
+	}
+	if uint64(size) > free {
+		return fmt.Errorf("not enough space at %s to back up %s: need %s, only %s available", desDir, src, io.FormatBytes(size), io.FormatBytes(int64(free)))
+	}
+	return nil
+}
+
+func (b *homeBackup) create() error {
+	fmt.Printf("Backing up %s to %s\n", b.home, b.backupPath)
+	if err := copyWithProgress(b.home, b.backupPath); err != nil {
+		_ = os.RemoveAll(b.backupPath)
+		return err
+	}
+	if b.dataTarget != "" {
+		fmt.Printf("Backing up %s to %s\n", b.dataTarget, b.dataBackupPath)
+		if err := copyWithProgress(b.dataTarget, b.dataBackupPath); err != nil {
+			_ = os.RemoveAll(b.backupPath)
+			_ = os.RemoveAll(b.dataBackupPath)
+			return err
+		}
+		// the backed up home must not share the live data
+		backupDataPath := filepath.Join(b.backupPath, "data")
+		if err := os.Remove(backupDataPath); err != nil {
+			return fmt.Errorf("failed to unlink %s: %v", backupDataPath, err)
+		}
+		if err := os.Symlink(b.dataBackupPath, backupDataPath); err != nil {
+			return fmt.Errorf("failed to link %s to %s: %v", backupDataPath, b.dataBackupPath, err)
+		}
+	}
+	b.done = true
+	return nil
+}
+
+func copyWithProgress(src, des string) error {
+	err := io.CopyDirectoryWithProgress(src, des, func(copied, total int64) {
+		fmt.Printf("\r%s / %s", io.FormatBytes(copied), io.FormatBytes(total))
+	})
+	fmt.Println()
+	return err
+}
+
+// restore puts the backup back in place and keeps the failed home, and its relocated data, renamed with the failed
+// version. The path of the failed home is returned.
+func (b *homeBackup) restore(failedVersion string) (string, error) {
+	failedPath := fmt.Sprintf("%s.failed-%s", b.home, failedVersion)
+	if err := os.Rename(b.home, failedPath); err != nil {
+		return "", err
+	}
+	if err := os.Rename(b.backupPath, b.home); err != nil {
+		return "", err
+	}
+	if b.dataTarget == "" {
+		return failedPath, nil
+	}
+	// the data goes back to where it was relocated, and the failed home links to the failed data
+	failedDataPath := fmt.Sprintf("%s.failed-%s", b.dataTarget, failedVersion)
+	if err := os.Rename(b.dataTarget, failedDataPath); err != nil {
+		return "", err
+	}
+	if err := os.Rename(b.dataBackupPath, b.dataTarget); err != nil {
+		return "", err
+	}
+	for _, link := range []struct{ path, target string }{
+		{filepath.Join(b.home, "data"), b.dataTarget},
+		{filepath.Join(failedPath, "data"), failedDataPath},
+	} {
+		if err := os.Remove(link.path); err != nil {
+			return "", err
+		}
+		if err := os.Symlink(link.target, link.path); err != nil {
+			return "", err
+		}
+	}
+	return failedPath, nil
+}
+
+func (b *homeBackup) String() string {
+	if b.dataTarget != "" {
+		return fmt.Sprintf("%s, with its data at %s", b.backupPath, b.dataBackupPath)
+	}
+	return b.backupPath
+}
+
+// storeWrittenSince tells whether the application store under dataPath was written since the given time. Opening a
+// store only creates empty write-ahead logs, so a non-empty log or a new table means the node wrote state.
+func storeWrittenSince(dataPath string, since time.Time) (bool, error) {
+	storePath := filepath.Join(dataPath, "application.db")
+	if !io.FileOrFolderExists(storePath) {
+		return false, nil
+	}
+	written := false
+	err := filepath.Walk(storePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || !info.ModTime().After(since) {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".ldb", ".sst":
+			written = true
+		case ".log":
+			written = written || info.Size() > 0
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect %s: %v", storePath, err)
+	}
+	return written, nil
+}
+
+// verifyMinitiaBinary runs the downloaded binary, which also loads its libraries, and checks it reports version
+func verifyMinitiaBinary(binaryPath, version string) error {
+	output, err := cosmosutils.GetBinaryVersion(binaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to run the downloaded binary: %v", err)
+	}
+	if cosmosutils.NormalizeVersion(output) != cosmosutils.NormalizeVersion(version) {
+		return fmt.Errorf("the downloaded binary reports version %s, expected %s", output, version)
+	}
+	return nil
+}
+
+func queryLatestHeight(rpc string) (int64, error) {
+	return cosmosutils.QueryLatestHeight(rpc)
+}
+
+// waitForNewBlock polls rpc until the node reports a height above startHeight. When startHeight is unknown (negative),
+// the first height reported after the restart is used instead.
+func waitForNewBlock(rpc string, startHeight int64, timeout, interval time.Duration) (int64, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		height, err := queryLatestHeight(rpc)
+		if err == nil {
+			if startHeight < 0 {
+				startHeight = height
+			} else if height > startHeight {
+				return height, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("the node did not produce a new block within %s", timeout)
+		}
+		time.Sleep(interval)
+	}
+}
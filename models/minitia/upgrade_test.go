@@ -0,0 +1,139 @@
+package minitia
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMinitiaServiceBinary(t *testing.T) {
+	unit := `
+[Service]
+ExecStart=/home/user/.weave/data/minimove@v0.6.4/minimove_v0.6.4/minitiad start --home /home/user/.minitia
+Environment="LD_LIBRARY_PATH=/home/user/.weave/data/minimove@v0.6.4/minimove_v0.6.4"
+`
+	vm, version := parseMinitiaServiceBinary(unit)
+	assert.Equal(t, "move", vm)
+	assert.Equal(t, "v0.6.4", version)
+
+	plist := `<string>/Users/user/.weave/data/miniwasm@v1.0.0-rc.1/minitiad</string>`
+	vm, version = parseMinitiaServiceBinary(plist)
+	assert.Equal(t, "wasm", vm)
+	assert.Equal(t, "v1.0.0-rc.1", version)
+
+	vm, _ = parseMinitiaServiceBinary("ExecStart=/usr/local/bin/minitiad start")
+	assert.Equal(t, "", vm)
+}
+
+func newABCIInfoServer(heights func() int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"result": {"response": {"version": "v0.6.4", "last_block_height": "%d"}}}`, heights())
+	}))
+}
+
+func TestWaitForNewBlock(t *testing.T) {
+	var height atomic.Int64
+	height.Store(10)
+	server := newABCIInfoServer(func() int64 { return height.Add(1) })
+	defer server.Close()
+
+	got, err := waitForNewBlock(server.URL, 10, time.Second, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), got)
+
+	got, err = waitForNewBlock(server.URL, -1, time.Second, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), got)
+}
+
+func TestWaitForNewBlockTimeout(t *testing.T) {
+	server := newABCIInfoServer(func() int64 { return 10 })
+	defer server.Close()
+
+	_, err := waitForNewBlock(server.URL, 10, 50*time.Millisecond, 10*time.Millisecond)
+	assert.ErrorContains(t, err, "did not produce a new block")
+}
+
+func TestHomeBackupWithRelocatedData(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, ".minitia")
+	dataTarget := filepath.Join(root, "disk", "minitia-data")
+	assert.NoError(t, os.MkdirAll(filepath.Join(home, "config"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte("before"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dataTarget, "application.db"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataTarget, "application.db", "000001.ldb"), []byte("v0.6.4 state"), 0644))
+	assert.NoError(t, os.Symlink(dataTarget, filepath.Join(home, "data")))
+
+	backup, err := newHomeBackup(home, "v0.6.4")
+	assert.NoError(t, err)
+	assert.NoError(t, backup.checkFreeSpace())
+	assert.NoError(t, backup.create())
+
+	link, err := os.Readlink(filepath.Join(home+".backup-v0.6.4", "data"))
+	assert.NoError(t, err)
+	assert.Equal(t, dataTarget+".backup-v0.6.4", link, "the backup does not share the live data")
+
+	// the new version migrates the live data
+	assert.NoError(t, os.WriteFile(filepath.Join(dataTarget, "application.db", "000001.ldb"), []byte("v0.7.0 state"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte("after"), 0644))
+
+	failedPath, err := backup.restore("v0.7.0")
+	assert.NoError(t, err)
+	assert.Equal(t, home+".failed-v0.7.0", failedPath)
+
+	content, err := os.ReadFile(filepath.Join(home, "data", "application.db", "000001.ldb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.6.4 state", string(content))
+	link, err = os.Readlink(filepath.Join(home, "data"))
+	assert.NoError(t, err)
+	assert.Equal(t, dataTarget, link, "the data goes back to where it was relocated")
+
+	content, err = os.ReadFile(filepath.Join(failedPath, "data", "application.db", "000001.ldb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.7.0 state", string(content), "the failed home is kept for inspection")
+	content, err = os.ReadFile(filepath.Join(failedPath, "config", "app.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "after", string(content))
+
+	_, err = newHomeBackup(home, "v0.7.0")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(home+".backup-v0.7.0", os.ModePerm))
+	_, err = newHomeBackup(home, "v0.7.0")
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestStoreWrittenSince(t *testing.T) {
+	data := t.TempDir()
+	store := filepath.Join(data, "application.db")
+	assert.NoError(t, os.MkdirAll(store, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(store, "000001.ldb"), []byte("state"), 0644))
+	startedAt := time.Now().Add(time.Second)
+
+	written, err := storeWrittenSince(data, startedAt)
+	assert.NoError(t, err)
+	assert.False(t, written)
+
+	// opening the store only creates an empty log
+	later := startedAt.Add(time.Second)
+	assert.NoError(t, os.WriteFile(filepath.Join(store, "000002.log"), nil, 0644))
+	assert.NoError(t, os.Chtimes(filepath.Join(store, "000002.log"), later, later))
+	assert.NoError(t, os.WriteFile(filepath.Join(store, "LOG"), []byte("opened"), 0644))
+	assert.NoError(t, os.Chtimes(filepath.Join(store, "LOG"), later, later))
+	written, err = storeWrittenSince(data, startedAt)
+	assert.NoError(t, err)
+	assert.False(t, written)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(store, "000002.log"), []byte("commit"), 0644))
+	assert.NoError(t, os.Chtimes(filepath.Join(store, "000002.log"), later, later))
+	written, err = storeWrittenSince(data, startedAt)
+	assert.NoError(t, err)
+	assert.True(t, written)
+}
@@ -0,0 +1,234 @@
+package minitia
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/types"
+)
+
+const (
+	BatchSubmissionTargetInitia   string = "INITIA"
+	BatchSubmissionTargetCelestia string = "CELESTIA"
+)
+
+var (
+	chainIdRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	errRequired  = errors.New("is required")
+)
+
+// vmForeignDenomPrefixes lists the denom namespaces owned by other VMs, which cannot be used as the rollup gas denom
+var vmForeignDenomPrefixes = map[string][]string{
+	"evm":  {"move/", "factory/"},
+	"move": {"evm/", "factory/"},
+	"wasm": {"evm/", "move/"},
+}
+
+// ConfigFieldError is a validation failure of a single field, addressed by its JSON path
+type ConfigFieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConfigFieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+type configValidator struct {
+	errs []error
+}
+
+func (v *configValidator) add(path string, err error) {
+	if err != nil {
+		v.errs = append(v.errs, &ConfigFieldError{Path: path, Err: err})
+	}
+}
+
+// required records an error when the string field at path is empty and reports whether it is set
+func (v *configValidator) required(path, value string) bool {
+	if value == "" {
+		v.add(path, errRequired)
+		return false
+	}
+	return true
+}
+
+// ValidateMinitiaConfig checks every field of a rollup config used with `weave rollup launch --with-config`.
+// All problems are returned at once, each one prefixed with the JSON path of the offending field.
+// The vm specific rules are skipped when vm is empty.
+func ValidateMinitiaConfig(vm string, config *types.MinitiaConfig) []error {
+	v := &configValidator{}
+	if config == nil {
+		v.add("$", errors.New("config is empty"))
+		return v.errs
+	}
+
+	if config.L1Config == nil {
+		v.add("l1_config", errRequired)
+	} else {
+		validateChainId(v, "l1_config.chain_id", config.L1Config.ChainID)
+		if v.required("l1_config.rpc_url", config.L1Config.RpcUrl) {
+			v.add("l1_config.rpc_url", common.ValidateURL(config.L1Config.RpcUrl))
+		}
+		if v.required("l1_config.gas_prices", config.L1Config.GasPrices) {
+			v.add("l1_config.gas_prices", common.ValidateDecCoin(config.L1Config.GasPrices))
+		}
+	}
+
+	if config.L2Config == nil {
+		v.add("l2_config", errRequired)
+	} else {
+		validateChainId(v, "l2_config.chain_id", config.L2Config.ChainID)
+		if v.required("l2_config.denom", config.L2Config.Denom) {
+			v.add("l2_config.denom", common.ValidateDenom(config.L2Config.Denom))
+			v.add("l2_config.denom", validateVMDenom(vm, config.L2Config.Denom))
+		}
+		if v.required("l2_config.moniker", config.L2Config.Moniker) {
+			v.add("l2_config.moniker", common.ValidateNonEmptyAndLengthString("Moniker", MaxMonikerLength)(config.L2Config.Moniker))
+		}
+	}
+
+	daHrp := "init"
+	if config.OpBridge == nil {
+		v.add("op_bridge", errRequired)
+	} else {
+		validateDuration(v, "op_bridge.output_submission_interval", config.OpBridge.OutputSubmissionInterval)
+		validateDuration(v, "op_bridge.output_finalization_period", config.OpBridge.OutputFinalizationPeriod)
+		switch target := config.OpBridge.BatchSubmissionTarget; target {
+		case BatchSubmissionTargetInitia:
+		case BatchSubmissionTargetCelestia:
+			daHrp = "celestia"
+		case "":
+			v.add("op_bridge.batch_submission_target", errRequired)
+		default:
+			v.add("op_bridge.batch_submission_target", fmt.Errorf("must be %s or %s", BatchSubmissionTargetInitia, BatchSubmissionTargetCelestia))
+		}
+	}
+
+	if config.SystemKeys == nil {
+		v.add("system_keys", errRequired)
+	} else {
+		keys := config.SystemKeys
+		// minievm derives keys with eth_secp256k1 on its own HD path, so the addresses of an evm rollup config
+		// cannot be compared with the ones derived here
+		matchMnemonic := vm != "" && vm != "evm"
+		validateSystemAccount(v, "system_keys.validator", keys.Validator, "", matchMnemonic)
+		validateSystemAccount(v, "system_keys.bridge_executor", keys.BridgeExecutor, "", matchMnemonic)
+		validateSystemAccount(v, "system_keys.output_submitter", keys.OutputSubmitter, "", matchMnemonic)
+		validateSystemAccount(v, "system_keys.batch_submitter", keys.BatchSubmitter, daHrp, matchMnemonic)
+		validateSystemAccount(v, "system_keys.challenger", keys.Challenger, "", matchMnemonic)
+	}
+
+	if config.GenesisAccounts != nil {
+		seen := make(map[string]int)
+		for idx, account := range *config.GenesisAccounts {
+			path := fmt.Sprintf("genesis_accounts[%d]", idx)
+			if v.required(path+".address", account.Address) {
+				v.add(path+".address", common.IsValidAddress(account.Address))
+				if first, ok := seen[account.Address]; ok {
+					v.add(path+".address", fmt.Errorf("duplicate of genesis_accounts[%d]", first))
+				} else {
+					seen[account.Address] = idx
+				}
+			}
+			if v.required(path+".coins", account.Coins) {
+				for _, coin := range strings.Split(account.Coins, ",") {
+					v.add(path+".coins", common.ValidateDecCoin(coin))
+				}
+			}
+		}
+	}
+
+	return v.errs
+}
+
+func validateChainId(v *configValidator, path, chainId string) {
+	if !v.required(path, chainId) {
+		return
+	}
+	v.add(path, common.ValidateNonEmptyAndLengthString("Chain ID", MaxChainIDLength)(chainId))
+	if !chainIdRegex.MatchString(chainId) {
+		v.add(path, errors.New("may only contain letters, digits, '.', '_' and '-'"))
+	}
+}
+
+func validateDuration(v *configValidator, path, duration string) {
+	if !v.required(path, duration) {
+		return
+	}
+	if err := common.IsValidTimestamp(duration); err != nil {
+		v.add(path, fmt.Errorf("%v, expected a duration such as 1m or 168h", err))
+		return
+	}
+	if d, _ := time.ParseDuration(duration); d <= 0 {
+		v.add(path, errors.New("must be positive"))
+	}
+}
+
+func validateVMDenom(vm, denom string) error {
+	for _, prefix := range vmForeignDenomPrefixes[vm] {
+		if strings.HasPrefix(denom, prefix) {
+			return fmt.Errorf("denoms starting with %q cannot be used as the gas denom of a %s rollup", prefix, vm)
+		}
+	}
+	return nil
+}
+
+// validateSystemAccount checks the mnemonic and addresses of a system key. The batch submitter only carries
+// da_address, which is checked against daHrp when it is set. With matchMnemonic the addresses must also be the
+// ones derived from the mnemonic.
+func validateSystemAccount(v *configValidator, path string, account *types.SystemAccount, daHrp string, matchMnemonic bool) {
+	if account == nil {
+		v.add(path, errRequired)
+		return
+	}
+
+	if account.Mnemonic == types.RedactedMnemonic {
+		v.add(path+".mnemonic", errors.New("is redacted, please fill in the mnemonic"))
+	} else if v.required(path+".mnemonic", account.Mnemonic) {
+		v.add(path+".mnemonic", common.ValidateMnemonic(account.Mnemonic))
+	}
+	mnemonicOk := matchMnemonic && account.Mnemonic != types.RedactedMnemonic && common.ValidateMnemonic(account.Mnemonic) == nil
+
+	checkAddress := func(field, hrp, address string) {
+		if hrp == "init" {
+			if err := common.IsValidAddress(address); err != nil {
+				v.add(path+"."+field, err)
+				return
+			}
+		} else if !strings.HasPrefix(address, hrp+"1") {
+			v.add(path+"."+field, fmt.Errorf("expected a %s1 address", hrp))
+			return
+		}
+		if !mnemonicOk {
+			return
+		}
+		derived, err := crypto.MnemonicToBech32Address(hrp, account.Mnemonic)
+		if err != nil {
+			v.add(path+".mnemonic", err)
+			return
+		}
+		if derived != address {
+			v.add(path+"."+field, fmt.Errorf("does not match the mnemonic, expected %s", derived))
+		}
+	}
+
+	if daHrp != "" {
+		if v.required(path+".da_address", account.DAAddress) {
+			checkAddress("da_address", daHrp, account.DAAddress)
+		}
+		return
+	}
+
+	if v.required(path+".l1_address", account.L1Address) {
+		checkAddress("l1_address", "init", account.L1Address)
+	}
+	if v.required(path+".l2_address", account.L2Address) {
+		checkAddress("l2_address", "init", account.L2Address)
+	}
+}
@@ -0,0 +1,84 @@
+package minitia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/types"
+)
+
+func newValidMinitiaConfig(t *testing.T) *types.MinitiaConfig {
+	mnemonic, err := crypto.GenerateMnemonic()
+	assert.NoError(t, err)
+	address, err := crypto.MnemonicToBech32Address("init", mnemonic)
+	assert.NoError(t, err)
+	daAddress, err := crypto.MnemonicToBech32Address("celestia", mnemonic)
+	assert.NoError(t, err)
+
+	return &types.MinitiaConfig{
+		L1Config: &types.L1Config{ChainID: "initiation-2", RpcUrl: "https://rpc.testnet.initia.xyz", GasPrices: "0.15uinit"},
+		L2Config: &types.L2Config{ChainID: "minimove-1", Denom: "umin", Moniker: "operator"},
+		OpBridge: &types.OpBridge{
+			OutputSubmissionInterval: "1m",
+			OutputFinalizationPeriod: "168h",
+			BatchSubmissionTarget:    BatchSubmissionTargetCelestia,
+		},
+		SystemKeys: &types.SystemKeys{
+			Validator:       types.NewSystemAccount(mnemonic, address),
+			BridgeExecutor:  types.NewSystemAccount(mnemonic, address),
+			OutputSubmitter: types.NewSystemAccount(mnemonic, address),
+			BatchSubmitter:  types.NewBatchSubmitterAccount(mnemonic, daAddress),
+			Challenger:      types.NewSystemAccount(mnemonic, address),
+		},
+		GenesisAccounts: &types.GenesisAccounts{{Address: address, Coins: "1000000umin"}},
+	}
+}
+
+func errorPaths(errs []error) []string {
+	var paths []string
+	for _, err := range errs {
+		paths = append(paths, err.(*ConfigFieldError).Path)
+	}
+	return paths
+}
+
+func TestValidateMinitiaConfig(t *testing.T) {
+	cfg := newValidMinitiaConfig(t)
+	assert.Empty(t, ValidateMinitiaConfig("move", cfg))
+
+	cfg.L1Config.RpcUrl = "ftp://rpc"
+	cfg.L2Config.ChainID = "mini move"
+	cfg.L2Config.Denom = "evm/0x1"
+	cfg.OpBridge.OutputFinalizationPeriod = "7 days"
+	cfg.SystemKeys.Challenger = nil
+	cfg.SystemKeys.Validator.L2Address = "init1invalid"
+	*cfg.GenesisAccounts = append(*cfg.GenesisAccounts, (*cfg.GenesisAccounts)[0], types.GenesisAccount{Address: "init1abc", Coins: "ten"})
+
+	errs := ValidateMinitiaConfig("move", cfg)
+	assert.ElementsMatch(t, []string{
+		"l1_config.rpc_url",
+		"l2_config.chain_id",
+		"l2_config.denom",
+		"op_bridge.output_finalization_period",
+		"system_keys.validator.l2_address",
+		"system_keys.challenger",
+		"genesis_accounts[1].address",
+		"genesis_accounts[2].address",
+		"genesis_accounts[2].coins",
+	}, errorPaths(errs))
+	assert.Contains(t, errs[0].Error(), "l1_config.rpc_url: ")
+}
+
+func TestValidateMinitiaConfigBatchSubmitter(t *testing.T) {
+	cfg := newValidMinitiaConfig(t)
+	cfg.OpBridge.BatchSubmissionTarget = BatchSubmissionTargetInitia
+	assert.Equal(t, []string{"system_keys.batch_submitter.da_address"}, errorPaths(ValidateMinitiaConfig("wasm", cfg)))
+
+	cfg.SystemKeys.BatchSubmitter.Mnemonic = types.RedactedMnemonic
+	assert.Equal(t, []string{"system_keys.batch_submitter.mnemonic", "system_keys.batch_submitter.da_address"}, errorPaths(ValidateMinitiaConfig("wasm", cfg)))
+
+	assert.Equal(t, []string{"$"}, errorPaths(ValidateMinitiaConfig("wasm", nil)))
+	assert.Equal(t, []string{"l1_config", "l2_config", "op_bridge", "system_keys"}, errorPaths(ValidateMinitiaConfig("wasm", &types.MinitiaConfig{})))
+}
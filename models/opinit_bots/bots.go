@@ -116,7 +116,7 @@ func CheckIfKeysExist(botInfos []BotInfo) ([]BotInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get user home dir: %v", err)
 	}
-	binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion), AppName)
+	binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", CurrentBotVersion()), AppName)
 	cmd := exec.Command(binaryPath, "keys", "list", "weave-dummy")
 	outputBytes, err := cmd.Output()
 	if err != nil {
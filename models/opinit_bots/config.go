@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
 )
 
 type NodeConfig struct {
@@ -103,3 +104,21 @@ func GenerateMnemonicKeyfile(botName string) (KeyFile, error) {
 		return KeyFile{}, fmt.Errorf("unsupported bot name: %s", botName)
 	}
 }
+
+// ResolveSecrets replaces `env:` and `file:` references in the key file with the mnemonics they point to
+func (k *KeyFile) ResolveSecrets() error {
+	for name, mnemonic := range map[string]*string{
+		"bridge_executor":        &k.BridgeExecutor,
+		"output_submitter":       &k.OutputSubmitter,
+		"challenger":             &k.Challenger,
+		"batch_submitter":        &k.BatchSubmitter,
+		"oracle_bridge_executor": &k.OracleBridgeExecutor,
+	} {
+		resolved, err := io.ResolveSecret(*mnemonic)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		*mnemonic = resolved
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package opinit_bots
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/styles"
+)
+
+var bech32PrefixRegex = regexp.MustCompile(`^[a-z]+$`)
+
+// BotConfigField is a value of the executor or challenger config that can be read and edited by its dotted path,
+// e.g. l1_node.rpc_address
+type BotConfigField struct {
+	Path string
+	Kind reflect.Kind
+}
+
+// BotConfigChange is a field whose value changes, with both values as they are written in the config
+type BotConfigChange struct {
+	Path string
+	Old  string
+	New  string
+}
+
+// BotConfigFile is the config of an OPinit bot as written in the OPinit home. Fields weave does not know about are
+// kept as they are.
+type BotConfigFile struct {
+	Bot    string
+	Path   string
+	raw    map[string]interface{}
+	mode   os.FileMode
+	fields []BotConfigField
+}
+
+func LoadBotConfigFile(opInitHome, bot string) (*BotConfigFile, error) {
+	fields, err := BotConfigFields(bot)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(opInitHome, bot+".json")
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s, run `weave opinit init %s` first: %v", path, bot, err)
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	raw, err := decodeConfigMap(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return &BotConfigFile{Bot: bot, Path: path, raw: raw, mode: info.Mode().Perm(), fields: fields}, nil
+}
+
+// BotConfigFields lists the scalar fields of the config of the bot, in the order of the config type
+func BotConfigFields(bot string) ([]BotConfigField, error) {
+	var config interface{}
+	switch bot {
+	case "executor":
+		config = ExecutorConfig{}
+	case "challenger":
+		config = ChallengerConfig{}
+	default:
+		return nil, fmt.Errorf("unsupported bot name: %s", bot)
+	}
+	var fields []BotConfigField
+	collectConfigFields(reflect.TypeOf(config), "", &fields)
+	return fields, nil
+}
+
+func collectConfigFields(t reflect.Type, prefix string, fields *[]BotConfigField) {
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			collectConfigFields(field.Type, prefix+name+".", fields)
+			continue
+		}
+		*fields = append(*fields, BotConfigField{Path: prefix + name, Kind: field.Type.Kind()})
+	}
+}
+
+func (f *BotConfigFile) Fields() []BotConfigField {
+	return f.fields
+}
+
+func (f *BotConfigFile) field(path string) (BotConfigField, error) {
+	for _, field := range f.fields {
+		if field.Path == path {
+			return field, nil
+		}
+	}
+	return BotConfigField{}, fmt.Errorf("unknown %s config field: %s", f.Bot, path)
+}
+
+// Get returns the value at path, or the section at path as indented JSON. An empty path returns the whole config.
+func (f *BotConfigFile) Get(path string) (string, error) {
+	value, found := lookupConfigValue(f.raw, path)
+	if !found {
+		if _, err := f.field(path); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+	return formatConfigValue(value)
+}
+
+// ValidateValue checks that value can be written to the field at path
+func (f *BotConfigFile) ValidateValue(path, value string) error {
+	field, err := f.field(path)
+	if err != nil {
+		return err
+	}
+	_, err = parseConfigValue(field, value)
+	return err
+}
+
+// Changes returns what writing values, keyed by path, changes in the config
+func (f *BotConfigFile) Changes(values map[string]string) ([]BotConfigChange, error) {
+	_, changes, err := f.apply(values)
+	return changes, err
+}
+
+// Save writes values, keyed by path, to the config file. The database of the bot is left untouched.
+func (f *BotConfigFile) Save(values map[string]string) ([]BotConfigChange, error) {
+	updated, changes, err := f.apply(values)
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+	bz, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s config: %v", f.Bot, err)
+	}
+	tmpPath := f.Path + ".tmp"
+	if err = os.WriteFile(tmpPath, bz, f.mode); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %v", tmpPath, err)
+	}
+	if err = os.Rename(tmpPath, f.Path); err != nil {
+		return nil, fmt.Errorf("failed to replace %s: %v", f.Path, err)
+	}
+	f.raw = updated
+	return changes, nil
+}
+
+func (f *BotConfigFile) apply(values map[string]string) (map[string]interface{}, []BotConfigChange, error) {
+	bz, err := json.Marshal(f.raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to copy %s config: %v", f.Bot, err)
+	}
+	updated, err := decodeConfigMap(bz)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to copy %s config: %v", f.Bot, err)
+	}
+
+	var changes []BotConfigChange
+	var errs []string
+	for _, field := range f.fields {
+		value, ok := values[field.Path]
+		if !ok {
+			continue
+		}
+		parsed, err := parseConfigValue(field, value)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		old, _ := f.Get(field.Path)
+		setConfigValue(updated, field.Path, parsed)
+		if formatted, _ := formatConfigValue(parsed); formatted != old {
+			changes = append(changes, BotConfigChange{Path: field.Path, Old: old, New: formatted})
+		}
+	}
+	for path := range values {
+		if _, err := f.field(path); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, nil, fmt.Errorf("invalid %s config:\n  - %s", f.Bot, strings.Join(errs, "\n  - "))
+	}
+
+	// the updated config must still be readable as the config type of the bot
+	updatedBz, err := json.Marshal(updated)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal %s config: %v", f.Bot, err)
+	}
+	var typed interface{} = &ExecutorConfig{}
+	if f.Bot == "challenger" {
+		typed = &ChallengerConfig{}
+	}
+	if err = json.Unmarshal(updatedBz, typed); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s config: %v", f.Bot, err)
+	}
+	return updated, changes, nil
+}
+
+// RenderBotConfigChanges shows the changes as a diff of the changed lines
+func RenderBotConfigChanges(changes []BotConfigChange) string {
+	if len(changes) == 0 {
+		return "No changes\n"
+	}
+	var b strings.Builder
+	for _, change := range changes {
+		b.WriteString(styles.Text(fmt.Sprintf("- %s: %s\n", change.Path, change.Old), styles.Yellow))
+		b.WriteString(styles.Text(fmt.Sprintf("+ %s: %s\n", change.Path, change.New), styles.Green))
+	}
+	return b.String()
+}
+
+func decodeConfigMap(bz []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	var raw map[string]interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func lookupConfigValue(raw map[string]interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return raw, true
+	}
+	var current interface{} = raw
+	for _, key := range strings.Split(path, ".") {
+		section, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = section[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setConfigValue(raw map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	section := raw
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := section[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			section[key] = next
+		}
+		section = next
+	}
+	section[keys[len(keys)-1]] = value
+}
+
+func formatConfigValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		bz, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal config value: %v", err)
+		}
+		return string(bz), nil
+	}
+}
+
+// parseConfigValue converts value to the JSON type of the field and checks it
+func parseConfigValue(field BotConfigField, value string) (interface{}, error) {
+	switch field.Kind {
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false: %s", field.Path, value)
+		}
+		return parsed, nil
+	case reflect.Int:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%s must be zero or a positive integer: %s", field.Path, value)
+		}
+		if strings.HasSuffix(field.Path, ".tx_timeout") && parsed == 0 {
+			return nil, fmt.Errorf("%s must be greater than 0", field.Path)
+		}
+		return json.Number(strconv.FormatInt(parsed, 10)), nil
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%s must be a positive number: %s", field.Path, value)
+		}
+		return json.Number(strconv.FormatFloat(parsed, 'f', -1, 64)), nil
+	}
+
+	var err error
+	switch {
+	case field.Path == "server.address":
+		_, _, err = net.SplitHostPort(value)
+	case strings.HasSuffix(field.Path, ".rpc_address"):
+		err = common.ValidateURL(value)
+	case strings.HasSuffix(field.Path, ".gas_price"):
+		err = common.ValidateDecCoin(value)
+	case strings.HasSuffix(field.Path, ".bech32_prefix"):
+		if !bech32PrefixRegex.MatchString(value) {
+			err = fmt.Errorf("must be lowercase letters only")
+		}
+	case strings.HasSuffix(field.Path, ".chain_id"):
+		err = common.ValidateEmptyString(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v: %s", field.Path, err, value)
+	}
+	return value, nil
+}
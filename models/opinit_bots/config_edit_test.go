@@ -0,0 +1,115 @@
+package opinit_bots
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeExecutorConfig(t *testing.T) string {
+	home := t.TempDir()
+	config := `{
+  "version": 1,
+  "server": {"address": "localhost:3000", "allow_origins": "*"},
+  "l1_node": {"chain_id": "initiation-2", "bech32_prefix": "init", "rpc_address": "https://rpc.testnet.initia.xyz:443", "gas_price": "0.15uinit", "gas_adjustment": 1.5, "tx_timeout": 60},
+  "l2_node": {"chain_id": "minimove-1", "bech32_prefix": "init", "rpc_address": "http://localhost:26657", "gas_price": "", "gas_adjustment": 1.5, "tx_timeout": 60},
+  "max_chunks": 5000,
+  "disable_batch_submitter": false,
+  "unknown_field": {"kept": true}
+}`
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "executor.json"), []byte(config), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "executor.db"), []byte("db"), 0644))
+	return home
+}
+
+func TestBotConfigFields(t *testing.T) {
+	fields, err := BotConfigFields("executor")
+	assert.NoError(t, err)
+	assert.Contains(t, fields, BotConfigField{Path: "l1_node.rpc_address", Kind: reflect.String})
+	assert.Contains(t, fields, BotConfigField{Path: "da_node.gas_adjustment", Kind: reflect.Float64})
+	assert.Contains(t, fields, BotConfigField{Path: "max_chunks", Kind: reflect.Int})
+
+	fields, err = BotConfigFields("challenger")
+	assert.NoError(t, err)
+	assert.Contains(t, fields, BotConfigField{Path: "disable_auto_set_l1_height", Kind: reflect.Bool})
+	assert.NotContains(t, fields, BotConfigField{Path: "da_node.chain_id", Kind: reflect.String})
+
+	_, err = BotConfigFields("relayer")
+	assert.Error(t, err)
+}
+
+func TestBotConfigFileGet(t *testing.T) {
+	configFile, err := LoadBotConfigFile(writeExecutorConfig(t), "executor")
+	assert.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"l1_node.gas_price":       "0.15uinit",
+		"l1_node.gas_adjustment":  "1.5",
+		"max_chunks":              "5000",
+		"disable_batch_submitter": "false",
+		"da_node.chain_id":        "",
+	} {
+		value, err := configFile.Get(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, value, path)
+	}
+
+	section, err := configFile.Get("server")
+	assert.NoError(t, err)
+	assert.Contains(t, section, `"address": "localhost:3000"`)
+
+	_, err = configFile.Get("l1_node.unknown")
+	assert.ErrorContains(t, err, "unknown executor config field")
+}
+
+func TestBotConfigFileSave(t *testing.T) {
+	home := writeExecutorConfig(t)
+	configFile, err := LoadBotConfigFile(home, "executor")
+	assert.NoError(t, err)
+
+	_, err = configFile.Save(map[string]string{
+		"l1_node.rpc_address": "not a url",
+		"max_chunks":          "-1",
+		"l2_node.unknown":     "value",
+	})
+	assert.Error(t, err)
+	for _, expected := range []string{"l1_node.rpc_address", "max_chunks must be zero or a positive integer", "unknown executor config field: l2_node.unknown"} {
+		assert.Contains(t, err.Error(), expected)
+	}
+
+	values := map[string]string{
+		"l1_node.rpc_address": "https://rpc.initia.example",
+		"l1_node.gas_price":   "0.15uinit",
+		"max_chunks":          "100",
+		"da_node.chain_id":    "initiation-2",
+	}
+	changes, err := configFile.Changes(values)
+	assert.NoError(t, err)
+	assert.Equal(t, []BotConfigChange{
+		{Path: "l1_node.rpc_address", Old: "https://rpc.testnet.initia.xyz:443", New: "https://rpc.initia.example"},
+		{Path: "da_node.chain_id", Old: "", New: "initiation-2"},
+		{Path: "max_chunks", Old: "5000", New: "100"},
+	}, changes)
+
+	saved, err := configFile.Save(values)
+	assert.NoError(t, err)
+	assert.Equal(t, changes, saved)
+
+	info, err := os.Stat(filepath.Join(home, "executor.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	assert.FileExists(t, filepath.Join(home, "executor.db"))
+
+	bz, err := os.ReadFile(filepath.Join(home, "executor.json"))
+	assert.NoError(t, err)
+	var config ExecutorConfig
+	assert.NoError(t, json.Unmarshal(bz, &config))
+	assert.Equal(t, "https://rpc.initia.example", config.L1Node.RPCAddress)
+	assert.Equal(t, 100, config.MaxChunks)
+	assert.Equal(t, 1.5, config.L1Node.GasAdjustment)
+	assert.Contains(t, string(bz), `"kept": true`)
+}
@@ -0,0 +1,315 @@
+package opinit_bots
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	weavecontext "github.com/initia-labs/weave/context"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/ui"
+)
+
+const configEditorReview = "Review and save the changes"
+
+// NewBotConfigEditor starts the interactive edit of the config of bot in opInitHome
+func NewBotConfigEditor(opInitHome, bot string) (tea.Model, error) {
+	configFile, err := LoadBotConfigFile(opInitHome, bot)
+	if err != nil {
+		return nil, err
+	}
+	state := NewOPInitBotsState()
+	state.configFile = configFile
+	state.configValues = make(map[string]string)
+	ctx := weavecontext.NewAppContext(state)
+	ctx = weavecontext.SetOPInitHome(ctx, opInitHome)
+	return NewConfigFieldSelector(ctx), nil
+}
+
+// configValue returns the value of the field at path after the edits so far
+func (state OPInitBotsState) configValue(path string) string {
+	if value, ok := state.configValues[path]; ok {
+		return value
+	}
+	value, _ := state.configFile.Get(path)
+	return value
+}
+
+type ConfigFieldSelector struct {
+	ui.Selector[string]
+	weavecontext.BaseModel
+	question string
+	paths    []string
+}
+
+func NewConfigFieldSelector(ctx context.Context) *ConfigFieldSelector {
+	state := weavecontext.GetCurrentState[OPInitBotsState](ctx)
+	var options, paths []string
+	for _, field := range state.configFile.Fields() {
+		label := fmt.Sprintf("%s: %s", field.Path, state.configValue(field.Path))
+		if _, edited := state.configValues[field.Path]; edited {
+			label += " (edited)"
+		}
+		options = append(options, label)
+		paths = append(paths, field.Path)
+	}
+	options = append(options, configEditorReview)
+	return &ConfigFieldSelector{
+		Selector:  ui.Selector[string]{Options: options},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: len(state.configValues) == 0},
+		question:  fmt.Sprintf("Which field of the %s config would you like to edit?", state.configFile.Bot),
+		paths:     paths,
+	}
+}
+
+func (m *ConfigFieldSelector) GetQuestion() string {
+	return m.question
+}
+
+func (m *ConfigFieldSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConfigFieldSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[OPInitBotsState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[OPInitBotsState](m)
+		if m.Cursor == len(m.paths) {
+			model, err := NewConfigReviewSelector(weavecontext.SetCurrentState(m.Ctx, state))
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
+			return model, nil
+		}
+		return NewConfigValueInput(weavecontext.SetCurrentState(m.Ctx, state), m.paths[m.Cursor]), nil
+	}
+	return m, cmd
+}
+
+func (m *ConfigFieldSelector) View() string {
+	state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{state.configFile.Bot}, styles.Question) + m.Selector.View())
+}
+
+type ConfigValueInput struct {
+	ui.TextInput
+	weavecontext.BaseModel
+	question string
+	path     string
+}
+
+func NewConfigValueInput(ctx context.Context, path string) *ConfigValueInput {
+	state := weavecontext.GetCurrentState[OPInitBotsState](ctx)
+	model := &ConfigValueInput{
+		TextInput: ui.NewTextInput(false),
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  fmt.Sprintf("Specify the new value of %s", path),
+		path:      path,
+	}
+	model.WithPrefillValue(state.configValue(path))
+	model.WithValidatorFn(func(value string) error {
+		return state.configFile.ValidateValue(path, value)
+	})
+	return model
+}
+
+func (m *ConfigValueInput) GetQuestion() string {
+	return m.question
+}
+
+func (m *ConfigValueInput) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConfigValueInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[OPInitBotsState](m, msg); handled {
+		return model, cmd
+	}
+
+	input, cmd, done := m.TextInput.Update(msg)
+	if done {
+		state := weavecontext.PushPageAndGetState[OPInitBotsState](m)
+		state.configValues[m.path] = input.Text
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.DotsSeparator, m.GetQuestion(), []string{m.path}, input.Text))
+		return NewConfigFieldSelector(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	m.TextInput = input
+	return m, cmd
+}
+
+func (m *ConfigValueInput) View() string {
+	state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{m.path}, styles.Question) + m.TextInput.View())
+}
+
+type ConfigReviewOption string
+
+const (
+	ConfigReviewSave        ConfigReviewOption = "Save, keeping the database"
+	ConfigReviewKeepEditing ConfigReviewOption = "Keep editing"
+	ConfigReviewDiscard     ConfigReviewOption = "Discard the changes"
+)
+
+type ConfigReviewSelector struct {
+	ui.Selector[ConfigReviewOption]
+	weavecontext.BaseModel
+	question string
+	diff     string
+}
+
+func NewConfigReviewSelector(ctx context.Context) (*ConfigReviewSelector, error) {
+	state := weavecontext.GetCurrentState[OPInitBotsState](ctx)
+	changes, err := state.configFile.Changes(state.configValues)
+	if err != nil {
+		return nil, err
+	}
+	options := []ConfigReviewOption{ConfigReviewSave, ConfigReviewKeepEditing, ConfigReviewDiscard}
+	if len(changes) == 0 {
+		options = []ConfigReviewOption{ConfigReviewKeepEditing, ConfigReviewDiscard}
+	}
+	return &ConfigReviewSelector{
+		Selector:  ui.Selector[ConfigReviewOption]{Options: options},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx},
+		question:  fmt.Sprintf("Would you like to save the changes to %s?", state.configFile.Path),
+		diff:      RenderBotConfigChanges(changes),
+	}, nil
+}
+
+func (m *ConfigReviewSelector) GetQuestion() string {
+	return m.question
+}
+
+func (m *ConfigReviewSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConfigReviewSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[OPInitBotsState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[OPInitBotsState](m)
+		switch *selected {
+		case ConfigReviewKeepEditing:
+			return NewConfigFieldSelector(weavecontext.SetCurrentState(m.Ctx, state)), nil
+		case ConfigReviewDiscard:
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, "The changes were discarded", []string{}, ""))
+			return NewConfigEditTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+		}
+
+		changes, err := state.configFile.Save(state.configValues)
+		if err != nil {
+			return m, m.HandlePanic(err)
+		}
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, string(*selected)))
+		state.weave.PushPreviousResponse(RenderBotConfigChanges(changes))
+		return NewConfigRestartSelector(weavecontext.SetCurrentState(m.Ctx, state)), nil
+	}
+	return m, cmd
+}
+
+func (m *ConfigReviewSelector) View() string {
+	state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
+	return m.WrapView(state.weave.Render() + m.diff + "\n" + styles.RenderPrompt(m.GetQuestion(), []string{}, styles.Question) + m.Selector.View())
+}
+
+type ConfigRestartOption string
+
+const (
+	ConfigRestartNow   ConfigRestartOption = "Yes, restart it now"
+	ConfigRestartLater ConfigRestartOption = "No, I will restart it later"
+)
+
+type ConfigRestartSelector struct {
+	ui.Selector[ConfigRestartOption]
+	weavecontext.BaseModel
+	question string
+}
+
+func NewConfigRestartSelector(ctx context.Context) *ConfigRestartSelector {
+	state := weavecontext.GetCurrentState[OPInitBotsState](ctx)
+	return &ConfigRestartSelector{
+		Selector: ui.Selector[ConfigRestartOption]{
+			Options:    []ConfigRestartOption{ConfigRestartNow, ConfigRestartLater},
+			CannotBack: true,
+		},
+		BaseModel: weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+		question:  fmt.Sprintf("Would you like to restart the %s service to apply the changes?", state.configFile.Bot),
+	}
+}
+
+func (m *ConfigRestartSelector) GetQuestion() string {
+	return m.question
+}
+
+func (m *ConfigRestartSelector) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConfigRestartSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if model, cmd, handled := weavecontext.HandleCommonCommands[OPInitBotsState](m, msg); handled {
+		return model, cmd
+	}
+	selected, cmd := m.Select(msg)
+	if selected != nil {
+		state := weavecontext.PushPageAndGetState[OPInitBotsState](m)
+		state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.ArrowSeparator, m.GetQuestion(), []string{}, string(*selected)))
+		bot := state.configFile.Bot
+		if *selected == ConfigRestartNow {
+			if err := RestartBotService(bot); err != nil {
+				return m, m.HandlePanic(err)
+			}
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, fmt.Sprintf("Restarted the OPinit %[1]s bot. You can see the logs with `weave opinit log %[1]s`", bot), []string{}, ""))
+		} else {
+			state.weave.PushPreviousResponse(styles.RenderPreviousResponse(styles.NoSeparator, fmt.Sprintf("Run `weave opinit restart %s` to apply the changes", bot), []string{}, ""))
+		}
+		return NewConfigEditTerminalState(weavecontext.SetCurrentState(m.Ctx, state)), tea.Quit
+	}
+	return m, cmd
+}
+
+func (m *ConfigRestartSelector) View() string {
+	state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
+	return m.WrapView(state.weave.Render() + styles.RenderPrompt(m.GetQuestion(), []string{}, styles.Question) + m.Selector.View())
+}
+
+// RestartBotService restarts the service of the bot so that it reads its config again
+func RestartBotService(bot string) error {
+	s, err := service.NewService(service.CommandName(bot))
+	if err != nil {
+		return err
+	}
+	if err = s.Restart(); err != nil {
+		return fmt.Errorf("failed to restart the %s service: %v", bot, err)
+	}
+	return nil
+}
+
+type ConfigEditTerminalState struct {
+	weavecontext.BaseModel
+}
+
+func NewConfigEditTerminalState(ctx context.Context) *ConfigEditTerminalState {
+	return &ConfigEditTerminalState{
+		weavecontext.BaseModel{Ctx: ctx, CannotBack: true},
+	}
+}
+
+func (m *ConfigEditTerminalState) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ConfigEditTerminalState) Update(_ tea.Msg) (tea.Model, tea.Cmd) {
+	return m, tea.Quit
+}
+
+func (m *ConfigEditTerminalState) View() string {
+	state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
+	return state.weave.Render()
+}
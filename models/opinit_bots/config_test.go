@@ -0,0 +1,77 @@
+package opinit_bots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/io"
+)
+
+var configFormats = []io.ConfigFormat{io.ConfigFormatJSON, io.ConfigFormatYAML, io.ConfigFormatTOML}
+
+func TestExecutorConfigFormatRoundTrip(t *testing.T) {
+	config := ExecutorConfig{
+		Version: 1,
+		Server:  ServerConfig{Address: "localhost:3000", AllowOrigins: "*", AllowHeaders: "Origin, Content-Type, Accept", AllowMethods: "GET"},
+		L1Node: NodeSettings{
+			ChainID: "initiation-2", Bech32Prefix: "init", RPCAddress: "https://rpc.testnet.initia.xyz:443",
+			GasPrice: "0.15uinit", GasAdjustment: 1.5, TxTimeout: 60,
+		},
+		L2Node:           NodeSettings{ChainID: "minimove-1", Bech32Prefix: "init", RPCAddress: "http://localhost:26657", GasPrice: "", GasAdjustment: 1.5, TxTimeout: 60},
+		DANode:           NodeSettings{ChainID: "mocha-4", Bech32Prefix: "celestia", RPCAddress: "http://localhost:26658", GasPrice: "0.1utia", GasAdjustment: 1.5, TxTimeout: 60},
+		BridgeExecutor:   "weave_bridge_executor",
+		MaxChunks:        5000,
+		MaxChunkSize:     300000,
+		L1StartHeight:    1,
+		BatchStartHeight: 10,
+	}
+
+	for _, format := range configFormats {
+		data, err := io.MarshalConfig(config, format)
+		assert.NoError(t, err)
+
+		var decoded ExecutorConfig
+		assert.NoError(t, io.UnmarshalConfig(data, format, &decoded), format)
+		assert.Equal(t, config, decoded, format)
+	}
+}
+
+func TestChallengerConfigFormatRoundTrip(t *testing.T) {
+	config := ChallengerConfig{
+		Version:                1,
+		Server:                 ServerConfig{Address: "localhost:3001"},
+		L1Node:                 NodeConfig{ChainID: "initiation-2", Bech32Prefix: "init", RPCAddress: "https://rpc.testnet.initia.xyz:443"},
+		L2Node:                 NodeConfig{ChainID: "minimove-1", Bech32Prefix: "init", RPCAddress: "http://localhost:26657"},
+		L2StartHeight:          7,
+		DisableAutoSetL1Height: true,
+	}
+	keyFile := KeyFile{Challenger: "challenger mnemonic"}
+
+	for _, format := range configFormats {
+		data, err := io.MarshalConfig(config, format)
+		assert.NoError(t, err)
+
+		var decoded ChallengerConfig
+		assert.NoError(t, io.UnmarshalConfig(data, format, &decoded), format)
+		assert.Equal(t, config, decoded, format)
+
+		data, err = io.MarshalConfig(keyFile, format)
+		assert.NoError(t, err)
+
+		var decodedKeyFile KeyFile
+		assert.NoError(t, io.UnmarshalConfig(data, format, &decodedKeyFile), format)
+		assert.Equal(t, keyFile, decodedKeyFile, format)
+	}
+}
+
+func TestKeyFileResolveSecrets(t *testing.T) {
+	t.Setenv("WEAVE_TEST_EXECUTOR_MNEMONIC", "executor mnemonic")
+	keyFile := KeyFile{BridgeExecutor: "env:WEAVE_TEST_EXECUTOR_MNEMONIC", OutputSubmitter: "output mnemonic"}
+
+	assert.NoError(t, keyFile.ResolveSecrets())
+	assert.Equal(t, KeyFile{BridgeExecutor: "executor mnemonic", OutputSubmitter: "output mnemonic"}, keyFile)
+
+	keyFile.Challenger = "env:WEAVE_TEST_MISSING_MNEMONIC"
+	assert.Error(t, keyFile.ResolveSecrets())
+}
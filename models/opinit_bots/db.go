@@ -0,0 +1,490 @@
+package opinit_bots
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+	"github.com/initia-labs/weave/styles"
+)
+
+const (
+	DBBackupDirectory  = "backups"
+	dbMetadataFile     = "metadata.json"
+	dbBackupTimeFormat = "20060102-150405"
+
+	botStopTimeout      = 60 * time.Second
+	botStopPollInterval = time.Second
+)
+
+// botServiceActive reports whether the service of the bot is running, it is replaced in tests
+var botServiceActive = func(bot string) (bool, error) {
+	return service.IsActive(service.CommandName(bot))
+}
+
+// DBHeights are the last L1, L2 and DA heights a bot has processed, as reported by its HTTP API
+type DBHeights struct {
+	BridgeId uint64 `json:"bridge_id,omitempty"`
+	L1       int64  `json:"l1_height"`
+	L2       int64  `json:"l2_height"`
+	DA       int64  `json:"da_height,omitempty"`
+}
+
+// DBMetadata describes a snapshot of the database of a bot, it is stored next to the snapshot
+type DBMetadata struct {
+	Bot        string     `json:"bot"`
+	BotVersion string     `json:"bot_version"`
+	L1ChainId  string     `json:"l1_chain_id"`
+	L2ChainId  string     `json:"l2_chain_id"`
+	Heights    *DBHeights `json:"heights,omitempty"`
+	Size       int64      `json:"size"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type DBBackup struct {
+	Path     string     `json:"path"`
+	Metadata DBMetadata `json:"metadata"`
+}
+
+// DBInfo is the state of the database of a bot, with the snapshots taken of it
+type DBInfo struct {
+	Bot        string     `json:"bot"`
+	Path       string     `json:"path"`
+	Exists     bool       `json:"exists"`
+	Size       int64      `json:"size"`
+	BotVersion string     `json:"bot_version,omitempty"`
+	Running    bool       `json:"running"`
+	Heights    *DBHeights `json:"heights,omitempty"`
+	// HeightsFrom is the path of the backup the heights were recorded in, empty when the running bot reported them
+	HeightsFrom string     `json:"heights_from,omitempty"`
+	Backups     []DBBackup `json:"backups"`
+}
+
+// DBPath returns where the bot keeps its database
+func DBPath(opInitHome, bot string) string {
+	return filepath.Join(opInitHome, fmt.Sprintf("%s.db", bot))
+}
+
+// DefaultDBBackupDir returns where snapshots of the databases are taken when no directory is given
+func DefaultDBBackupDir(opInitHome string) string {
+	return filepath.Join(opInitHome, DBBackupDirectory)
+}
+
+// CheckDBVersionCompatibility checks that a database written by backupVersion can be opened by botVersion.
+// The OPinit bots keep their database layout within a minor version.
+func CheckDBVersionCompatibility(backupVersion, botVersion string) error {
+	if minorVersion(backupVersion) != minorVersion(botVersion) {
+		return fmt.Errorf("the backup was taken with opinitd %s, which is not compatible with opinitd %s", backupVersion, botVersion)
+	}
+	return nil
+}
+
+// minorVersion returns the major and minor parts of a version, e.g. v0.1 for v0.1.12
+func minorVersion(version string) string {
+	version = strings.SplitN(cosmosutils.NormalizeVersion(version), "-", 2)[0]
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// queryDBHeights asks the running bot how far it has processed each chain. It fails when the bot is not running.
+func queryDBHeights(bot string, config botStatusConfig) (*DBHeights, error) {
+	server := botServerURL(bot, config.Server.Address)
+	httpClient := client.NewHTTPClient()
+	switch bot {
+	case "executor":
+		var res executorAPIStatus
+		if _, err := httpClient.Get(server, "/status", nil, &res); err != nil {
+			return nil, err
+		}
+		heights := &DBHeights{BridgeId: res.BridgeId, L1: res.Host.Node.LastBlockHeight, L2: res.Child.Node.LastBlockHeight}
+		if res.DA != nil {
+			heights.DA = res.DA.Node.LastBlockHeight
+		}
+		return heights, nil
+	case "challenger":
+		var res challengerAPIStatus
+		if _, err := httpClient.Get(server, "/status", nil, &res); err != nil {
+			return nil, err
+		}
+		return &DBHeights{BridgeId: res.BridgeId, L1: res.Host.Node.LastBlockHeight, L2: res.Child.Node.LastBlockHeight}, nil
+	default:
+		return nil, fmt.Errorf("unsupported bot: %s", bot)
+	}
+}
+
+func loadDBBotConfig(opInitHome, bot string) (botStatusConfig, error) {
+	var config botStatusConfig
+	configPath := filepath.Join(opInitHome, bot+".json")
+	bz, err := os.ReadFile(configPath)
+	if err != nil {
+		return config, fmt.Errorf("failed to read %s, run `weave opinit init %s` first: %v", configPath, bot, err)
+	}
+	if err = json.Unmarshal(bz, &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %v", configPath, err)
+	}
+	return config, nil
+}
+
+// GetDBInfo returns the size of the database of the bot, its processed heights and the snapshots found in backupDir.
+// The heights are reported by the bot when its API answers, otherwise they are the ones recorded in the latest backup.
+func GetDBInfo(opInitHome, bot, backupDir string) (*DBInfo, error) {
+	config, err := loadDBBotConfig(opInitHome, bot)
+	if err != nil {
+		return nil, err
+	}
+	info := &DBInfo{Bot: bot, Path: DBPath(opInitHome, bot), BotVersion: InstalledBotVersion()}
+	if io.FileOrFolderExists(info.Path) {
+		info.Exists = true
+		if info.Size, err = io.DirectorySize(info.Path); err != nil {
+			return nil, err
+		}
+	}
+	info.Running, err = botRunning(bot, config)
+	if err != nil {
+		return nil, err
+	}
+	if info.Backups, err = ListDBBackups(backupDir, bot); err != nil {
+		return nil, err
+	}
+	if heights, err := queryDBHeights(bot, config); err == nil {
+		info.Heights = heights
+	} else {
+		for _, backup := range info.Backups {
+			if backup.Metadata.Heights != nil {
+				info.Heights = backup.Metadata.Heights
+				info.HeightsFrom = backup.Path
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+// ListDBBackups returns the snapshots of the database of the bot found in backupDir, the most recent first
+func ListDBBackups(backupDir, bot string) ([]DBBackup, error) {
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", backupDir, err)
+	}
+
+	var backups []DBBackup
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), bot+"-") {
+			continue
+		}
+		backup, err := LoadDBBackup(filepath.Join(backupDir, entry.Name()))
+		if err != nil || backup.Metadata.Bot != bot {
+			continue
+		}
+		backups = append(backups, *backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Metadata.CreatedAt.After(backups[j].Metadata.CreatedAt)
+	})
+	return backups, nil
+}
+
+// LoadDBBackup reads the metadata of the snapshot at path
+func LoadDBBackup(path string) (*DBBackup, error) {
+	metadataPath := filepath.Join(path, dbMetadataFile)
+	bz, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s, is %s a backup taken by `weave opinit db backup`? %v", metadataPath, path, err)
+	}
+	backup := &DBBackup{Path: path}
+	if err = json.Unmarshal(bz, &backup.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", metadataPath, err)
+	}
+	if !io.FileOrFolderExists(filepath.Join(path, filepath.Base(DBPath("", backup.Metadata.Bot)))) {
+		return nil, fmt.Errorf("the backup at %s has no %s database", path, backup.Metadata.Bot)
+	}
+	return backup, nil
+}
+
+// BackupDB snapshots the database of the bot into a new directory of backupDir. A running bot is stopped while its
+// database is copied and started again afterwards.
+func BackupDB(opInitHome, bot, backupDir string, progress func(string)) (*DBBackup, error) {
+	config, err := loadDBBotConfig(opInitHome, bot)
+	if err != nil {
+		return nil, err
+	}
+	dbPath := DBPath(opInitHome, bot)
+	if !io.FileOrFolderExists(dbPath) {
+		return nil, fmt.Errorf("no database found at %s", dbPath)
+	}
+
+	metadata := DBMetadata{
+		Bot:        bot,
+		BotVersion: InstalledBotVersion(),
+		L1ChainId:  config.L1Node.ChainID,
+		L2ChainId:  config.L2Node.ChainID,
+		CreatedAt:  time.Now().UTC(),
+	}
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s-%s", bot, metadata.CreatedAt.Format(dbBackupTimeFormat)))
+	if io.FileOrFolderExists(backupPath) {
+		return nil, fmt.Errorf("%s already exists, please remove it first", backupPath)
+	}
+
+	size, err := io.DirectorySize(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(backupDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", backupDir, err)
+	}
+	if free, err := io.GetFreeSpace(backupDir); err == nil && free < uint64(size) {
+		return nil, fmt.Errorf("not enough free space in %s: the database takes %s, %s available", backupDir, io.FormatBytes(size), io.FormatBytes(int64(free)))
+	}
+
+	stopped, heights, err := stopRunningBot(bot, config, progress)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Heights = heights
+	if heights == nil && stopped {
+		progress(fmt.Sprintf("The %s API did not answer, the processed heights are not recorded", bot))
+	} else if heights == nil {
+		progress(fmt.Sprintf("The %s is not running, the processed heights are not recorded", bot))
+	}
+
+	err = copyDB(dbPath, filepath.Join(backupPath, filepath.Base(dbPath)), progress)
+	if err == nil {
+		metadata.Size = size
+		err = writeDBMetadata(backupPath, metadata)
+	}
+	if err != nil {
+		_ = os.RemoveAll(backupPath)
+	}
+	if startErr := startStoppedBot(bot, stopped, progress); startErr != nil && err == nil {
+		err = startErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DBBackup{Path: backupPath, Metadata: metadata}, nil
+}
+
+// RestoreDB replaces the database of the bot with the snapshot at backupPath. The snapshot must have been taken for
+// the same chains and by a compatible opinitd version, unless force is set. A running bot is stopped while its
+// database is replaced and started again afterwards.
+func RestoreDB(opInitHome, bot, backupPath string, force bool, progress func(string)) (*DBBackup, error) {
+	config, err := loadDBBotConfig(opInitHome, bot)
+	if err != nil {
+		return nil, err
+	}
+	backup, err := LoadDBBackup(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDBBackup(backup.Metadata, bot, config, InstalledBotVersion()); err != nil {
+		if !force {
+			return nil, fmt.Errorf("%v. Use --force to restore it anyway", err)
+		}
+		progress(fmt.Sprintf("Restoring anyway: %v", err))
+	}
+
+	stopped, _, err := stopRunningBot(bot, config, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath := DBPath(opInitHome, bot)
+	previousPath := dbPath + ".pre-restore"
+	err = func() error {
+		if io.FileOrFolderExists(dbPath) {
+			if err := os.RemoveAll(previousPath); err != nil {
+				return fmt.Errorf("failed to remove %s: %v", previousPath, err)
+			}
+			if err := os.Rename(dbPath, previousPath); err != nil {
+				return fmt.Errorf("failed to move the current database aside: %v", err)
+			}
+		}
+		if err := copyDB(filepath.Join(backupPath, filepath.Base(dbPath)), dbPath, progress); err != nil {
+			_ = os.RemoveAll(dbPath)
+			if io.FileOrFolderExists(previousPath) {
+				_ = os.Rename(previousPath, dbPath)
+			}
+			return err
+		}
+		return os.RemoveAll(previousPath)
+	}()
+
+	if startErr := startStoppedBot(bot, stopped, progress); startErr != nil && err == nil {
+		err = startErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+func checkDBBackup(metadata DBMetadata, bot string, config botStatusConfig, botVersion string) error {
+	if metadata.Bot != bot {
+		return fmt.Errorf("the backup is of the %s database, not the %s one", metadata.Bot, bot)
+	}
+	if metadata.L1ChainId != config.L1Node.ChainID || metadata.L2ChainId != config.L2Node.ChainID {
+		return fmt.Errorf("the backup was taken for %s and %s, but the %s is configured for %s and %s", metadata.L1ChainId, metadata.L2ChainId, bot, config.L1Node.ChainID, config.L2Node.ChainID)
+	}
+	return CheckDBVersionCompatibility(metadata.BotVersion, botVersion)
+}
+
+// botRunning reports whether the service of the bot is running. When the service state cannot be read, a bot whose
+// HTTP API answers runs outside of weave and is an error, since it cannot be stopped from here.
+func botRunning(bot string, config botStatusConfig) (bool, error) {
+	active, err := botServiceActive(bot)
+	if err == nil {
+		return active, nil
+	}
+	if _, queryErr := queryDBHeights(bot, config); queryErr == nil {
+		return false, fmt.Errorf("the %s answers on its API but the state of its service is unknown, stop it first: %v", bot, err)
+	}
+	return false, nil
+}
+
+// stopRunningBot stops the service of the bot when it is running and waits until it exits. It returns the heights
+// the bot reported before stopping, nil when its API did not answer.
+func stopRunningBot(bot string, config botStatusConfig, progress func(string)) (bool, *DBHeights, error) {
+	running, err := botRunning(bot, config)
+	if err != nil || !running {
+		return false, nil, err
+	}
+	heights, err := queryDBHeights(bot, config)
+	if err != nil {
+		heights = nil
+	}
+	s, err := service.NewService(service.CommandName(bot))
+	if err != nil {
+		return false, nil, err
+	}
+	if err = s.Stop(); err != nil {
+		return false, nil, fmt.Errorf("failed to stop the %s service: %v", bot, err)
+	}
+	if err = waitForBotStopped(bot); err != nil {
+		return true, nil, err
+	}
+	if heights != nil {
+		progress(fmt.Sprintf("Stopped the OPinit %s bot at L1 height %d and L2 height %d", bot, heights.L1, heights.L2))
+	} else {
+		progress(fmt.Sprintf("Stopped the OPinit %s bot", bot))
+	}
+	return true, heights, nil
+}
+
+// waitForBotStopped polls the service of the bot until it is no longer running, so its database is closed
+func waitForBotStopped(bot string) error {
+	deadline := time.Now().Add(botStopTimeout)
+	for {
+		active, err := botServiceActive(bot)
+		if err != nil {
+			return fmt.Errorf("failed to check the %s service: %v", bot, err)
+		}
+		if !active {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the %s service is still running %s after stopping it", bot, botStopTimeout)
+		}
+		time.Sleep(botStopPollInterval)
+	}
+}
+
+func startStoppedBot(bot string, stopped bool, progress func(string)) error {
+	if !stopped {
+		return nil
+	}
+	s, err := service.NewService(service.CommandName(bot))
+	if err != nil {
+		return err
+	}
+	if err = s.Start(); err != nil {
+		return fmt.Errorf("failed to start the %s service again, run `weave opinit start %s -d`: %v", bot, bot, err)
+	}
+	progress(fmt.Sprintf("Started the OPinit %s bot again", bot))
+	return nil
+}
+
+func copyDB(src, des string, progress func(string)) error {
+	progress(fmt.Sprintf("Copying %s to %s", src, des))
+	if err := io.CopyDirectoryWithProgress(src, des, nil); err != nil {
+		return fmt.Errorf("failed to copy the database: %v", err)
+	}
+	if err := io.VerifyDirectoryCopy(src, des); err != nil {
+		return fmt.Errorf("failed to verify the copy of the database: %v", err)
+	}
+	return nil
+}
+
+func writeDBMetadata(backupPath string, metadata DBMetadata) error {
+	bz, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the backup metadata: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(backupPath, dbMetadataFile), bz, 0644); err != nil {
+		return fmt.Errorf("failed to write the backup metadata: %v", err)
+	}
+	return nil
+}
+
+func (h *DBHeights) String() string {
+	text := fmt.Sprintf("L1 %d, L2 %d", h.L1, h.L2)
+	if h.DA > 0 {
+		text += fmt.Sprintf(", DA %d", h.DA)
+	}
+	return text
+}
+
+func (b *DBBackup) String() string {
+	text := fmt.Sprintf("%s  %s  opinitd %s  %s", b.Metadata.CreatedAt.Local().Format(time.DateTime), io.FormatBytes(b.Metadata.Size), b.Metadata.BotVersion, b.Path)
+	if b.Metadata.Heights != nil {
+		text += styles.Text(fmt.Sprintf(" (%s)", b.Metadata.Heights.String()), styles.Gray)
+	}
+	return text
+}
+
+func (i *DBInfo) String() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldText(fmt.Sprintf("OPinit %s database\n", i.Bot), styles.Cyan))
+	b.WriteString(fmt.Sprintf("  %-20s %s\n", "Path:", i.Path))
+	if !i.Exists {
+		b.WriteString("  " + styles.Text("No database yet, the bot syncs from its start heights", styles.Yellow) + "\n")
+	} else {
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Size:", styles.BoldText(io.FormatBytes(i.Size), styles.White)))
+	}
+	b.WriteString(fmt.Sprintf("  %-20s %s\n", "Bot version:", i.BotVersion))
+	running := "no"
+	if i.Running {
+		running = "yes"
+	}
+	b.WriteString(fmt.Sprintf("  %-20s %s\n", "Running:", running))
+	switch {
+	case i.Heights != nil && i.HeightsFrom == "":
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Processed heights:", i.Heights.String()))
+	case i.Heights != nil:
+		b.WriteString(fmt.Sprintf("  %-20s %s %s\n", "Processed heights:", i.Heights.String(), styles.Text("(as recorded by the latest backup, the bot API does not answer)", styles.Gray)))
+	default:
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Processed heights:", styles.Text("unknown, the bot API does not answer and no backup recorded them", styles.Gray)))
+	}
+	if len(i.Backups) == 0 {
+		b.WriteString(fmt.Sprintf("  %-20s none\n", "Backups:"))
+	} else {
+		b.WriteString(fmt.Sprintf("  %-20s\n", "Backups:"))
+		for _, backup := range i.Backups {
+			b.WriteString("    " + backup.String() + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package opinit_bots
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDBVersionCompatibility(t *testing.T) {
+	assert.NoError(t, CheckDBVersionCompatibility("v0.1.12", "v0.1.15"))
+	assert.NoError(t, CheckDBVersionCompatibility("0.1.12", "v0.1.12-beta.1"))
+	assert.ErrorContains(t, CheckDBVersionCompatibility("v0.1.12", "v0.2.0"), "the backup was taken with opinitd v0.1.12, which is not compatible with opinitd v0.2.0")
+}
+
+func TestCheckDBBackup(t *testing.T) {
+	config := botStatusConfig{L1Node: NodeConfig{ChainID: "initiation-2"}, L2Node: NodeConfig{ChainID: "minimove-1"}}
+	metadata := DBMetadata{Bot: "executor", BotVersion: "v0.1.12", L1ChainId: "initiation-2", L2ChainId: "minimove-1"}
+	assert.NoError(t, checkDBBackup(metadata, "executor", config, "v0.1.13"))
+	assert.ErrorContains(t, checkDBBackup(metadata, "challenger", config, "v0.1.12"), "the backup is of the executor database, not the challenger one")
+
+	config.L2Node.ChainID = "minimove-2"
+	assert.ErrorContains(t, checkDBBackup(metadata, "executor", config, "v0.1.12"), "the backup was taken for initiation-2 and minimove-1")
+}
+
+// stubBotService makes the services of the bots report active, or err when it is set
+func stubBotService(t *testing.T, active bool, err error) {
+	original := botServiceActive
+	botServiceActive = func(string) (bool, error) { return active, err }
+	t.Cleanup(func() { botServiceActive = original })
+}
+
+func TestBackupAndRestoreDB(t *testing.T) {
+	opInitHome := t.TempDir()
+	backupDir := filepath.Join(opInitHome, DBBackupDirectory)
+	// the bot is not running, its API cannot be reached
+	stubBotService(t, false, nil)
+	assert.NoError(t, os.WriteFile(filepath.Join(opInitHome, "executor.json"),
+		[]byte(`{"server": {"address": "localhost:1"}, "l1_node": {"chain_id": "initiation-2"}, "l2_node": {"chain_id": "minimove-1"}}`), 0644))
+	dbPath := DBPath(opInitHome, "executor")
+	assert.NoError(t, os.MkdirAll(dbPath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dbPath, "000001.ldb"), []byte("synced"), 0644))
+
+	var messages []string
+	progress := func(message string) { messages = append(messages, message) }
+	backup, err := BackupDB(opInitHome, "executor", backupDir, progress)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), backup.Metadata.Size)
+	assert.Nil(t, backup.Metadata.Heights)
+	assert.Contains(t, messages, "The executor is not running, the processed heights are not recorded")
+
+	backups, err := ListDBBackups(backupDir, "executor")
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	assert.Equal(t, backup.Path, backups[0].Path)
+	assert.Equal(t, "minimove-1", backups[0].Metadata.L2ChainId)
+
+	// the database gets corrupted
+	assert.NoError(t, os.WriteFile(filepath.Join(dbPath, "000001.ldb"), []byte("corrupt"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dbPath, "000002.ldb"), []byte("corrupt"), 0644))
+
+	_, err = RestoreDB(opInitHome, "executor", backup.Path, false, progress)
+	assert.NoError(t, err)
+	bz, err := os.ReadFile(filepath.Join(dbPath, "000001.ldb"))
+	assert.NoError(t, err)
+	assert.Equal(t, "synced", string(bz))
+	assert.NoFileExists(t, filepath.Join(dbPath, "000002.ldb"))
+	assert.NoDirExists(t, dbPath+".pre-restore")
+
+	_, err = RestoreDB(opInitHome, "executor", opInitHome, false, progress)
+	assert.ErrorContains(t, err, "is "+opInitHome+" a backup taken by `weave opinit db backup`?")
+}
+
+func TestGetDBInfoHeightsFromBackup(t *testing.T) {
+	opInitHome := t.TempDir()
+	backupDir := filepath.Join(opInitHome, DBBackupDirectory)
+	stubBotService(t, false, errors.New("System has not been booted with systemd"))
+	assert.NoError(t, os.WriteFile(filepath.Join(opInitHome, "executor.json"),
+		[]byte(`{"server": {"address": "localhost:1"}, "l1_node": {"chain_id": "initiation-2"}, "l2_node": {"chain_id": "minimove-1"}}`), 0644))
+	older := filepath.Join(backupDir, "executor-20250101-000000")
+	latest := filepath.Join(backupDir, "executor-20250102-000000")
+	assert.NoError(t, os.MkdirAll(DBPath(older, "executor"), 0755))
+	assert.NoError(t, os.MkdirAll(DBPath(latest, "executor"), 0755))
+	assert.NoError(t, writeDBMetadata(older, DBMetadata{Bot: "executor", Heights: &DBHeights{L1: 10, L2: 20}, CreatedAt: time.Now().Add(-time.Hour)}))
+	assert.NoError(t, writeDBMetadata(latest, DBMetadata{Bot: "executor", Heights: &DBHeights{L1: 30, L2: 40}, CreatedAt: time.Now()}))
+
+	// the service state is unknown and the API does not answer, the heights come from the latest backup
+	info, err := GetDBInfo(opInitHome, "executor", backupDir)
+	assert.NoError(t, err)
+	assert.False(t, info.Running)
+	assert.Equal(t, &DBHeights{L1: 30, L2: 40}, info.Heights)
+	assert.Equal(t, latest, info.HeightsFrom)
+	assert.Contains(t, info.String(), "as recorded by the latest backup")
+}
+
+func TestBotRunning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"bridge_id": 1, "host": {"node": {"last_block_height": 5}}, "child": {"node": {"last_block_height": 6}}}`))
+	}))
+	defer server.Close()
+	answering := botStatusConfig{Server: ServerConfig{Address: server.URL}}
+	silent := botStatusConfig{Server: ServerConfig{Address: "localhost:1"}}
+
+	// the service state decides, whether the API answers or not
+	stubBotService(t, true, nil)
+	running, err := botRunning("executor", silent)
+	assert.NoError(t, err)
+	assert.True(t, running)
+	stubBotService(t, false, nil)
+	running, err = botRunning("executor", answering)
+	assert.NoError(t, err)
+	assert.False(t, running)
+
+	// without a service state, a bot answering on its API cannot be stopped
+	stubBotService(t, false, errors.New("unsupported OS: windows"))
+	_, err = botRunning("executor", answering)
+	assert.ErrorContains(t, err, "the executor answers on its API but the state of its service is unknown")
+	running, err = botRunning("executor", silent)
+	assert.NoError(t, err)
+	assert.False(t, running)
+}
+
+func TestListDBBackupsOrder(t *testing.T) {
+	backupDir := t.TempDir()
+	now := time.Now().UTC()
+	for idx, bot := range []string{"executor", "executor", "challenger"} {
+		path := filepath.Join(backupDir, bot+"-"+now.Add(time.Duration(idx)*time.Hour).Format(dbBackupTimeFormat))
+		assert.NoError(t, os.MkdirAll(DBPath(path, bot), 0755))
+		assert.NoError(t, writeDBMetadata(path, DBMetadata{Bot: bot, CreatedAt: now.Add(time.Duration(idx) * time.Hour)}))
+	}
+
+	backups, err := ListDBBackups(backupDir, "executor")
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+	assert.True(t, backups[0].Metadata.CreatedAt.After(backups[1].Metadata.CreatedAt))
+
+	backups, err = ListDBBackups(filepath.Join(backupDir, "missing"), "executor")
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+}
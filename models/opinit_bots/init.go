@@ -92,6 +92,16 @@ func setFieldPlaceholder(fields []*Field, name, placeholder string) error {
 	return nil
 }
 
+// celestiaGasPrices returns the gas price of the DA node from the fee tokens of the Celestia registry, falling back
+// to DefaultCelestiaGasPrices when the registry does not list utia
+func celestiaGasPrices(chainRegistry *registry.ChainRegistry) string {
+	gasPrices, err := chainRegistry.GetGasPriceByDenom(DefaultCelestiaGasDenom)
+	if err != nil {
+		return DefaultCelestiaGasPrices
+	}
+	return gasPrices
+}
+
 func NewOPInitBotInitSelector(ctx context.Context) (tea.Model, error) {
 	tooltips := []ui.Tooltip{
 		ui.NewTooltip("Executor", "Executes cross-chain transactions, ensuring that assets and data move securely between Initia and Minitias.", "", []string{}, []string{}, []string{}),
@@ -599,18 +609,7 @@ func (m *PrefillMinitiaConfig) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if minitiaConfig.OpBridge.BatchSubmissionTarget == "CELESTIA" {
-				var network registry.ChainType
-				l1ChainRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
-				if err != nil {
-					return m, m.HandlePanic(err)
-				}
-				if l1ChainRegistry.GetChainId() == minitiaConfig.L1Config.ChainID {
-					network = registry.CelestiaTestnet
-				} else {
-					network = registry.CelestiaMainnet
-				}
-
-				chainRegistry, err := registry.GetChainRegistry(network)
+				chainRegistry, err := registry.GetCelestiaChainRegistry(minitiaConfig.L1Config.ChainID)
 				if err != nil {
 					return m, m.HandlePanic(err)
 				}
@@ -622,7 +621,7 @@ func (m *PrefillMinitiaConfig) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				state.botConfig["da_node.rpc_address"] = activeRpc
 				state.botConfig["da_node.bech32_prefix"] = chainRegistry.GetBech32Prefix()
-				state.botConfig["da_node.gas_price"] = DefaultCelestiaGasPrices
+				state.botConfig["da_node.gas_price"] = celestiaGasPrices(chainRegistry)
 				state.daIsCelestia = true
 			} else {
 				state.botConfig["da_node.chain_id"] = state.botConfig["l1_node.chain_id"]
@@ -664,6 +663,7 @@ type L1PrefillOption string
 
 var (
 	L1PrefillOptionTestnet L1PrefillOption = ""
+	L1PrefillOptionLocal   L1PrefillOption = ""
 	L1PrefillOptionCustom  L1PrefillOption = "Custom"
 )
 
@@ -675,17 +675,24 @@ type L1PrefillSelector struct {
 }
 
 func NewL1PrefillSelector(ctx context.Context) (*L1PrefillSelector, error) {
+	var options []L1PrefillOption
+	localL1, hasLocalL1 := registry.GetSavedLocalL1Config()
 	initiaTestnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
-	if err != nil {
+	if err == nil {
+		L1PrefillOptionTestnet = L1PrefillOption(fmt.Sprintf("Testnet (%s)", initiaTestnetRegistry.GetChainId()))
+		options = append(options, L1PrefillOptionTestnet)
+	} else if !hasLocalL1 {
 		return nil, fmt.Errorf("initia testnet registry: %w", err)
 	}
-	L1PrefillOptionTestnet = L1PrefillOption(fmt.Sprintf("Testnet (%s)", initiaTestnetRegistry.GetChainId()))
+	if hasLocalL1 {
+		L1PrefillOptionLocal = L1PrefillOption(fmt.Sprintf("Local L1 (%s)", localL1.ChainId))
+		options = append(options, L1PrefillOptionLocal)
+	}
+	// options = append(options, L1PrefillOptionCustom)
+
 	return &L1PrefillSelector{
 		Selector: ui.Selector[L1PrefillOption]{
-			Options: []L1PrefillOption{
-				L1PrefillOptionTestnet,
-				// L1PrefillOptionCustom,
-			},
+			Options: options,
 		},
 		BaseModel:  weavecontext.BaseModel{Ctx: ctx},
 		question:   "Which L1 would you like your rollup to connect to?",
@@ -728,6 +735,22 @@ func (m *L1PrefillSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err != nil {
 				return m, m.HandlePanic(err)
 			}
+		case L1PrefillOptionLocal:
+			analytics.TrackEvent(analytics.L1PrefillSelected, analytics.NewEmptyEvent().Add(analytics.OptionEventKey, "local"))
+
+			chainRegistry, err := registry.GetChainRegistry(registry.InitiaL1Local)
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
+			chainId = chainRegistry.GetChainId()
+			rpc, err = chainRegistry.GetActiveRpc()
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
+			minGasPrice, err = chainRegistry.GetMinGasPriceByDenom(DefaultInitiaGasDenom)
+			if err != nil {
+				return m, m.HandlePanic(err)
+			}
 		case L1PrefillOptionCustom:
 			analytics.TrackEvent(analytics.L1PrefillSelected, analytics.NewEmptyEvent().Add(analytics.OptionEventKey, "custom"))
 		}
@@ -780,30 +803,30 @@ func NewSetDALayer(ctx context.Context) (tea.Model, error) {
 		tooltip.InitiaDALayerTooltip,
 	}
 	state := weavecontext.GetCurrentState[OPInitBotsState](ctx)
-	var network registry.ChainType
-	l1ChainRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+	network, err := registry.GetCelestiaChainType(state.botConfig["l1_node.chain_id"])
 	if err != nil {
 		return nil, fmt.Errorf("initia testnet registry: %w", err)
 	}
-	if l1ChainRegistry.GetChainId() == state.botConfig["l1_node.chain_id"] {
-		network = registry.CelestiaTestnet
+	if network == registry.CelestiaTestnet {
 		tooltips = append(tooltips, tooltip.CelestiaTestnetDALayerTooltip)
 	} else {
-		network = registry.CelestiaMainnet
 		tooltips = append(tooltips, tooltip.CelestiaMainnetDALayerTooltip)
 	}
 
+	options := []DALayerNetwork{Initia, Celestia}
 	chainRegistry, err := registry.GetChainRegistry(network)
 	if err != nil {
-		return nil, fmt.Errorf("celestia registry: %w", err)
+		// A local L1 can run without internet access, in which case only the L1 itself can be the DA layer
+		if !registry.IsLocalL1(state.botConfig["l1_node.chain_id"]) {
+			return nil, fmt.Errorf("celestia registry: %w", err)
+		}
+		options = []DALayerNetwork{Initia}
+		tooltips = tooltips[:1]
 	}
 
 	return &SetDALayer{
 		Selector: ui.Selector[DALayerNetwork]{
-			Options: []DALayerNetwork{
-				Initia,
-				Celestia,
-			},
+			Options:    options,
 			CannotBack: true,
 			Tooltips:   &tooltips,
 		},
@@ -846,7 +869,7 @@ func (m *SetDALayer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			state.botConfig["da_node.rpc_address"] = activeRpc
 			state.botConfig["da_node.bech32_prefix"] = m.chainRegistry.GetBech32Prefix()
-			state.botConfig["da_node.gas_price"] = DefaultCelestiaGasPrices
+			state.botConfig["da_node.gas_price"] = celestiaGasPrices(m.chainRegistry)
 			state.daIsCelestia = true
 		}
 		model, err := NewFetchL1StartHeightLoading(weavecontext.SetCurrentState(m.Ctx, state))
@@ -888,22 +911,20 @@ func waitFetchL1StartHeight(ctx context.Context) tea.Cmd {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to initialize opchild querier: %w", err)}
 		}
 
-		var network registry.ChainType
-		l1ChainRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+		network, err := registry.GetL1ChainType(state.botConfig["l1_node.chain_id"])
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("initia testnet registry: %w", err)}
 		}
-		if l1ChainRegistry.GetChainId() == state.botConfig["l1_node.chain_id"] {
-			network = registry.InitiaL1Testnet
-		} else {
-			network = registry.InitiaL1Mainnet
-		}
 
-		gqlApi, err := registry.GetInitiaGraphQLFromType(network)
-		if err != nil {
-			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("cannot fetch initia GraphQL api: %w", err)}
+		// There is no GraphQL indexer for a local L1, the start height is then asked for unless it is in the artifacts
+		var gqlClient *client.GraphQLClient
+		if network != registry.InitiaL1Local {
+			gqlApi, err := registry.GetInitiaGraphQLFromType(network)
+			if err != nil {
+				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("cannot fetch initia GraphQL api: %w", err)}
+			}
+			gqlClient = client.NewGraphQLClient(gqlApi, client.NewHTTPClient())
 		}
-		gqlClient := client.NewGraphQLClient(gqlApi, client.NewHTTPClient())
 
 		l1NextSequence, err := minitiadQuerier.QueryOPChildNextL1Sequence(l2Rpc)
 		if err != nil {
@@ -936,10 +957,10 @@ func waitFetchL1StartHeight(ctx context.Context) tea.Cmd {
 				if err != nil {
 					return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to parse l1 start height: %w", err)}
 				}
-			} else {
+			} else if gqlClient != nil {
 				state.L1StartHeight, _ = cosmosutils.QueryCreateBridgeHeight(gqlClient, bridgeInfo.BridgeID)
 			}
-		} else {
+		} else if gqlClient != nil {
 			sequence, err := strconv.Atoi(l1NextSequence)
 			if err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to parse next sequence number: %w", err)}
@@ -1178,7 +1199,7 @@ func WaitStartingInitBot(ctx context.Context) tea.Cmd {
 			if err != nil {
 				return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get user home dir: %v", err)}
 			}
-			binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion), AppName)
+			binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", CurrentBotVersion()), AppName)
 			if address, err := cosmosutils.OPInitGetAddressForKey(binaryPath, OracleBridgeExecutorKeyName, opInitHome); err == nil {
 				// TODO: revisit error
 				_ = cosmosutils.OPInitGrantOracle(binaryPath, address, opInitHome)
@@ -1389,7 +1410,7 @@ func WaitSetupOPInitBotsMissingKey(ctx context.Context) tea.Cmd {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get user home directory: %v", err)}
 		}
 
-		binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion), AppName)
+		binaryPath := filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", CurrentBotVersion()), AppName)
 
 		opInitHome, err := weavecontext.GetOPInitHome(ctx)
 		if err != nil {
@@ -1553,7 +1574,7 @@ func ensureOPInitBotsBinary(userHome string) (string, error) {
 	tarballPath := filepath.Join(weaveDataPath, "opinitd.tar.gz")
 	goos := runtime.GOOS
 	goarch := runtime.GOARCH
-	extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion))
+	extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("opinitd@%s", CurrentBotVersion()))
 
 	// Check if the binary already exists
 	if _, err := os.Stat(binaryPath); err == nil {
@@ -1571,7 +1592,7 @@ func ensureOPInitBotsBinary(userHome string) (string, error) {
 	}
 
 	// Get the binary download URL
-	url, err := getBinaryURL(OpinitBotBinaryVersion, goos, goarch)
+	url, err := getBinaryURL(CurrentBotVersion(), goos, goarch)
 	if err != nil {
 		return binaryPath, fmt.Errorf("failed to get binary URL: %v", err)
 	}
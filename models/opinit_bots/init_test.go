@@ -228,7 +228,7 @@ func TestSetDALayer_Update_SelectCelestia(t *testing.T) {
 		state := weavecontext.GetCurrentState[OPInitBotsState](m.Ctx)
 		assert.Equal(t, chainRegistry.ChainId, state.botConfig["da_node.chain_id"])
 		assert.Equal(t, chainRegistry.Bech32Prefix, state.botConfig["da_node.bech32_prefix"])
-		assert.Equal(t, DefaultCelestiaGasPrices, state.botConfig["da_node.gas_price"])
+		assert.Equal(t, celestiaGasPrices(chainRegistry), state.botConfig["da_node.gas_price"])
 		assert.True(t, state.daIsCelestia) // Ensure daIsCelestia is true for Celestia
 	}
 }
@@ -0,0 +1,818 @@
+package opinit_bots
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/minitia"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+	"github.com/initia-labs/weave/types"
+)
+
+const (
+	// rotationGasLimit is the gas limit of the txs signed from the OPinit keyring, fixed so that the fee, and so what
+	// is left to drain from the old key, is known before broadcasting
+	rotationGasLimit uint64 = 300000
+
+	rotationTxFilename = "rotate_key_tx.json"
+)
+
+// KeyRole is an OPinit bot key that can be rotated
+type KeyRole string
+
+const (
+	BridgeExecutorRole       KeyRole = "bridge-executor"
+	OutputSubmitterRole      KeyRole = "output-submitter"
+	BatchSubmitterRole       KeyRole = "batch-submitter"
+	ChallengerRole           KeyRole = "challenger"
+	OracleBridgeExecutorRole KeyRole = "oracle-bridge-executor"
+)
+
+var KeyRoles = []KeyRole{BridgeExecutorRole, OutputSubmitterRole, BatchSubmitterRole, ChallengerRole, OracleBridgeExecutorRole}
+
+func ParseKeyRole(role string) (KeyRole, error) {
+	for _, keyRole := range KeyRoles {
+		if string(keyRole) == role {
+			return keyRole, nil
+		}
+	}
+	var roles []string
+	for _, keyRole := range KeyRoles {
+		roles = append(roles, string(keyRole))
+	}
+	return "", fmt.Errorf("invalid key role '%s'. Valid options are: [%s]", role, strings.Join(roles, ", "))
+}
+
+// Bot returns the bot that signs with the key
+func (r KeyRole) Bot() string {
+	if r == ChallengerRole {
+		return "challenger"
+	}
+	return "executor"
+}
+
+func (r KeyRole) KeyName() string {
+	switch r {
+	case BridgeExecutorRole:
+		return BridgeExecutorKeyName
+	case OutputSubmitterRole:
+		return OutputSubmitterKeyName
+	case BatchSubmitterRole:
+		return BatchSubmitterKeyName
+	case ChallengerRole:
+		return ChallengerKeyName
+	default:
+		return OracleBridgeExecutorKeyName
+	}
+}
+
+// DefaultFunding returns what a new key of the role is funded with, the same as at launch. It is in uinit on the L1,
+// or in utia for a batch submitter on Celestia.
+func (r KeyRole) DefaultFunding() string {
+	switch r {
+	case BridgeExecutorRole:
+		return minitia.DefaultL1BridgeExecutorBalance
+	case OutputSubmitterRole:
+		return minitia.DefaultL1OutputSubmitterBalance
+	case BatchSubmitterRole:
+		return minitia.DefaultL1BatchSubmitterBalance
+	case ChallengerRole:
+		return minitia.DefaultL1ChallengerBalance
+	default:
+		return "0"
+	}
+}
+
+// FundSource is where the funds of the new key come from
+type FundSource string
+
+const (
+	FundFromGasStation FundSource = "gas-station"
+	FundFromOldKey     FundSource = "old-key"
+)
+
+type RotateKeyOptions struct {
+	// Mnemonic of the new key. A new mnemonic is generated when empty.
+	Mnemonic string
+	FundFrom FundSource
+	// Amount is sent to the new key in the fee denom of the chain it pays fees on, DefaultFunding of the role when
+	// empty
+	Amount string
+	// BridgeId is queried from the HTTP API of the bot when empty
+	BridgeId string
+	// MinitiaHome is the home of the rollup whose operator updates the bridge executors in the opchild params
+	MinitiaHome string
+}
+
+// rotationChain is a chain the rotation sends txs to
+type rotationChain struct {
+	name     string
+	chainId  string
+	rpc      string
+	lcd      string
+	gasPrice string
+	denom    string
+}
+
+// KeyRotation is everything that is known about a key rotation before anything is changed
+type KeyRotation struct {
+	Role         KeyRole
+	OldAddress   string
+	NewAddress   string
+	Mnemonic     string
+	GeneratedKey bool
+	Amount       string
+	FundFrom     FundSource
+	BridgeId     string
+	// Update describes the on-chain change that authorizes the new key, empty when none is needed or it is done
+	Update string
+	Notes  []string
+	// Resumed is set when an unfinished rotation of the role is continued
+	Resumed bool
+
+	opInitHome string
+	binaryPath string
+	isCelestia bool
+	chainIds   []string
+	l1         rotationChain
+	// funds is the chain the key pays its fees on, the L1 or Celestia for a batch submitter on Celestia. It is nil
+	// when the key holds no funds.
+	funds        *rotationChain
+	bridge       *types.Bridge
+	minitiaHome  string
+	signerKey    string
+	updateMsg    map[string]interface{}
+	paramsUpdate *minitia.OPChildParamsUpdate
+	state        *rotationState
+}
+
+// PlanKeyRotation checks that the key of role can be rotated in opInitHome and works out the new key, the funding and
+// the on-chain update. An unfinished rotation of the role is resumed. Nothing is changed.
+func PlanKeyRotation(opInitHome string, role KeyRole, options RotateKeyOptions) (*KeyRotation, error) {
+	configFile, err := LoadBotConfigFile(opInitHome, role.Bot())
+	if err != nil {
+		return nil, err
+	}
+	rotation := &KeyRotation{
+		Role:        role,
+		Mnemonic:    options.Mnemonic,
+		Amount:      options.Amount,
+		FundFrom:    options.FundFrom,
+		BridgeId:    options.BridgeId,
+		opInitHome:  opInitHome,
+		minitiaHome: options.MinitiaHome,
+	}
+	if rotation.state, err = loadRotationState(opInitHome, role); err != nil {
+		return nil, err
+	}
+	if state := rotation.state; state != nil {
+		rotation.Resumed = true
+		rotation.Mnemonic = state.Mnemonic
+		rotation.Amount = state.Amount
+		rotation.FundFrom = state.FundFrom
+		rotation.BridgeId = state.BridgeId
+	}
+	if rotation.Amount == "" {
+		rotation.Amount = role.DefaultFunding()
+	}
+	if rotation.FundFrom == "" {
+		rotation.FundFrom = FundFromGasStation
+	}
+	if rotation.FundFrom != FundFromGasStation && rotation.FundFrom != FundFromOldKey {
+		return nil, fmt.Errorf("invalid funding source '%s'. Valid options are: [%s, %s]", rotation.FundFrom, FundFromGasStation, FundFromOldKey)
+	}
+	if _, ok := new(big.Int).SetString(rotation.Amount, 10); !ok || strings.HasPrefix(rotation.Amount, "-") {
+		return nil, fmt.Errorf("amount must be zero or a positive integer: %s", rotation.Amount)
+	}
+
+	rotation.l1.name = "Initia L1"
+	rotation.l1.denom = DefaultInitiaGasDenom
+	rotation.l1.chainId, _ = configFile.Get("l1_node.chain_id")
+	rotation.l1.rpc, _ = configFile.Get("l1_node.rpc_address")
+	for _, path := range []string{"l1_node.chain_id", "l2_node.chain_id", "da_node.chain_id"} {
+		if chainId, _ := configFile.Get(path); chainId != "" && !contains(rotation.chainIds, chainId) {
+			rotation.chainIds = append(rotation.chainIds, chainId)
+		}
+	}
+	bech32Prefix := "init"
+	if role == BatchSubmitterRole {
+		if daPrefix, _ := configFile.Get("da_node.bech32_prefix"); daPrefix != "" && daPrefix != "init" {
+			rotation.isCelestia = true
+			bech32Prefix = daPrefix
+		}
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home dir: %v", err)
+	}
+	rotation.binaryPath = filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", CurrentBotVersion()), AppName)
+	if !cosmosutils.OPInitKeyExist(rotation.binaryPath, role.KeyName(), opInitHome) {
+		return nil, fmt.Errorf("the %s key %s is not in the keyring of %s, set up the keys with `weave opinit setup-keys` first", role, role.KeyName(), opInitHome)
+	}
+
+	if rotation.Mnemonic == "" {
+		if rotation.Mnemonic, err = crypto.GenerateMnemonic(); err != nil {
+			return nil, err
+		}
+		rotation.GeneratedKey = true
+	}
+	if rotation.NewAddress, err = crypto.MnemonicToBech32Address(bech32Prefix, rotation.Mnemonic); err != nil {
+		return nil, fmt.Errorf("invalid mnemonic of the new key: %v", err)
+	}
+	if state := rotation.state; state != nil {
+		// the keyring may already hold the new key, the old one is only known from the state
+		rotation.OldAddress = state.OldAddress
+		if options.Mnemonic != "" && options.Mnemonic != state.Mnemonic {
+			return nil, fmt.Errorf("the rotation of the %s key to %s started at %s is unfinished, run it again without --mnemonic to resume it or remove %s to start over",
+				role, state.NewAddress, state.StartedAt.Format(time.RFC3339), rotationStatePath(opInitHome, role))
+		}
+	} else {
+		if rotation.OldAddress, err = cosmosutils.OPInitGetAddressForKey(rotation.binaryPath, role.KeyName(), opInitHome); err != nil {
+			return nil, err
+		}
+		if rotation.NewAddress == rotation.OldAddress {
+			return nil, fmt.Errorf("the new %s key is the current one, %s", role, rotation.OldAddress)
+		}
+	}
+
+	l1Registry, err := registry.GetL1ChainRegistry(rotation.l1.chainId)
+	if err != nil {
+		return nil, err
+	}
+	if rotation.l1.lcd, err = l1Registry.GetActiveLcd(); err != nil {
+		return nil, err
+	}
+	if rotation.l1.gasPrice, err = l1Registry.GetGasPriceByDenom(DefaultInitiaGasDenom); err != nil {
+		return nil, err
+	}
+	switch {
+	case role == OracleBridgeExecutorRole:
+	case rotation.isCelestia:
+		if rotation.funds, err = celestiaRotationChain(rotation.l1.chainId); err != nil {
+			return nil, err
+		}
+	default:
+		rotation.funds = &rotation.l1
+	}
+
+	if rotation.BridgeId == "" {
+		serverAddress, _ := configFile.Get("server.address")
+		if rotation.BridgeId, err = queryBotBridgeId(role.Bot(), serverAddress); err != nil {
+			return nil, err
+		}
+	}
+	if rotation.bridge, err = cosmosutils.QueryBridge(rotation.l1.lcd, rotation.BridgeId); err != nil {
+		return nil, fmt.Errorf("failed to query bridge %s on %s: %v", rotation.BridgeId, rotation.l1.chainId, err)
+	}
+
+	if err = rotation.planUpdate(); err != nil {
+		return nil, err
+	}
+	if rotation.Resumed {
+		rotation.Notes = append(rotation.Notes, fmt.Sprintf("Resuming the rotation started at %s, the old key is kept in %s", rotation.state.StartedAt.Format(time.RFC3339), rotation.state.KeyringBackup))
+	}
+	if role == BridgeExecutorRole {
+		rotation.Notes = append(rotation.Notes, fmt.Sprintf("Only the L1 balance is moved, the L2 balance of %s stays with the old key, which is kept in the keyring backup", rotation.OldAddress))
+	}
+	return rotation, nil
+}
+
+// celestiaRotationChain is the Celestia network paired with the L1, where a batch submitter on Celestia pays its fees
+func celestiaRotationChain(l1ChainId string) (*rotationChain, error) {
+	celestiaRegistry, err := registry.GetCelestiaChainRegistry(l1ChainId)
+	if err != nil {
+		return nil, err
+	}
+	chain := &rotationChain{name: "Celestia", chainId: celestiaRegistry.GetChainId(), denom: minitia.DefaultCelestiaGasDenom}
+	if chain.rpc, err = celestiaRegistry.GetActiveRpc(); err != nil {
+		return nil, err
+	}
+	if chain.lcd, err = celestiaRegistry.GetActiveLcd(); err != nil {
+		return nil, err
+	}
+	if chain.gasPrice, err = celestiaRegistry.GetGasPriceByDenom(minitia.DefaultCelestiaGasDenom); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// planUpdate works out the message that makes the chains accept the new key. The L1 bridge roles are changed by the
+// current challenger (proposer and challenger) or proposer (batch submitter), the bridge executors by the rollup
+// operator through the opchild params.
+func (k *KeyRotation) planUpdate() error {
+	bridgeConfig := k.bridge.BridgeConfig
+	switch k.Role {
+	case OutputSubmitterRole:
+		return k.planBridgeUpdate("proposer", bridgeConfig.Proposer, ChallengerKeyName, bridgeConfig.Challenger, map[string]interface{}{
+			"@type":        "/opinit.ophost.v1.MsgUpdateProposer",
+			"bridge_id":    k.BridgeId,
+			"new_proposer": k.NewAddress,
+		})
+	case ChallengerRole:
+		return k.planBridgeUpdate("challenger", bridgeConfig.Challenger, ChallengerKeyName, bridgeConfig.Challenger, map[string]interface{}{
+			"@type":      "/opinit.ophost.v1.MsgUpdateChallenger",
+			"bridge_id":  k.BridgeId,
+			"challenger": k.NewAddress,
+		})
+	case BatchSubmitterRole:
+		return k.planBridgeUpdate("batch submitter", bridgeConfig.BatchInfo.Submitter, OutputSubmitterKeyName, bridgeConfig.Proposer, map[string]interface{}{
+			"@type":     "/opinit.ophost.v1.MsgUpdateBatchInfo",
+			"bridge_id": k.BridgeId,
+			"new_batch_info": types.BatchInfo{
+				Submitter: k.NewAddress,
+				ChainType: bridgeConfig.BatchInfo.ChainType,
+			},
+		})
+	case BridgeExecutorRole:
+		params, err := minitia.GetOPChildParams(k.minitiaHome)
+		if err != nil {
+			return fmt.Errorf("the bridge executors are updated by the rollup operator in %s: %v", k.minitiaHome, err)
+		}
+		if contains(params.BridgeExecutors, k.NewAddress) && !contains(params.BridgeExecutors, k.OldAddress) {
+			return nil
+		}
+		executors := replaceAddress(params.BridgeExecutors, k.OldAddress, k.NewAddress)
+		k.paramsUpdate = &minitia.OPChildParamsUpdate{BridgeExecutors: &executors}
+		k.Update = fmt.Sprintf("Set the opchild bridge executors to %s, signed by the rollup operator", strings.Join(executors, ", "))
+	case OracleBridgeExecutorRole:
+		k.Notes = append(k.Notes, "The bridge executor grants the new key the permission to relay oracle data")
+	}
+	return nil
+}
+
+// planBridgeUpdate plans an update of the bridge config on the L1 signed by signerKey of the OPinit keyring, which
+// must be the address the bridge authorizes
+func (k *KeyRotation) planBridgeUpdate(field, current, signerKey, authority string, msg map[string]interface{}) error {
+	if current == k.NewAddress {
+		return nil
+	}
+	if current != k.OldAddress {
+		return fmt.Errorf("the %s of bridge %s is %s, not the %s key %s", field, k.BridgeId, current, k.Role, k.OldAddress)
+	}
+	signer, err := cosmosutils.OPInitGetAddressForKey(k.binaryPath, signerKey, k.opInitHome)
+	if err != nil || signer != authority {
+		return fmt.Errorf("updating the %s of bridge %s must be signed by %s, whose key is not in the keyring of %s. Submit the update through governance instead", field, k.BridgeId, authority, k.opInitHome)
+	}
+	msg["authority"] = signer
+	k.signerKey = signerKey
+	k.updateMsg = msg
+	k.Update = fmt.Sprintf("Set the %s of bridge %s to %s, signed by %s", field, k.BridgeId, k.NewAddress, signer)
+	return nil
+}
+
+func (k *KeyRotation) String() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldText(fmt.Sprintf("Rotating the %s key of the OPinit %s\n", k.Role, k.Role.Bot()), styles.Cyan))
+	funding := "none"
+	if k.funds != nil && k.Amount != "0" {
+		funding = fmt.Sprintf("%s%s on %s from the %s", k.Amount, k.funds.denom, k.funds.name, k.FundFrom)
+	}
+	update := k.Update
+	if update == "" {
+		update = "none"
+	}
+	for _, field := range [][2]string{
+		{"Old key", k.OldAddress},
+		{"New key", k.NewAddress},
+		{"Funding", funding},
+		{"On-chain update", update},
+	} {
+		b.WriteString(fmt.Sprintf("  %-16s %s\n", field[0]+":", styles.BoldText(field[1], styles.White)))
+	}
+	for _, note := range k.Notes {
+		b.WriteString(styles.Text(fmt.Sprintf("  %s\n", note), styles.Yellow))
+	}
+	return b.String()
+}
+
+// Run funds the new key, applies the on-chain update, swaps the key in the OPinit keyring, restarts the bot and
+// drains what is left on the old key to the new one. Each step reports to progress when it is done. The keyring with
+// the old key is first copied to a backup of its own, and the steps are recorded as they complete, so that running
+// the rotation again after a failure resumes it.
+func (k *KeyRotation) Run(progress func(string)) error {
+	weaveDummyKeyPath := filepath.Join(k.opInitHome, "weave-dummy")
+	if k.state == nil {
+		k.state = &rotationState{
+			Role:          k.Role,
+			OldAddress:    k.OldAddress,
+			NewAddress:    k.NewAddress,
+			Mnemonic:      k.Mnemonic,
+			Amount:        k.Amount,
+			FundFrom:      k.FundFrom,
+			BridgeId:      k.BridgeId,
+			KeyringBackup: filepath.Join(k.opInitHome, fmt.Sprintf("weave-dummy.rotated-%s-%s", k.Role, time.Now().UTC().Format("20060102T150405Z"))),
+			StartedAt:     time.Now().UTC(),
+		}
+		// the backup keeps the old key until its funds are drained
+		if err := io.CopyDirectory(weaveDummyKeyPath, k.state.KeyringBackup); err != nil {
+			return fmt.Errorf("failed to back up the keyring: %v", err)
+		}
+		if err := k.state.save(k.opInitHome); err != nil {
+			return err
+		}
+	}
+	oldKeyringPath := k.state.KeyringBackup
+
+	var l1Executor, fundsExecutor *cosmosutils.InitiadTxExecutor
+	var err error
+	if (k.funds != nil && !k.isCelestia) || k.updateMsg != nil {
+		if l1Executor, err = cosmosutils.NewInitiadTxExecutor(k.l1.lcd); err != nil {
+			return err
+		}
+		fundsExecutor = l1Executor
+	}
+	celestiaBinaryPath := ""
+	if k.funds != nil && k.isCelestia {
+		if celestiaBinaryPath, err = minitia.InstallCelestiaBinary(k.l1.chainId); err != nil {
+			return err
+		}
+		fundsExecutor = cosmosutils.NewInitiadTxExecutorFromBinary(celestiaBinaryPath)
+	}
+
+	if err = k.step(rotationStepFunded, func() error {
+		if k.funds == nil || k.Amount == "0" {
+			return nil
+		}
+		return k.fund(fundsExecutor, celestiaBinaryPath, oldKeyringPath, progress)
+	}); err != nil {
+		return err
+	}
+
+	if err = k.step(rotationStepUpdated, func() error {
+		if k.updateMsg != nil {
+			txResponse, err := k.sendFromKeyring(l1Executor, k.l1, weaveDummyKeyPath, k.signerKey, []map[string]interface{}{k.updateMsg})
+			if err != nil {
+				return fmt.Errorf("failed to update bridge %s: %v", k.BridgeId, err)
+			}
+			progress(fmt.Sprintf("%s (tx %s)", k.Update, txResponse.TxHash))
+		}
+		if k.paramsUpdate != nil {
+			messagesPath, err := minitia.CreateParamsMessagesFile()
+			if err != nil {
+				return err
+			}
+			defer os.Remove(messagesPath)
+			current, _, err := minitia.BuildOPChildUpdateParamsMsg(k.minitiaHome, messagesPath, *k.paramsUpdate)
+			if err != nil {
+				return err
+			}
+			txHash, err := minitia.UpdateOPChildParams(k.minitiaHome, messagesPath, current, *k.paramsUpdate)
+			if err != nil {
+				return err
+			}
+			progress(fmt.Sprintf("%s (tx %s)", k.Update, txHash))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err = k.step(rotationStepKeySwapped, func() error {
+		if _, err := cosmosutils.OPInitRecoverKeyFromMnemonic(k.binaryPath, k.Role.KeyName(), k.Mnemonic, k.isCelestia, k.opInitHome); err != nil {
+			return err
+		}
+		for _, chainId := range k.chainIds {
+			if err := io.CopyDirectory(weaveDummyKeyPath, filepath.Join(k.opInitHome, chainId)); err != nil {
+				return fmt.Errorf("failed to copy the keyring for %s: %v", chainId, err)
+			}
+		}
+		progress(fmt.Sprintf("Replaced the %s key in the keyring of %s", k.Role.KeyName(), k.opInitHome))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err = k.step(rotationStepRestarted, func() error {
+		if k.Role == BridgeExecutorRole || k.Role == OracleBridgeExecutorRole {
+			if err := k.grantOracle(); err != nil {
+				return err
+			}
+		}
+		if err := RestartBotService(k.Role.Bot()); err != nil {
+			return err
+		}
+		progress(fmt.Sprintf("Restarted the OPinit %s bot", k.Role.Bot()))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err = k.step(rotationStepDrained, func() error {
+		if k.funds == nil {
+			return nil
+		}
+		return k.drain(fundsExecutor, oldKeyringPath, progress)
+	}); err != nil {
+		return fmt.Errorf("%v. The old key is kept in %s", err, oldKeyringPath)
+	}
+
+	if err = removeRotationState(k.opInitHome, k.Role); err != nil {
+		return err
+	}
+	if k.Role == BridgeExecutorRole {
+		progress(fmt.Sprintf("The old key is kept in %s for its L2 balance", oldKeyringPath))
+		return nil
+	}
+	if err = os.RemoveAll(oldKeyringPath); err != nil {
+		return fmt.Errorf("failed to remove %s: %v", oldKeyringPath, err)
+	}
+	return nil
+}
+
+// step runs fn unless the step was completed by an earlier run, and records it as completed
+func (k *KeyRotation) step(step rotationStep, fn func() error) error {
+	if k.state.isDone(step) {
+		return nil
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	k.state.complete(step)
+	return k.state.save(k.opInitHome)
+}
+
+func (k *KeyRotation) fund(executor *cosmosutils.InitiadTxExecutor, celestiaBinaryPath, oldKeyringPath string, progress func(string)) error {
+	chain := k.funds
+	// a rotation run again does not fund the new key twice
+	if balances, err := cosmosutils.QueryBankBalances(chain.lcd, k.NewAddress); err == nil && hasAtLeast(*balances, cosmosutils.Coin{Denom: chain.denom, Amount: k.Amount}) {
+		progress(fmt.Sprintf("%s already holds %s%s", k.NewAddress, k.Amount, chain.denom))
+		return nil
+	}
+
+	amount := cosmosutils.Coins{{Denom: chain.denom, Amount: k.Amount}}
+	var txResponse *cosmosutils.InitiadTxResponse
+	var err error
+	switch {
+	case k.FundFrom == FundFromOldKey:
+		txResponse, err = k.sendFromKeyring(executor, *chain, oldKeyringPath, k.Role.KeyName(), []map[string]interface{}{
+			cosmosutils.NewMsgSend(k.OldAddress, k.NewAddress, amount),
+		})
+	case celestiaBinaryPath != "":
+		txResponse, err = minitia.FundOnCelestia(celestiaBinaryPath, k.l1.chainId, k.NewAddress, k.Amount, func(string) error { return nil })
+	default:
+		gasStationMnemonic := config.GetGasStationMnemonic()
+		if gasStationMnemonic == "" {
+			return fmt.Errorf("no gas station is set up, run `weave gas-station setup` or fund the new key from the old key")
+		}
+		txResponse, err = executor.BroadcastMsgSend(gasStationMnemonic, k.NewAddress, k.Amount+chain.denom, chain.gasPrice, chain.rpc, chain.chainId)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fund the new %s key: %v", k.Role, err)
+	}
+	progress(fmt.Sprintf("Funded %s with %s%s on %s from the %s (tx %s)", k.NewAddress, k.Amount, chain.denom, chain.name, k.FundFrom, txResponse.TxHash))
+	return nil
+}
+
+func (k *KeyRotation) drain(executor *cosmosutils.InitiadTxExecutor, oldKeyringPath string, progress func(string)) error {
+	chain := k.funds
+	balances, err := cosmosutils.QueryBankBalances(chain.lcd, k.OldAddress)
+	if err != nil {
+		return fmt.Errorf("failed to query the balances of %s: %v", k.OldAddress, err)
+	}
+	fee, err := txFee(chain.gasPrice, rotationGasLimit)
+	if err != nil {
+		return err
+	}
+	leftover := drainableCoins(*balances, fee)
+	if len(leftover) == 0 {
+		progress(fmt.Sprintf("Nothing is left to drain from %s", k.OldAddress))
+		return nil
+	}
+	txResponse, err := k.sendFromKeyring(executor, *chain, oldKeyringPath, k.Role.KeyName(), []map[string]interface{}{
+		cosmosutils.NewMsgSend(k.OldAddress, k.NewAddress, leftover),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to drain %s: %v", k.OldAddress, err)
+	}
+	progress(fmt.Sprintf("Drained %s from %s to %s on %s (tx %s)", renderCoins(leftover), k.OldAddress, k.NewAddress, chain.name, txResponse.TxHash))
+	return nil
+}
+
+func (k *KeyRotation) sendFromKeyring(executor *cosmosutils.InitiadTxExecutor, chain rotationChain, keyringPath, keyName string, messages []map[string]interface{}) (*cosmosutils.InitiadTxResponse, error) {
+	fee, err := txFee(chain.gasPrice, rotationGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home dir: %v", err)
+	}
+	txPath := filepath.Join(userHome, common.WeaveDataDirectory, rotationTxFilename)
+	if err = cosmosutils.WriteUnsignedTx(txPath, messages, fee, rotationGasLimit); err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = io.DeleteFile(txPath)
+	}()
+	return executor.SignAndBroadcastFromKeyring(txPath, cosmosutils.TestKeyring(keyringPath), keyName, chain.rpc, chain.chainId)
+}
+
+// grantOracle lets the oracle bridge executor relay oracle data with the fee grant of the bridge executor. It is
+// required again whenever either key changes.
+func (k *KeyRotation) grantOracle() error {
+	if !cosmosutils.OPInitKeyExist(k.binaryPath, OracleBridgeExecutorKeyName, k.opInitHome) {
+		return nil
+	}
+	address, err := cosmosutils.OPInitGetAddressForKey(k.binaryPath, OracleBridgeExecutorKeyName, k.opInitHome)
+	if err != nil {
+		return err
+	}
+	if err = cosmosutils.OPInitGrantOracle(k.binaryPath, address, k.opInitHome); err != nil && k.bridge.BridgeConfig.OracleEnabled {
+		return err
+	}
+	return nil
+}
+
+// queryBotBridgeId reads the bridge id from the HTTP API of the bot
+func queryBotBridgeId(bot, serverAddress string) (string, error) {
+	var res struct {
+		BridgeId uint64 `json:"bridge_id"`
+	}
+	server := botServerURL(bot, serverAddress)
+	if _, err := client.NewHTTPClient().Get(server, "/status", nil, &res); err != nil || res.BridgeId == 0 {
+		return "", fmt.Errorf("failed to query the bridge id from %s/status, start the %s or pass --bridge-id: %v", server, bot, err)
+	}
+	return fmt.Sprintf("%d", res.BridgeId), nil
+}
+
+// txFee returns the fee of gasLimit at gasPrice, rounded up
+func txFee(gasPrice string, gasLimit uint64) (cosmosutils.Coin, error) {
+	amount, denom, err := common.ParseDecCoin(gasPrice)
+	if err != nil {
+		return cosmosutils.Coin{}, fmt.Errorf("invalid gas price %s: %v", gasPrice, err)
+	}
+	price, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return cosmosutils.Coin{}, fmt.Errorf("invalid gas price: %s", gasPrice)
+	}
+	fee := new(big.Rat).Mul(price, new(big.Rat).SetInt64(int64(gasLimit)))
+	ceil := new(big.Int).Quo(fee.Num(), fee.Denom())
+	if new(big.Rat).SetInt(ceil).Cmp(fee) < 0 {
+		ceil.Add(ceil, big.NewInt(1))
+	}
+	return cosmosutils.Coin{Denom: denom, Amount: ceil.String()}, nil
+}
+
+// drainableCoins returns the balances left after paying fee, or nothing when the fee cannot be paid
+func drainableCoins(balances cosmosutils.Coins, fee cosmosutils.Coin) cosmosutils.Coins {
+	feeAmount, _ := new(big.Int).SetString(fee.Amount, 10)
+	var coins cosmosutils.Coins
+	paid := false
+	for _, coin := range balances {
+		amount, ok := new(big.Int).SetString(coin.Amount, 10)
+		if !ok {
+			continue
+		}
+		if coin.Denom == fee.Denom {
+			if amount.Cmp(feeAmount) < 0 {
+				return nil
+			}
+			amount.Sub(amount, feeAmount)
+			paid = true
+		}
+		if amount.Sign() > 0 {
+			coins = append(coins, cosmosutils.Coin{Denom: coin.Denom, Amount: amount.String()})
+		}
+	}
+	if !paid {
+		return nil
+	}
+	return coins
+}
+
+func hasAtLeast(balances cosmosutils.Coins, want cosmosutils.Coin) bool {
+	wantAmount, ok := new(big.Int).SetString(want.Amount, 10)
+	if !ok {
+		return false
+	}
+	for _, coin := range balances {
+		if amount, ok := new(big.Int).SetString(coin.Amount, 10); ok && coin.Denom == want.Denom {
+			return amount.Cmp(wantAmount) >= 0
+		}
+	}
+	return false
+}
+
+// replaceAddress returns addresses with oldAddress replaced by newAddress, adding newAddress when oldAddress is not
+// there
+func replaceAddress(addresses []string, oldAddress, newAddress string) []string {
+	replaced := []string{newAddress}
+	for _, address := range addresses {
+		if address != oldAddress && address != newAddress {
+			replaced = append(replaced, address)
+		}
+	}
+	return replaced
+}
+
+func renderCoins(coins cosmosutils.Coins) string {
+	var rendered []string
+	for _, coin := range coins {
+		rendered = append(rendered, coin.Amount+coin.Denom)
+	}
+	return strings.Join(rendered, ",")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type rotationStep string
+
+const (
+	rotationStepFunded     rotationStep = "funded"
+	rotationStepUpdated    rotationStep = "updated"
+	rotationStepKeySwapped rotationStep = "key_swapped"
+	rotationStepRestarted  rotationStep = "restarted"
+	rotationStepDrained    rotationStep = "drained"
+)
+
+// rotationState records an unfinished key rotation, so that running the rotation of the role again resumes it. It
+// holds the mnemonic of the new key, so the file is only readable by the current user and is deleted once the
+// rotation completes.
+type rotationState struct {
+	Role       KeyRole    `json:"role"`
+	OldAddress string     `json:"old_address"`
+	NewAddress string     `json:"new_address"`
+	Mnemonic   string     `json:"mnemonic"`
+	Amount     string     `json:"amount"`
+	FundFrom   FundSource `json:"fund_from"`
+	BridgeId   string     `json:"bridge_id"`
+	// KeyringBackup is the copy of the keyring made before anything changed, it holds the old key
+	KeyringBackup  string         `json:"keyring_backup"`
+	CompletedSteps []rotationStep `json:"completed_steps"`
+	StartedAt      time.Time      `json:"started_at"`
+}
+
+func rotationStatePath(opInitHome string, role KeyRole) string {
+	return filepath.Join(opInitHome, fmt.Sprintf("weave-rotation.%s.json", role))
+}
+
+// loadRotationState reads the state of an unfinished rotation of role, or returns nil when there is none
+func loadRotationState(opInitHome string, role KeyRole) (*rotationState, error) {
+	path := rotationStatePath(opInitHome, role)
+	bz, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	var state rotationState
+	if err = json.Unmarshal(bz, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if state.Role != role || state.Mnemonic == "" || state.OldAddress == "" || state.KeyringBackup == "" {
+		return nil, fmt.Errorf("rotation state %s is incomplete", path)
+	}
+	return &state, nil
+}
+
+func (s *rotationState) save(opInitHome string) error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the rotation state: %v", err)
+	}
+	if err = io.WriteSecretFile(rotationStatePath(opInitHome, s.Role), bz); err != nil {
+		return fmt.Errorf("failed to write the rotation state, the rotation cannot be resumed: %v", err)
+	}
+	return nil
+}
+
+func (s *rotationState) isDone(step rotationStep) bool {
+	return slices.Contains(s.CompletedSteps, step)
+}
+
+func (s *rotationState) complete(step rotationStep) {
+	if !s.isDone(step) {
+		s.CompletedSteps = append(s.CompletedSteps, step)
+	}
+}
+
+// removeRotationState removes the state of a completed rotation, overwriting the mnemonic it holds
+func removeRotationState(opInitHome string, role KeyRole) error {
+	path := rotationStatePath(opInitHome, role)
+	if err := io.SecureDelete(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package opinit_bots
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/types"
+)
+
+func TestParseKeyRole(t *testing.T) {
+	role, err := ParseKeyRole("output-submitter")
+	assert.NoError(t, err)
+	assert.Equal(t, OutputSubmitterRole, role)
+	assert.Equal(t, "executor", role.Bot())
+	assert.Equal(t, OutputSubmitterKeyName, role.KeyName())
+
+	role, err = ParseKeyRole("challenger")
+	assert.NoError(t, err)
+	assert.Equal(t, "challenger", role.Bot())
+	assert.Equal(t, ChallengerKeyName, role.KeyName())
+
+	assert.Equal(t, "0", OracleBridgeExecutorRole.DefaultFunding())
+
+	_, err = ParseKeyRole("executor")
+	assert.ErrorContains(t, err, "Valid options are: [bridge-executor, output-submitter, batch-submitter, challenger, oracle-bridge-executor]")
+}
+
+func TestTxFee(t *testing.T) {
+	fee, err := txFee("0.015uinit", 300000)
+	assert.NoError(t, err)
+	assert.Equal(t, cosmosutils.Coin{Denom: "uinit", Amount: "4500"}, fee)
+
+	fee, err = txFee("0.0151uinit", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", fee.Amount)
+
+	_, err = txFee("uinit", 100)
+	assert.Error(t, err)
+}
+
+func TestDrainableCoins(t *testing.T) {
+	fee := cosmosutils.Coin{Denom: "uinit", Amount: "4500"}
+	assert.Equal(t, cosmosutils.Coins{{Denom: "uinit", Amount: "95500"}, {Denom: "uusdc", Amount: "10"}},
+		drainableCoins(cosmosutils.Coins{{Denom: "uinit", Amount: "100000"}, {Denom: "uusdc", Amount: "10"}}, fee))
+	assert.Equal(t, cosmosutils.Coins{{Denom: "uusdc", Amount: "10"}},
+		drainableCoins(cosmosutils.Coins{{Denom: "uinit", Amount: "4500"}, {Denom: "uusdc", Amount: "10"}}, fee))
+	assert.Nil(t, drainableCoins(cosmosutils.Coins{{Denom: "uinit", Amount: "4000"}}, fee))
+	assert.Nil(t, drainableCoins(cosmosutils.Coins{{Denom: "uusdc", Amount: "10"}}, fee))
+	assert.Nil(t, drainableCoins(cosmosutils.Coins{}, fee))
+}
+
+func TestHasAtLeast(t *testing.T) {
+	balances := cosmosutils.Coins{{Denom: "uinit", Amount: "2000000"}}
+	assert.True(t, hasAtLeast(balances, cosmosutils.Coin{Denom: "uinit", Amount: "2000000"}))
+	assert.False(t, hasAtLeast(balances, cosmosutils.Coin{Denom: "uinit", Amount: "2000001"}))
+	assert.False(t, hasAtLeast(balances, cosmosutils.Coin{Denom: "uusdc", Amount: "1"}))
+}
+
+func TestReplaceAddress(t *testing.T) {
+	assert.Equal(t, []string{"init1new", "init1other"}, replaceAddress([]string{"init1old", "init1other"}, "init1old", "init1new"))
+	assert.Equal(t, []string{"init1new", "init1other"}, replaceAddress([]string{"init1other"}, "init1old", "init1new"))
+	assert.Equal(t, []string{"init1new"}, replaceAddress([]string{"init1new", "init1old"}, "init1old", "init1new"))
+}
+
+func TestPlanBridgeUpdate(t *testing.T) {
+	rotation := &KeyRotation{
+		Role:       OutputSubmitterRole,
+		OldAddress: "init1old",
+		NewAddress: "init1new",
+		BridgeId:   "1",
+		bridge:     &types.Bridge{BridgeConfig: types.BridgeConfig{Proposer: "init1new", Challenger: "init1challenger"}},
+	}
+	// the proposer was already updated by an earlier run
+	assert.NoError(t, rotation.planUpdate())
+	assert.Nil(t, rotation.updateMsg)
+	assert.Empty(t, rotation.Update)
+
+	rotation.bridge.BridgeConfig.Proposer = "init1someone"
+	assert.ErrorContains(t, rotation.planUpdate(), "the proposer of bridge 1 is init1someone, not the output-submitter key init1old")
+}
+
+func TestRotationState(t *testing.T) {
+	home := t.TempDir()
+	state, err := loadRotationState(home, ChallengerRole)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	rotation := &KeyRotation{Role: ChallengerRole, opInitHome: home, state: &rotationState{
+		Role:          ChallengerRole,
+		OldAddress:    "init1old",
+		NewAddress:    "init1new",
+		Mnemonic:      "new mnemonic",
+		KeyringBackup: filepath.Join(home, "weave-dummy.rotated-challenger-20240501T000000Z"),
+	}}
+	assert.NoError(t, rotation.step(rotationStepFunded, func() error { return nil }))
+	assert.ErrorContains(t, rotation.step(rotationStepUpdated, func() error { return errors.New("out of gas") }), "out of gas")
+
+	info, err := os.Stat(rotationStatePath(home, ChallengerRole))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// a resumed rotation skips the steps that are done
+	state, err = loadRotationState(home, ChallengerRole)
+	assert.NoError(t, err)
+	assert.Equal(t, []rotationStep{rotationStepFunded}, state.CompletedSteps)
+	resumed := &KeyRotation{Role: ChallengerRole, opInitHome: home, state: state}
+	assert.NoError(t, resumed.step(rotationStepFunded, func() error { return errors.New("funded twice") }))
+
+	assert.NoError(t, removeRotationState(home, ChallengerRole))
+	state, err = loadRotationState(home, ChallengerRole)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	assert.NoError(t, os.WriteFile(rotationStatePath(home, ChallengerRole), []byte(`{"role": "challenger"}`), 0600))
+	_, err = loadRotationState(home, ChallengerRole)
+	assert.ErrorContains(t, err, "is incomplete")
+}
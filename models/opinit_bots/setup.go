@@ -579,7 +579,7 @@ func getBinaryURL(version, os, arch string) (string, error) {
 }
 
 func GetBinaryPath(userHome string) string {
-	return filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion), AppName)
+	return filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", CurrentBotVersion()), AppName)
 }
 
 func EnsureOPInitBotsBinary(ctx context.Context) tea.Cmd {
@@ -602,12 +602,12 @@ func EnsureOPInitBotsBinary(ctx context.Context) tea.Cmd {
 
 		goos := runtime.GOOS
 		goarch := runtime.GOARCH
-		url, err := getBinaryURL(OpinitBotBinaryVersion, goos, goarch)
+		url, err := getBinaryURL(CurrentBotVersion(), goos, goarch)
 		if err != nil {
 			return ui.NonRetryableErrorLoading{Err: fmt.Errorf("failed to get binary url: %v", err)}
 		}
 
-		extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("opinitd@%s", OpinitBotBinaryVersion))
+		extractedPath := filepath.Join(weaveDataPath, fmt.Sprintf("opinitd@%s", CurrentBotVersion()))
 
 		if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
 
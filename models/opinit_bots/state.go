@@ -27,6 +27,10 @@ type OPInitBotsState struct {
 	AddMinitiaConfig     bool
 	UsePrefilledMinitia  bool
 	L1StartHeight        int
+
+	// configFile and configValues hold the config being edited by `weave opinit config edit` and the new values
+	configFile   *BotConfigFile
+	configValues map[string]string
 }
 
 // NewOPInitBotsState initializes OPInitBotsState with default values
@@ -69,6 +73,8 @@ func (state OPInitBotsState) Clone() OPInitBotsState {
 		isDeleteDB:           state.isDeleteDB,
 		AddMinitiaConfig:     state.AddMinitiaConfig,
 		L1StartHeight:        state.L1StartHeight,
+		configFile:           state.configFile,
+		configValues:         make(map[string]string),
 	}
 
 	if state.MinitiaConfig != nil {
@@ -85,6 +91,9 @@ func (state OPInitBotsState) Clone() OPInitBotsState {
 	for k, v := range state.botConfig {
 		clone.botConfig[k] = v
 	}
+	for k, v := range state.configValues {
+		clone.configValues[k] = v
+	}
 
 	return clone
 }
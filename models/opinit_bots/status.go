@@ -0,0 +1,511 @@
+package opinit_bots
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+)
+
+// The parts of the status served by the HTTP API of the executor and challenger that weave reports on
+type apiNodeStatus struct {
+	LastBlockHeight int64 `json:"last_block_height"`
+	Broadcaster     *struct {
+		PendingTxs int64 `json:"pending_txs"`
+	} `json:"broadcaster,omitempty"`
+}
+
+type apiChildStatus struct {
+	Node                              apiNodeStatus    `json:"node"`
+	LastFinalizedDepositL1BlockHeight int64            `json:"last_finalized_deposit_l1_block_height"`
+	LastFinalizedDepositL1Sequence    uint64           `json:"last_finalized_deposit_l1_sequence"`
+	LastWithdrawalL2Sequence          uint64           `json:"last_withdrawal_l2_sequence"`
+	LastOutputSubmissionTime          time.Time        `json:"last_output_submission_time"`
+	NextOutputSubmissionTime          time.Time        `json:"next_output_submission_time"`
+	NumPendingEvents                  map[string]int64 `json:"num_pending_events,omitempty"`
+}
+
+type executorAPIStatus struct {
+	BridgeId uint64 `json:"bridge_id"`
+	Host     struct {
+		Node                            apiNodeStatus `json:"node"`
+		LastProposedOutputIndex         uint64        `json:"last_proposed_output_index"`
+		LastProposedOutputL2BlockNumber int64         `json:"last_proposed_output_l2_block_number"`
+	} `json:"host"`
+	Child          apiChildStatus `json:"child"`
+	BatchSubmitter *struct {
+		Node                    apiNodeStatus `json:"node"`
+		BatchStartBlockNumber   int64         `json:"batch_start_block_number"`
+		BatchEndBlockNumber     int64         `json:"batch_end_block_number"`
+		LastBatchSubmissionTime time.Time     `json:"last_batch_submission_time"`
+	} `json:"batch_submitter,omitempty"`
+	DA *struct {
+		Node apiNodeStatus `json:"node"`
+	} `json:"da,omitempty"`
+}
+
+type challengerAPIStatus struct {
+	BridgeId uint64 `json:"bridge_id"`
+	Host     struct {
+		Node             apiNodeStatus    `json:"node"`
+		LastOutputIndex  uint64           `json:"last_output_index"`
+		LastOutputTime   time.Time        `json:"last_output_time"`
+		NumPendingEvents map[string]int64 `json:"num_pending_events,omitempty"`
+	} `json:"host"`
+	Child            apiChildStatus    `json:"child"`
+	LatestChallenges []ChallengeStatus `json:"latest_challenges"`
+}
+
+// BotStatus is the sync and relay progress of an OPinit bot, gathered from its HTTP API and the chains it talks to
+type BotStatus struct {
+	Bot                string            `json:"bot"`
+	Server             string            `json:"server"`
+	BridgeId           uint64            `json:"bridge_id,omitempty"`
+	L1                 *ChainSyncStatus  `json:"l1,omitempty"`
+	L2                 *ChainSyncStatus  `json:"l2,omitempty"`
+	LastOutput         *OutputStatus     `json:"last_output,omitempty"`
+	NextOutput         *time.Time        `json:"next_output_submission_time,omitempty"`
+	Batch              *BatchStatus      `json:"batch,omitempty"`
+	PendingWithdrawals *uint64           `json:"pending_withdrawals,omitempty"`
+	Deposits           *DepositStatus    `json:"deposits,omitempty"`
+	PendingEvents      map[string]int64  `json:"pending_events,omitempty"`
+	LatestChallenges   []ChallengeStatus `json:"latest_challenges,omitempty"`
+	Warnings           []string          `json:"warnings,omitempty"`
+	Error              string            `json:"error,omitempty"`
+}
+
+// ChainSyncStatus compares the height a bot has synced on a chain with the tip of that chain
+type ChainSyncStatus struct {
+	ChainId      string `json:"chain_id"`
+	SyncedHeight int64  `json:"synced_height"`
+	LatestHeight int64  `json:"latest_height,omitempty"`
+	Behind       int64  `json:"behind,omitempty"`
+	PendingTxs   int64  `json:"pending_txs,omitempty"`
+}
+
+type OutputStatus struct {
+	OutputIndex   uint64     `json:"output_index"`
+	L2BlockNumber int64      `json:"l2_block_number,omitempty"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+	Finalized     *bool      `json:"finalized,omitempty"`
+	FinalizesAt   *time.Time `json:"finalizes_at,omitempty"`
+}
+
+// BatchStatus is the batch the executor is collecting but has not submitted to the DA layer yet
+type BatchStatus struct {
+	StartL2Height      int64      `json:"start_l2_height"`
+	EndL2Height        int64      `json:"end_l2_height"`
+	LastSubmissionTime *time.Time `json:"last_submission_time,omitempty"`
+	PendingDATxs       int64      `json:"pending_da_txs"`
+}
+
+// DepositStatus is how far the relay of L1 deposits to the rollup is behind the L1
+type DepositStatus struct {
+	LastRelayedSequence uint64  `json:"last_relayed_sequence"`
+	LastRelayedL1Height int64   `json:"last_relayed_l1_height,omitempty"`
+	LatestSequence      *uint64 `json:"latest_sequence,omitempty"`
+	Pending             *uint64 `json:"pending,omitempty"`
+}
+
+type ChallengeStatus struct {
+	EventType string    `json:"event_type"`
+	Log       string    `json:"log"`
+	Time      time.Time `json:"timestamp"`
+}
+
+type botStatusConfig struct {
+	Server ServerConfig `json:"server"`
+	L1Node NodeConfig   `json:"l1_node"`
+	L2Node NodeConfig   `json:"l2_node"`
+}
+
+// ConfiguredBots returns the bots that have a config in opInitHome
+func ConfiguredBots(opInitHome string) []string {
+	var bots []string
+	for _, bot := range []string{"executor", "challenger"} {
+		if _, err := os.Stat(filepath.Join(opInitHome, bot+".json")); err == nil {
+			bots = append(bots, bot)
+		}
+	}
+	return bots
+}
+
+// GetBotStatus queries the HTTP API of the bot configured in opInitHome, the tips of its chains and the bridge on the
+// L1. A bot that cannot be reached is reported in Error, other failing queries are reported as warnings.
+func GetBotStatus(opInitHome, bot string) (*BotStatus, error) {
+	configPath := filepath.Join(opInitHome, bot+".json")
+	bz, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s, run `weave opinit init %s` first: %v", configPath, bot, err)
+	}
+	var config botStatusConfig
+	if err = json.Unmarshal(bz, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", configPath, err)
+	}
+
+	var l1Lcd string
+	var lcdErr error
+	if l1Registry, err := registry.GetL1ChainRegistry(config.L1Node.ChainID); err != nil {
+		lcdErr = err
+	} else if l1Lcd, err = l1Registry.GetActiveLcd(); err != nil {
+		lcdErr = err
+	}
+
+	status := collectBotStatus(bot, config, l1Lcd)
+	if lcdErr != nil && status.Error == "" {
+		status.warn("failed to reach the REST API of the L1 %s, the output finalization and pending deposits are unknown: %v", config.L1Node.ChainID, lcdErr)
+	}
+	return status, nil
+}
+
+// ChallengerActivity is what the HTTP API of the challenger tells about the outputs it checked and the challenges it
+// made, without querying the chains
+type ChallengerActivity struct {
+	Server           string
+	L1ChainId        string
+	BridgeId         uint64
+	LastOutputIndex  uint64
+	LatestChallenges []ChallengeStatus
+}
+
+// QueryChallengerActivity queries the HTTP API of the challenger configured in opInitHome
+func QueryChallengerActivity(opInitHome string) (*ChallengerActivity, error) {
+	config, err := loadDBBotConfig(opInitHome, "challenger")
+	if err != nil {
+		return nil, err
+	}
+	activity := &ChallengerActivity{Server: botServerURL("challenger", config.Server.Address), L1ChainId: config.L1Node.ChainID}
+	var res challengerAPIStatus
+	if _, err = client.NewHTTPClient().Get(activity.Server, "/status", nil, &res); err != nil {
+		return activity, fmt.Errorf("failed to query %s/status, is the challenger running? %v", activity.Server, err)
+	}
+	activity.BridgeId = res.BridgeId
+	activity.LastOutputIndex = res.Host.LastOutputIndex
+	activity.LatestChallenges = res.LatestChallenges
+	return activity, nil
+}
+
+func collectBotStatus(bot string, config botStatusConfig, l1Lcd string) *BotStatus {
+	status := &BotStatus{Bot: bot, Server: botServerURL(bot, config.Server.Address)}
+	httpClient := client.NewHTTPClient()
+
+	var child apiChildStatus
+	var lastOutputIndex uint64
+	switch bot {
+	case "executor":
+		var res executorAPIStatus
+		if _, err := httpClient.Get(status.Server, "/status", nil, &res); err != nil {
+			status.Error = fmt.Sprintf("failed to query %s/status, is the executor running? %v", status.Server, err)
+			return status
+		}
+		status.BridgeId = res.BridgeId
+		child = res.Child
+		lastOutputIndex = res.Host.LastProposedOutputIndex
+		status.L1 = newChainSyncStatus(config.L1Node.ChainID, res.Host.Node)
+		if lastOutputIndex > 0 {
+			status.LastOutput = &OutputStatus{OutputIndex: lastOutputIndex, L2BlockNumber: res.Host.LastProposedOutputL2BlockNumber}
+		}
+		if !child.NextOutputSubmissionTime.IsZero() {
+			status.NextOutput = &child.NextOutputSubmissionTime
+		}
+		if res.BatchSubmitter != nil {
+			status.Batch = &BatchStatus{
+				StartL2Height: res.BatchSubmitter.BatchStartBlockNumber,
+				EndL2Height:   res.BatchSubmitter.BatchEndBlockNumber,
+			}
+			if !res.BatchSubmitter.LastBatchSubmissionTime.IsZero() {
+				status.Batch.LastSubmissionTime = &res.BatchSubmitter.LastBatchSubmissionTime
+			}
+			if res.DA != nil && res.DA.Node.Broadcaster != nil {
+				status.Batch.PendingDATxs = res.DA.Node.Broadcaster.PendingTxs
+			}
+		}
+	case "challenger":
+		var res challengerAPIStatus
+		if _, err := httpClient.Get(status.Server, "/status", nil, &res); err != nil {
+			status.Error = fmt.Sprintf("failed to query %s/status, is the challenger running? %v", status.Server, err)
+			return status
+		}
+		status.BridgeId = res.BridgeId
+		child = res.Child
+		lastOutputIndex = res.Host.LastOutputIndex
+		status.L1 = newChainSyncStatus(config.L1Node.ChainID, res.Host.Node)
+		if lastOutputIndex > 0 {
+			status.LastOutput = &OutputStatus{OutputIndex: lastOutputIndex}
+			if !res.Host.LastOutputTime.IsZero() {
+				status.LastOutput.SubmittedAt = &res.Host.LastOutputTime
+			}
+		}
+		status.PendingEvents = map[string]int64{}
+		for event, count := range res.Host.NumPendingEvents {
+			status.PendingEvents["L1 "+event] = count
+		}
+		for event, count := range child.NumPendingEvents {
+			status.PendingEvents["L2 "+event] = count
+		}
+		status.LatestChallenges = res.LatestChallenges
+	default:
+		status.Error = fmt.Sprintf("unsupported bot name: %s", bot)
+		return status
+	}
+	status.L2 = newChainSyncStatus(config.L2Node.ChainID, child.Node)
+	status.Deposits = &DepositStatus{
+		LastRelayedSequence: child.LastFinalizedDepositL1Sequence,
+		LastRelayedL1Height: child.LastFinalizedDepositL1BlockHeight,
+	}
+
+	status.queryChainTip(status.L1, config.L1Node.RPCAddress)
+	status.queryChainTip(status.L2, config.L2Node.RPCAddress)
+
+	if l1Lcd == "" || status.BridgeId == 0 {
+		return status
+	}
+	bridgeId := strconv.FormatUint(status.BridgeId, 10)
+
+	if nextL1Sequence, err := cosmosutils.QueryNextL1Sequence(l1Lcd, bridgeId); err != nil {
+		status.warn("failed to query the next L1 sequence of bridge %s: %v", bridgeId, err)
+	} else if nextL1Sequence > 0 {
+		latest := nextL1Sequence - 1
+		pending := uint64(0)
+		if latest > child.LastFinalizedDepositL1Sequence {
+			pending = latest - child.LastFinalizedDepositL1Sequence
+		}
+		status.Deposits.LatestSequence = &latest
+		status.Deposits.Pending = &pending
+	}
+
+	if status.LastOutput == nil {
+		return status
+	}
+	finalizationPeriod, err := queryFinalizationPeriod(l1Lcd, bridgeId)
+	if err != nil {
+		status.warn("failed to query the finalization period of bridge %s: %v", bridgeId, err)
+		return status
+	}
+	isFinalized := func(outputIndex uint64) (bool, *time.Time, error) {
+		output, err := cosmosutils.QueryOutputProposal(l1Lcd, bridgeId, outputIndex)
+		if err != nil {
+			return false, nil, err
+		}
+		submittedAt, err := time.Parse(time.RFC3339Nano, output.OutputProposal.L1BlockTime)
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid L1 block time %q: %v", output.OutputProposal.L1BlockTime, err)
+		}
+		finalizesAt := submittedAt.Add(finalizationPeriod)
+		return !time.Now().Before(finalizesAt), &submittedAt, nil
+	}
+
+	finalized, submittedAt, err := isFinalized(lastOutputIndex)
+	if err != nil {
+		status.warn("failed to query output %d of bridge %s: %v", lastOutputIndex, bridgeId, err)
+		return status
+	}
+	finalizesAt := submittedAt.Add(finalizationPeriod)
+	status.LastOutput.SubmittedAt = submittedAt
+	status.LastOutput.Finalized = &finalized
+	status.LastOutput.FinalizesAt = &finalizesAt
+
+	if bot == "executor" {
+		status.queryPendingWithdrawals(child.LastWithdrawalL2Sequence, lastOutputIndex, finalized, isFinalized)
+	}
+	return status
+}
+
+// queryPendingWithdrawals counts the withdrawals that cannot be claimed on the L1 yet because their output is not
+// finalized. Outputs and withdrawals are both ordered, so the boundaries are found with binary searches.
+func (s *BotStatus) queryPendingWithdrawals(lastSequence, lastOutputIndex uint64, lastOutputFinalized bool, isFinalized func(uint64) (bool, *time.Time, error)) {
+	lastFinalizedOutput := lastOutputIndex
+	if !lastOutputFinalized {
+		firstUnfinalized, err := searchFirst(1, lastOutputIndex, func(outputIndex uint64) (bool, error) {
+			finalized, _, err := isFinalized(outputIndex)
+			return !finalized, err
+		})
+		if err != nil {
+			s.warn("failed to find the last finalized output: %v", err)
+			return
+		}
+		lastFinalizedOutput = firstUnfinalized - 1
+	}
+
+	httpClient := client.NewHTTPClient()
+	firstPending, err := searchFirst(1, lastSequence, func(sequence uint64) (bool, error) {
+		var withdrawal apiWithdrawal
+		if _, err := httpClient.Get(s.Server, fmt.Sprintf("/withdrawal/%d", sequence), nil, &withdrawal); err != nil {
+			return false, err
+		}
+		// the latest withdrawals are not in an output yet and report output index 0
+		return withdrawal.OutputIndex == 0 || withdrawal.OutputIndex > lastFinalizedOutput, nil
+	})
+	if err != nil {
+		s.warn("failed to query the withdrawals from the executor: %v", err)
+		return
+	}
+	pending := lastSequence - firstPending + 1
+	s.PendingWithdrawals = &pending
+}
+
+// searchFirst returns the first value in [lo, hi] for which pred holds, given pred never turns false again once it
+// holds. hi+1 is returned when it holds for none of them.
+func searchFirst(lo, hi uint64, pred func(uint64) (bool, error)) (uint64, error) {
+	end := hi + 1
+	for lo < end {
+		mid := lo + (end-lo)/2
+		ok, err := pred(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			end = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+func (s *BotStatus) queryChainTip(chain *ChainSyncStatus, rpc string) {
+	if rpc == "" {
+		return
+	}
+	height, err := cosmosutils.QueryLatestHeight(rpc)
+	if err != nil {
+		s.warn("failed to query the latest height of %s from %s: %v", chain.ChainId, rpc, err)
+		return
+	}
+	chain.LatestHeight = height
+	if height > chain.SyncedHeight {
+		chain.Behind = height - chain.SyncedHeight
+	}
+}
+
+func (s *BotStatus) warn(format string, args ...interface{}) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, args...))
+}
+
+func newChainSyncStatus(chainId string, node apiNodeStatus) *ChainSyncStatus {
+	status := &ChainSyncStatus{ChainId: chainId, SyncedHeight: node.LastBlockHeight}
+	if node.Broadcaster != nil {
+		status.PendingTxs = node.Broadcaster.PendingTxs
+	}
+	return status
+}
+
+func queryFinalizationPeriod(l1Lcd, bridgeId string) (time.Duration, error) {
+	bridge, err := cosmosutils.QueryBridge(l1Lcd, bridgeId)
+	if err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(bridge.BridgeConfig.FinalizationPeriod)
+}
+
+// defaultBotServers are the URLs of the bot servers at their default listen addresses
+var defaultBotServers = map[string]string{
+	"executor":   "http://localhost:3000",
+	"challenger": "http://localhost:3001",
+}
+
+// botServerURL turns the listen address of the bot server into a URL to query it
+func botServerURL(bot, address string) string {
+	return common.ListenAddressToEndpoint(address, "http://", defaultBotServers[bot])
+}
+
+func (s *BotStatus) String() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldText(fmt.Sprintf("OPinit %s\n", s.Bot), styles.Cyan))
+	b.WriteString(fmt.Sprintf("  %-20s %s\n", "Server:", s.Server))
+	if s.Error != "" {
+		b.WriteString("  " + styles.Text(s.Error, styles.Yellow) + "\n")
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("  %-20s %s\n", "Bridge ID:", styles.BoldText(strconv.FormatUint(s.BridgeId, 10), styles.White)))
+
+	for _, chain := range []struct {
+		name   string
+		status *ChainSyncStatus
+	}{{"L1", s.L1}, {"L2", s.L2}} {
+		if chain.status == nil {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", fmt.Sprintf("%s (%s):", chain.name, chain.status.ChainId), chain.status.String()))
+	}
+
+	if s.LastOutput == nil {
+		b.WriteString(fmt.Sprintf("  %-20s none\n", "Last output:"))
+	} else {
+		output := fmt.Sprintf("#%d", s.LastOutput.OutputIndex)
+		if s.LastOutput.L2BlockNumber > 0 {
+			output += fmt.Sprintf(" for L2 block %d", s.LastOutput.L2BlockNumber)
+		}
+		if s.LastOutput.SubmittedAt != nil {
+			output += fmt.Sprintf(", submitted %s", s.LastOutput.SubmittedAt.UTC().Format(time.RFC3339))
+		}
+		switch {
+		case s.LastOutput.Finalized == nil:
+		case *s.LastOutput.Finalized:
+			output += styles.Text(" (finalized)", styles.Gray)
+		default:
+			output += styles.Text(fmt.Sprintf(" (finalizes %s)", s.LastOutput.FinalizesAt.UTC().Format(time.RFC3339)), styles.Gray)
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Last output:", output))
+	}
+	if s.NextOutput != nil {
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Next output:", s.NextOutput.UTC().Format(time.RFC3339)))
+	}
+
+	if s.Batch != nil {
+		batch := fmt.Sprintf("L2 blocks %d-%d, %d pending DA txs", s.Batch.StartL2Height, s.Batch.EndL2Height, s.Batch.PendingDATxs)
+		if s.Batch.LastSubmissionTime != nil {
+			batch += styles.Text(fmt.Sprintf(" (last submitted %s)", s.Batch.LastSubmissionTime.UTC().Format(time.RFC3339)), styles.Gray)
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Pending batch:", batch))
+	}
+	if s.PendingWithdrawals != nil {
+		b.WriteString(fmt.Sprintf("  %-20s %d not finalized on L1\n", "Pending withdrawals:", *s.PendingWithdrawals))
+	}
+	if s.Deposits != nil {
+		deposits := fmt.Sprintf("relayed up to #%d", s.Deposits.LastRelayedSequence)
+		if s.Deposits.Pending != nil {
+			deposits = fmt.Sprintf("%d behind, %s of #%d", *s.Deposits.Pending, deposits, *s.Deposits.LatestSequence)
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", "Deposits:", deposits))
+	}
+	if s.Bot == "challenger" {
+		var pending int64
+		for _, count := range s.PendingEvents {
+			pending += count
+		}
+		b.WriteString(fmt.Sprintf("  %-20s %d\n", "Pending events:", pending))
+		b.WriteString(fmt.Sprintf("  %-20s %d\n", "Latest challenges:", len(s.LatestChallenges)))
+		for _, challenge := range s.LatestChallenges {
+			b.WriteString(fmt.Sprintf("    %s %s: %s\n", challenge.Time.UTC().Format(time.RFC3339), challenge.EventType, challenge.Log))
+		}
+	}
+
+	for _, warning := range s.Warnings {
+		b.WriteString(styles.Text("  i "+warning, styles.Yellow) + "\n")
+	}
+	return b.String()
+}
+
+func (c *ChainSyncStatus) String() string {
+	text := fmt.Sprintf("synced %d", c.SyncedHeight)
+	if c.LatestHeight > 0 {
+		text += fmt.Sprintf(" of %d", c.LatestHeight)
+		if c.Behind > 0 {
+			text += styles.Text(fmt.Sprintf(" (%d behind)", c.Behind), styles.Yellow)
+		}
+	}
+	if c.PendingTxs > 0 {
+		text += fmt.Sprintf(", %d pending txs", c.PendingTxs)
+	}
+	return text
+}
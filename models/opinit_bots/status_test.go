@@ -0,0 +1,139 @@
+package opinit_bots
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectExecutorStatus(t *testing.T) {
+	now := time.Now().UTC()
+	// outputs 1-3 are finalized, 4-5 are within the finalization period of an hour
+	outputTimes := map[string]time.Time{
+		"1": now.Add(-4 * time.Hour), "2": now.Add(-3 * time.Hour), "3": now.Add(-2 * time.Hour),
+		"4": now.Add(-30 * time.Minute), "5": now.Add(-10 * time.Minute),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/status":
+			_, _ = fmt.Fprint(w, `{
+  "bridge_id": 1,
+  "host": {"node": {"last_block_height": 990, "broadcaster": {"pending_txs": 1}}, "last_proposed_output_index": 5, "last_proposed_output_l2_block_number": 500},
+  "child": {"node": {"last_block_height": 600}, "last_finalized_deposit_l1_block_height": 980, "last_finalized_deposit_l1_sequence": 7, "last_withdrawal_l2_sequence": 10},
+  "batch_submitter": {"node": {"last_block_height": 600}, "batch_start_block_number": 581, "batch_end_block_number": 600},
+  "da": {"node": {"broadcaster": {"pending_txs": 2}}}
+}`)
+		case strings.HasPrefix(r.URL.Path, "/withdrawal/"):
+			// withdrawals 1-6 are in outputs up to 3, 7-10 in later outputs
+			sequence, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/withdrawal/"))
+			outputIndex := 3
+			if sequence > 6 {
+				outputIndex = 4
+			}
+			_, _ = fmt.Fprintf(w, `{"sequence": %d, "output_index": %d}`, sequence, outputIndex)
+		case r.URL.Path == "/abci_info":
+			_, _ = fmt.Fprint(w, `{"result": {"response": {"last_block_height": "1000"}}}`)
+		case r.URL.Path == "/opinit/ophost/v1/bridges/1":
+			_, _ = fmt.Fprint(w, `{"bridge_id": "1", "bridge_config": {"finalization_period": "3600s"}}`)
+		case r.URL.Path == "/opinit/ophost/v1/bridges/1/next_l1_sequence":
+			_, _ = fmt.Fprint(w, `{"next_l1_sequence": "10"}`)
+		case strings.HasPrefix(r.URL.Path, "/opinit/ophost/v1/bridges/1/outputs/"):
+			index := strings.TrimPrefix(r.URL.Path, "/opinit/ophost/v1/bridges/1/outputs/")
+			_, _ = fmt.Fprintf(w, `{"bridge_id": "1", "output_index": "%s", "output_proposal": {"l1_block_time": "%s"}}`, index, outputTimes[index].Format(time.RFC3339Nano))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := botStatusConfig{
+		Server: ServerConfig{Address: strings.TrimPrefix(server.URL, "http://")},
+		L1Node: NodeConfig{ChainID: "initiation-2", RPCAddress: server.URL},
+		L2Node: NodeConfig{ChainID: "minimove-1", RPCAddress: server.URL},
+	}
+	status := collectBotStatus("executor", config, server.URL)
+
+	assert.Empty(t, status.Error)
+	assert.Empty(t, status.Warnings)
+	assert.Equal(t, uint64(1), status.BridgeId)
+	assert.Equal(t, &ChainSyncStatus{ChainId: "initiation-2", SyncedHeight: 990, LatestHeight: 1000, Behind: 10, PendingTxs: 1}, status.L1)
+	assert.Equal(t, int64(400), status.L2.Behind)
+	assert.Equal(t, uint64(5), status.LastOutput.OutputIndex)
+	assert.False(t, *status.LastOutput.Finalized)
+	assert.Equal(t, int64(2), status.Batch.PendingDATxs)
+	assert.Equal(t, uint64(4), *status.PendingWithdrawals)
+	assert.Equal(t, uint64(2), *status.Deposits.Pending)
+
+	text := status.String()
+	assert.Contains(t, text, "synced 990 of 1000")
+	assert.Contains(t, text, "4 not finalized on L1")
+	assert.Contains(t, text, "2 behind, relayed up to #7 of #9")
+}
+
+func TestCollectStatusUnreachableBot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	status := collectBotStatus("challenger", botStatusConfig{Server: ServerConfig{Address: server.URL}}, "")
+	assert.Contains(t, status.Error, "is the challenger running?")
+	assert.Nil(t, status.L1)
+}
+
+func TestSearchFirst(t *testing.T) {
+	for _, tc := range []struct {
+		lo, hi, threshold, expected uint64
+	}{
+		{1, 10, 4, 4},
+		{1, 10, 1, 1},
+		{1, 10, 11, 11},
+		{1, 0, 1, 1},
+	} {
+		got, err := searchFirst(tc.lo, tc.hi, func(v uint64) (bool, error) { return v >= tc.threshold, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, got)
+	}
+}
+
+func TestQueryPendingWithdrawalsCountsWithdrawalsWithoutOutput(t *testing.T) {
+	// withdrawals 4 and 5 are not in an output yet
+	outputIndexes := []uint64{1, 1, 2, 0, 0}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sequence, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/withdrawal/"))
+		assert.NoError(t, err)
+		_, _ = fmt.Fprintf(w, `{"sequence": %d, "output_index": %d}`, sequence, outputIndexes[sequence-1])
+	}))
+	defer server.Close()
+
+	status := &BotStatus{Server: server.URL}
+	status.queryPendingWithdrawals(5, 2, true, nil)
+	assert.Empty(t, status.Warnings)
+	assert.Equal(t, uint64(2), *status.PendingWithdrawals)
+}
+
+func TestBotServerURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:3000", botServerURL("executor", "localhost:3000"))
+	assert.Equal(t, "http://localhost:3000", botServerURL("executor", "0.0.0.0:3000"))
+	assert.Equal(t, "http://localhost:3000", botServerURL("executor", ":3000"))
+	assert.Equal(t, "http://localhost:3001", botServerURL("challenger", "[::]:3001"))
+	assert.Equal(t, "http://[::1]:3000", botServerURL("executor", "[::1]:3000"))
+	assert.Equal(t, "http://10.0.0.5:3001", botServerURL("challenger", "tcp://10.0.0.5:3001"))
+	assert.Equal(t, "http://localhost:3001", botServerURL("challenger", ""))
+}
+
+func TestConfiguredBots(t *testing.T) {
+	home := t.TempDir()
+	assert.Empty(t, ConfiguredBots(home))
+	assert.NoError(t, os.WriteFile(filepath.Join(home, "challenger.json"), []byte("{}"), 0644))
+	assert.Equal(t, []string{"challenger"}, ConfiguredBots(home))
+}
@@ -0,0 +1,327 @@
+package opinit_bots
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+)
+
+const (
+	opinitBotsReleasesURL = "https://api.github.com/repos/initia-labs/opinit-bots/releases"
+	// opinitBotsSpecAtTagURL is the spec_version.json shipped with a release of the OPinit bots
+	opinitBotsSpecAtTagURL = "https://raw.githubusercontent.com/initia-labs/opinit-bots/refs/tags/%s/spec_version.json"
+)
+
+var reOPinitBinaryLink = regexp.MustCompile(`opinitd@(v?[0-9][^/\s]*)`)
+
+// CurrentBotVersion returns the opinitd version weave installs and runs the bots with: the version pinned with
+// `weave opinit version use`, or the default one
+func CurrentBotVersion() string {
+	if version := config.GetOPInitBotsVersion(); version != "" {
+		return version
+	}
+	return OpinitBotBinaryVersion
+}
+
+// InstalledBotVersion returns the opinitd version the bot services run, read from the ~/.weave/data/opinitd link
+// to the installed binary. It falls back to CurrentBotVersion when the link is missing.
+func InstalledBotVersion() string {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return CurrentBotVersion()
+	}
+	target, err := os.Readlink(filepath.Join(userHome, common.WeaveDataDirectory, AppName))
+	if err != nil {
+		return CurrentBotVersion()
+	}
+	if matches := reOPinitBinaryLink.FindStringSubmatch(target); matches != nil {
+		return cosmosutils.NormalizeVersion(matches[1])
+	}
+	return CurrentBotVersion()
+}
+
+// BotVersion is a release of the OPinit bots and whether it implements the spec version the rollup's L1 requires
+type BotVersion struct {
+	Version     string `json:"version"`
+	Current     bool   `json:"current"`
+	Installed   bool   `json:"installed"`
+	SpecVersion *int   `json:"spec_version,omitempty"`
+	Compatible  *bool  `json:"compatible,omitempty"`
+	url         string
+}
+
+// BotVersions lists the releases of the OPinit bots against the spec version of the L1 the bots are configured for
+type BotVersions struct {
+	L1ChainId           string       `json:"l1_chain_id,omitempty"`
+	RequiredSpecVersion *int         `json:"required_spec_version,omitempty"`
+	Versions            []BotVersion `json:"versions"`
+}
+
+// ConfiguredL1ChainId returns the L1 chain id of the configured bots, empty when no bot is configured
+func ConfiguredL1ChainId(opInitHome string) (string, error) {
+	for _, bot := range ConfiguredBots(opInitHome) {
+		config, err := loadDBBotConfig(opInitHome, bot)
+		if err != nil {
+			return "", err
+		}
+		if config.L1Node.ChainID != "" {
+			return config.L1Node.ChainID, nil
+		}
+	}
+	return "", nil
+}
+
+// ListBotVersions returns the releases of opinitd for this platform, the most recent first, with the spec version
+// each of them implements for the L1 of the configured bots
+func ListBotVersions(opInitHome string) (*BotVersions, error) {
+	releases, err := cosmosutils.ListBinaryReleases(opinitBotsReleasesURL)
+	if err != nil {
+		return nil, err
+	}
+	l1ChainId, err := ConfiguredL1ChainId(opInitHome)
+	if err != nil {
+		return nil, err
+	}
+	versions := &BotVersions{L1ChainId: l1ChainId}
+	if l1ChainId != "" {
+		required, err := registry.GetOPInitBotsSpecVersion(l1ChainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the spec version of %s: %v", l1ChainId, err)
+		}
+		versions.RequiredSpecVersion = &required
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	current := InstalledBotVersion()
+	for _, version := range cosmosutils.SortVersions(releases) {
+		versions.Versions = append(versions.Versions, BotVersion{
+			Version:   version,
+			Current:   cosmosutils.NormalizeVersion(version) == current,
+			Installed: io.FileOrFolderExists(botBinaryPath(userHome, version)),
+			url:       releases[version],
+		})
+	}
+
+	if versions.RequiredSpecVersion != nil {
+		var wg sync.WaitGroup
+		for idx := range versions.Versions {
+			wg.Add(1)
+			go func(version *BotVersion) {
+				defer wg.Done()
+				if spec, err := querySpecVersionAtTag(version.Version, l1ChainId); err == nil {
+					compatible := spec == *versions.RequiredSpecVersion
+					version.SpecVersion, version.Compatible = &spec, &compatible
+				}
+			}(&versions.Versions[idx])
+		}
+		wg.Wait()
+	}
+	return versions, nil
+}
+
+// querySpecVersionAtTag returns the spec version the release version of the OPinit bots implements for l1ChainId
+func querySpecVersionAtTag(version, l1ChainId string) (int, error) {
+	if registry.IsLocalL1(l1ChainId) {
+		return registry.LocalOPInitBotsSpecVersion, nil
+	}
+	var specVersions map[string]int
+	if _, err := client.NewHTTPClient().Get(fmt.Sprintf(opinitBotsSpecAtTagURL, version), "", nil, &specVersions); err != nil {
+		return 0, fmt.Errorf("failed to get the spec_version.json of opinitd %s: %v", version, err)
+	}
+	spec, ok := specVersions[l1ChainId]
+	if !ok {
+		return 0, fmt.Errorf("opinitd %s does not list %s in its spec_version.json", version, l1ChainId)
+	}
+	return spec, nil
+}
+
+// CheckSpecCompatibility explains why opinitd version cannot run the bots of l1ChainId, which requires the spec
+// version required. candidateErr is the error met looking up the spec version of the candidate, if any.
+func CheckSpecCompatibility(version, l1ChainId string, required, candidate int, candidateErr error) error {
+	if candidateErr != nil {
+		return fmt.Errorf("cannot tell whether opinitd %s supports %s: %v", version, l1ChainId, candidateErr)
+	}
+	if candidate != required {
+		return fmt.Errorf("opinitd %s implements spec version %d for %s, but %s requires spec version %d", version, candidate, l1ChainId, l1ChainId, required)
+	}
+	return nil
+}
+
+func botBinaryPath(userHome, version string) string {
+	return filepath.Join(userHome, common.WeaveDataDirectory, fmt.Sprintf("opinitd@%s", version), AppName)
+}
+
+// UseBotVersion installs opinitd version, checks it against the spec version of the L1 the bots are configured for,
+// points the bot services to it, sets the config version of the bots to the spec version and restarts the bots whose
+// service is running. An incompatible version is refused unless force is set, and then leaves the config versions.
+func UseBotVersion(opInitHome, version string, force bool, progress func(string)) error {
+	version = cosmosutils.NormalizeVersion(version)
+	releases, err := cosmosutils.ListBinaryReleases(opinitBotsReleasesURL)
+	if err != nil {
+		return err
+	}
+	url, ok := releases[version]
+	if !ok {
+		return fmt.Errorf("opinitd %s is not released for this platform, see `weave opinit version list`", version)
+	}
+
+	l1ChainId, err := ConfiguredL1ChainId(opInitHome)
+	if err != nil {
+		return err
+	}
+	var specVersion int
+	if l1ChainId != "" {
+		required, err := registry.GetOPInitBotsSpecVersion(l1ChainId)
+		if err != nil {
+			return fmt.Errorf("failed to get the spec version of %s: %v", l1ChainId, err)
+		}
+		candidate, candidateErr := querySpecVersionAtTag(version, l1ChainId)
+		specVersion = candidate
+		if err = CheckSpecCompatibility(version, l1ChainId, required, candidate, candidateErr); err != nil {
+			if !force {
+				return fmt.Errorf("%v. Use --force to use it anyway", err)
+			}
+			// the spec version of an incompatible candidate is not the one the chain expects, the configs keep theirs
+			progress(fmt.Sprintf("Using it anyway, the config version of the bots is left unchanged: %v", err))
+			specVersion = 0
+		}
+	}
+
+	previous := InstalledBotVersion()
+	if minorVersion(previous) != minorVersion(version) {
+		progress(fmt.Sprintf("opinitd %s may not read the databases of opinitd %s, back them up first with `weave opinit db backup`", version, previous))
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	binaryPath := botBinaryPath(userHome, version)
+	if err = installBotBinary(userHome, version, url, binaryPath, progress); err != nil {
+		return err
+	}
+
+	// find the running bots before the binary they run changes
+	bots := ConfiguredBots(opInitHome)
+	var running []string
+	for _, bot := range bots {
+		config, err := loadDBBotConfig(opInitHome, bot)
+		if err != nil {
+			continue
+		}
+		isRunning, err := botRunning(bot, config)
+		if err != nil {
+			return err
+		}
+		if isRunning {
+			running = append(running, bot)
+		}
+	}
+
+	if err = cosmosutils.SetSymlink(binaryPath); err != nil {
+		return err
+	}
+	if specVersion > 0 {
+		for _, bot := range bots {
+			if err = migrateConfigVersion(opInitHome, bot, specVersion, progress); err != nil {
+				return err
+			}
+		}
+	}
+	if err = config.SetOPInitBotsVersion(version); err != nil {
+		return err
+	}
+	progress(fmt.Sprintf("The OPinit bots now run opinitd %s", version))
+
+	for _, bot := range running {
+		if err = RestartBotService(bot); err != nil {
+			return err
+		}
+		progress(fmt.Sprintf("Restarted the OPinit %s bot", bot))
+	}
+	return nil
+}
+
+func installBotBinary(userHome, version, url, binaryPath string, progress func(string)) error {
+	if !io.FileOrFolderExists(binaryPath) {
+		progress(fmt.Sprintf("Downloading opinitd %s", version))
+		extractedPath := filepath.Dir(binaryPath)
+		if err := os.MkdirAll(extractedPath, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create %s: %v", extractedPath, err)
+		}
+		tarballPath := filepath.Join(userHome, common.WeaveDataDirectory, "opinitd.tar.gz")
+		if err := io.DownloadAndExtractTarGz(url, tarballPath, extractedPath); err != nil {
+			return fmt.Errorf("failed to download and extract binary: %v", err)
+		}
+		if err := os.Chmod(binaryPath, 0755); err != nil {
+			return fmt.Errorf("failed to set permissions for binary: %v", err)
+		}
+	}
+
+	// running the binary also loads its libraries
+	output, err := cosmosutils.GetBinaryVersion(binaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to run opinitd %s: %v", version, err)
+	}
+	if cosmosutils.NormalizeVersion(output) != version {
+		return fmt.Errorf("the downloaded binary reports version %s, expected %s", output, version)
+	}
+	return nil
+}
+
+// migrateConfigVersion sets the version field of the bot config to the spec version the new opinitd implements
+func migrateConfigVersion(opInitHome, bot string, specVersion int, progress func(string)) error {
+	configFile, err := LoadBotConfigFile(opInitHome, bot)
+	if err != nil {
+		return err
+	}
+	changes, err := configFile.Save(map[string]string{"version": strconv.Itoa(specVersion)})
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		progress(fmt.Sprintf("Migrated the %s config:\n%s", bot, strings.TrimRight(RenderBotConfigChanges(changes), "\n")))
+	}
+	return nil
+}
+
+func (v *BotVersions) String() string {
+	var b strings.Builder
+	if v.RequiredSpecVersion != nil {
+		b.WriteString(fmt.Sprintf("%s requires spec version %d\n\n", v.L1ChainId, *v.RequiredSpecVersion))
+	}
+	for _, version := range v.Versions {
+		line := fmt.Sprintf("%-16s", version.Version)
+		switch {
+		case version.Compatible == nil && v.RequiredSpecVersion != nil:
+			line += styles.Text("spec unknown", styles.Gray)
+		case version.Compatible == nil:
+		case *version.Compatible:
+			line += styles.Text(fmt.Sprintf("spec %d", *version.SpecVersion), styles.Green)
+		default:
+			line += styles.Text(fmt.Sprintf("spec %d, incompatible", *version.SpecVersion), styles.Yellow)
+		}
+		if version.Current {
+			line += styles.BoldText(" (current)", styles.Cyan)
+		} else if version.Installed {
+			line += styles.Text(" (installed)", styles.Gray)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
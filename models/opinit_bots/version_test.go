@@ -0,0 +1,58 @@
+package opinit_bots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSpecCompatibility(t *testing.T) {
+	assert.NoError(t, CheckSpecCompatibility("v0.1.12", "initiation-2", 1, 1, nil))
+	assert.EqualError(t, CheckSpecCompatibility("v1.0.0", "initiation-2", 1, 2, nil),
+		"opinitd v1.0.0 implements spec version 2 for initiation-2, but initiation-2 requires spec version 1")
+	assert.ErrorContains(t, CheckSpecCompatibility("v0.1.0", "initiation-2", 1, 0, errors.New("not found")),
+		"cannot tell whether opinitd v0.1.0 supports initiation-2: not found")
+}
+
+func TestMigrateConfigVersion(t *testing.T) {
+	home := writeExecutorConfig(t)
+
+	var messages []string
+	progress := func(message string) { messages = append(messages, message) }
+	assert.NoError(t, migrateConfigVersion(home, "executor", 1, progress))
+	assert.Empty(t, messages)
+
+	assert.NoError(t, migrateConfigVersion(home, "executor", 2, progress))
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "Migrated the executor config")
+
+	configFile, err := LoadBotConfigFile(home, "executor")
+	assert.NoError(t, err)
+	version, err := configFile.Get("version")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", version)
+
+	chainId, err := ConfiguredL1ChainId(home)
+	assert.NoError(t, err)
+	assert.Equal(t, "initiation-2", chainId)
+}
+
+func TestBotVersionsString(t *testing.T) {
+	required, one, two := 1, 1, 2
+	compatible, incompatible := true, false
+	versions := &BotVersions{
+		L1ChainId:           "initiation-2",
+		RequiredSpecVersion: &required,
+		Versions: []BotVersion{
+			{Version: "v1.0.0", SpecVersion: &two, Compatible: &incompatible},
+			{Version: "v0.1.12", SpecVersion: &one, Compatible: &compatible, Current: true, Installed: true},
+			{Version: "v0.1.0"},
+		},
+	}
+	text := versions.String()
+	assert.Contains(t, text, "initiation-2 requires spec version 1")
+	assert.Contains(t, text, "spec 2, incompatible")
+	assert.Contains(t, text, "(current)")
+	assert.Contains(t, text, "spec unknown")
+}
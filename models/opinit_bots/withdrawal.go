@@ -0,0 +1,396 @@
+package opinit_bots
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/crypto"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/styles"
+)
+
+const (
+	// finalizeWithdrawalSimulationGasLimit only prices the copy of MsgFinalizeTokenWithdrawal that is simulated, the
+	// gas of the proofs grows with the number of withdrawals in the output, so the tx is signed with the simulated gas
+	finalizeWithdrawalSimulationGasLimit uint64 = 500000
+	withdrawalsPageLimit                        = 100
+)
+
+// apiWithdrawal is a withdrawal as the executor serves it, with the proof data needed to finalize it on the L1
+type apiWithdrawal struct {
+	BridgeId         uint64           `json:"bridge_id"`
+	OutputIndex      uint64           `json:"output_index"`
+	WithdrawalProofs [][]byte         `json:"withdrawal_proofs"`
+	Sender           string           `json:"sender"`
+	Sequence         uint64           `json:"sequence"`
+	Amount           cosmosutils.Coin `json:"amount"`
+	Version          []byte           `json:"version"`
+	StorageRoot      []byte           `json:"storage_root"`
+	LastBlockHash    []byte           `json:"last_block_hash"`
+	BlockNumber      int64            `json:"block_number"`
+	Receiver         string           `json:"receiver"`
+	WithdrawalHash   []byte           `json:"withdrawal_hash"`
+}
+
+type apiWithdrawals struct {
+	Withdrawals []apiWithdrawal `json:"withdrawals"`
+	Next        uint64          `json:"next"`
+}
+
+// WithdrawalState is how far a withdrawal from the rollup is on its way to be finalized on the L1
+type WithdrawalState string
+
+const (
+	// WithdrawalPendingOutput is a withdrawal whose output is not submitted to the L1 yet
+	WithdrawalPendingOutput WithdrawalState = "pending output"
+	// WithdrawalInChallengePeriod is a withdrawal whose output can still be challenged
+	WithdrawalInChallengePeriod WithdrawalState = "in challenge period"
+	WithdrawalFinalizable       WithdrawalState = "finalizable"
+	WithdrawalFinalized         WithdrawalState = "finalized"
+)
+
+type WithdrawalStatus struct {
+	Sequence       uint64          `json:"sequence"`
+	BridgeId       uint64          `json:"bridge_id"`
+	Sender         string          `json:"sender"`
+	Receiver       string          `json:"receiver"`
+	Amount         string          `json:"amount"`
+	L2BlockNumber  int64           `json:"l2_block_number"`
+	OutputIndex    uint64          `json:"output_index,omitempty"`
+	State          WithdrawalState `json:"state"`
+	FinalizesAt    *time.Time      `json:"finalizes_at,omitempty"`
+	FinalizeTxHash string          `json:"finalize_tx_hash,omitempty"`
+	withdrawal     apiWithdrawal
+}
+
+// WithdrawalSigner is the L1 key that signs MsgFinalizeTokenWithdrawal, either a key of the configured keyring or a
+// mnemonic
+type WithdrawalSigner struct {
+	KeyName    string
+	KeyringDir string
+	Mnemonic   string
+}
+
+func (s WithdrawalSigner) keyring() cosmosutils.Keyring {
+	keyring := cosmosutils.DefaultKeyring()
+	keyring.Dir = s.KeyringDir
+	return keyring
+}
+
+// Withdrawals looks up the withdrawals of the rollup through the HTTP API of its executor and their outputs on the L1
+type Withdrawals struct {
+	server     string
+	l1Lcd      string
+	l1RPC      string
+	l1ChainId  string
+	l1GasPrice string
+
+	finalizationPeriods map[uint64]time.Duration
+	lastOutputIndexes   map[uint64]uint64
+	outputTimes         map[string]time.Time
+}
+
+// NewWithdrawals reads the executor config in opInitHome for its API and L1
+func NewWithdrawals(opInitHome string) (*Withdrawals, error) {
+	configFile, err := LoadBotConfigFile(opInitHome, "executor")
+	if err != nil {
+		return nil, err
+	}
+	serverAddress, _ := configFile.Get("server.address")
+	l1ChainId, _ := configFile.Get("l1_node.chain_id")
+	l1RPC, _ := configFile.Get("l1_node.rpc_address")
+
+	l1Registry, err := registry.GetL1ChainRegistry(l1ChainId)
+	if err != nil {
+		return nil, err
+	}
+	l1Lcd, err := l1Registry.GetActiveLcd()
+	if err != nil {
+		return nil, err
+	}
+	l1GasPrice, err := l1Registry.GetGasPriceByDenom(DefaultInitiaGasDenom)
+	if err != nil {
+		return nil, err
+	}
+	return newWithdrawals(botServerURL("executor", serverAddress), l1Lcd, l1RPC, l1ChainId, l1GasPrice), nil
+}
+
+func newWithdrawals(server, l1Lcd, l1RPC, l1ChainId, l1GasPrice string) *Withdrawals {
+	return &Withdrawals{
+		server:              server,
+		l1Lcd:               l1Lcd,
+		l1RPC:               l1RPC,
+		l1ChainId:           l1ChainId,
+		l1GasPrice:          l1GasPrice,
+		finalizationPeriods: make(map[uint64]time.Duration),
+		lastOutputIndexes:   make(map[uint64]uint64),
+		outputTimes:         make(map[string]time.Time),
+	}
+}
+
+// Get returns the withdrawal with the given sequence, or every withdrawal sent by the given L2 address
+func (w *Withdrawals) Get(sequenceOrAddress string) ([]*WithdrawalStatus, error) {
+	httpClient := client.NewHTTPClient()
+	var withdrawals []apiWithdrawal
+	if sequence, err := strconv.ParseUint(sequenceOrAddress, 10, 64); err == nil {
+		var withdrawal apiWithdrawal
+		if _, err = httpClient.Get(w.server, fmt.Sprintf("/withdrawal/%d", sequence), nil, &withdrawal); err != nil {
+			return nil, fmt.Errorf("failed to query withdrawal %d from the executor at %s: %v", sequence, w.server, err)
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	} else {
+		if err = common.IsValidAddress(sequenceOrAddress); err != nil {
+			return nil, fmt.Errorf("expected a withdrawal sequence or an L2 address: %s", sequenceOrAddress)
+		}
+		offset := uint64(0)
+		for {
+			var res apiWithdrawals
+			params := map[string]string{"offset": strconv.FormatUint(offset, 10), "limit": strconv.Itoa(withdrawalsPageLimit), "order": "ASC"}
+			if _, err = httpClient.Get(w.server, fmt.Sprintf("/withdrawals/%s", sequenceOrAddress), params, &res); err != nil {
+				return nil, fmt.Errorf("failed to query the withdrawals of %s from the executor at %s: %v", sequenceOrAddress, w.server, err)
+			}
+			withdrawals = append(withdrawals, res.Withdrawals...)
+			if len(res.Withdrawals) < withdrawalsPageLimit || res.Next <= offset {
+				break
+			}
+			offset = res.Next
+		}
+	}
+
+	var statuses []*WithdrawalStatus
+	for _, withdrawal := range withdrawals {
+		status, err := w.status(withdrawal, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// status works out the state of the withdrawal from its output on the L1
+func (w *Withdrawals) status(withdrawal apiWithdrawal, now time.Time) (*WithdrawalStatus, error) {
+	status := &WithdrawalStatus{
+		Sequence:      withdrawal.Sequence,
+		BridgeId:      withdrawal.BridgeId,
+		Sender:        withdrawal.Sender,
+		Receiver:      withdrawal.Receiver,
+		Amount:        withdrawal.Amount.Amount + withdrawal.Amount.Denom,
+		L2BlockNumber: withdrawal.BlockNumber,
+		OutputIndex:   withdrawal.OutputIndex,
+		State:         WithdrawalPendingOutput,
+		withdrawal:    withdrawal,
+	}
+	bridgeId := strconv.FormatUint(withdrawal.BridgeId, 10)
+
+	lastOutputIndex, ok := w.lastOutputIndexes[withdrawal.BridgeId]
+	if !ok {
+		lastOutput, err := cosmosutils.QueryLastOutputProposal(w.l1Lcd, bridgeId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the last output of bridge %s: %v", bridgeId, err)
+		}
+		if lastOutput != nil {
+			if lastOutputIndex, err = strconv.ParseUint(lastOutput.OutputIndex, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid output index %q: %v", lastOutput.OutputIndex, err)
+			}
+		}
+		w.lastOutputIndexes[withdrawal.BridgeId] = lastOutputIndex
+	}
+	if withdrawal.OutputIndex == 0 || withdrawal.OutputIndex > lastOutputIndex {
+		return status, nil
+	}
+
+	finalizationPeriod, ok := w.finalizationPeriods[withdrawal.BridgeId]
+	if !ok {
+		var err error
+		if finalizationPeriod, err = queryFinalizationPeriod(w.l1Lcd, bridgeId); err != nil {
+			return nil, fmt.Errorf("failed to query the finalization period of bridge %s: %v", bridgeId, err)
+		}
+		w.finalizationPeriods[withdrawal.BridgeId] = finalizationPeriod
+	}
+	outputKey := fmt.Sprintf("%d/%d", withdrawal.BridgeId, withdrawal.OutputIndex)
+	submittedAt, ok := w.outputTimes[outputKey]
+	if !ok {
+		output, err := cosmosutils.QueryOutputProposal(w.l1Lcd, bridgeId, withdrawal.OutputIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query output %d of bridge %s: %v", withdrawal.OutputIndex, bridgeId, err)
+		}
+		if submittedAt, err = time.Parse(time.RFC3339Nano, output.OutputProposal.L1BlockTime); err != nil {
+			return nil, fmt.Errorf("invalid L1 block time %q: %v", output.OutputProposal.L1BlockTime, err)
+		}
+		w.outputTimes[outputKey] = submittedAt
+	}
+	finalizesAt := submittedAt.Add(finalizationPeriod)
+	status.FinalizesAt = &finalizesAt
+	if now.Before(finalizesAt) {
+		status.State = WithdrawalInChallengePeriod
+		return status, nil
+	}
+
+	claimed, err := cosmosutils.QueryWithdrawalClaimed(w.l1Lcd, bridgeId, withdrawal.WithdrawalHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query whether withdrawal %d is finalized: %v", withdrawal.Sequence, err)
+	}
+	status.State = WithdrawalFinalizable
+	if claimed {
+		status.State = WithdrawalFinalized
+	}
+	return status, nil
+}
+
+// Finalize signs and broadcasts MsgFinalizeTokenWithdrawal for the withdrawal on the L1. Anyone can finalize a
+// withdrawal, the funds always go to its receiver.
+func (w *Withdrawals) Finalize(status *WithdrawalStatus, signer WithdrawalSigner) error {
+	if status.State != WithdrawalFinalizable {
+		return fmt.Errorf("withdrawal %d is %s, it cannot be finalized", status.Sequence, status.State)
+	}
+	executor, err := cosmosutils.NewInitiadTxExecutor(w.l1Lcd)
+	if err != nil {
+		return err
+	}
+	var sender string
+	if signer.Mnemonic != "" {
+		sender, err = crypto.MnemonicToBech32Address("init", signer.Mnemonic)
+	} else {
+		sender, err = executor.KeyAddress(signer.keyring(), signer.KeyName)
+	}
+	if err != nil {
+		return err
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home dir: %v", err)
+	}
+	// every run has its own tx file, so finalizing several withdrawals at once does not mix them up
+	txFile, err := os.CreateTemp(filepath.Join(userHome, common.WeaveDataDirectory), "finalize_withdrawal_tx_*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create tx file: %v", err)
+	}
+	txPath := txFile.Name()
+	_ = txFile.Close()
+	defer func() {
+		_ = io.DeleteFile(txPath)
+	}()
+
+	messages := []map[string]interface{}{BuildFinalizeWithdrawalMsg(sender, status.withdrawal)}
+	var txResponse *cosmosutils.InitiadTxResponse
+	signAndBroadcast := func(keyring cosmosutils.Keyring, from string) error {
+		gasLimit, err := w.simulateFinalizeTx(executor, txPath, messages, keyring, from)
+		if err != nil {
+			return err
+		}
+		fee, err := txFee(w.l1GasPrice, gasLimit)
+		if err != nil {
+			return err
+		}
+		if err = cosmosutils.WriteUnsignedTx(txPath, messages, fee, gasLimit); err != nil {
+			return err
+		}
+		txResponse, err = executor.SignAndBroadcastFromKeyring(txPath, keyring, from, w.l1RPC, w.l1ChainId)
+		return err
+	}
+	if signer.Mnemonic != "" {
+		err = executor.WithMnemonicKey(signer.Mnemonic, signAndBroadcast)
+	} else {
+		err = signAndBroadcast(signer.keyring(), signer.KeyName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to finalize withdrawal %d: %v", status.Sequence, err)
+	}
+	status.State = WithdrawalFinalized
+	status.FinalizeTxHash = txResponse.TxHash
+	return nil
+}
+
+// simulateFinalizeTx writes the unsigned tx of the messages to txPath, priced at a placeholder gas limit, and returns
+// the gas it simulates to
+func (w *Withdrawals) simulateFinalizeTx(executor *cosmosutils.InitiadTxExecutor, txPath string, messages []map[string]interface{}, keyring cosmosutils.Keyring, from string) (uint64, error) {
+	fee, err := txFee(w.l1GasPrice, finalizeWithdrawalSimulationGasLimit)
+	if err != nil {
+		return 0, err
+	}
+	if err = cosmosutils.WriteUnsignedTx(txPath, messages, fee, finalizeWithdrawalSimulationGasLimit); err != nil {
+		return 0, err
+	}
+	return executor.SimulateFromKeyring(txPath, keyring, from, w.l1RPC, w.l1Lcd, w.l1ChainId)
+}
+
+// BuildFinalizeWithdrawalMsg returns MsgFinalizeTokenWithdrawal for the withdrawal as it is written in an unsigned tx
+func BuildFinalizeWithdrawalMsg(sender string, withdrawal apiWithdrawal) map[string]interface{} {
+	proofs := withdrawal.WithdrawalProofs
+	if proofs == nil {
+		proofs = [][]byte{}
+	}
+	return map[string]interface{}{
+		"@type":             "/opinit.ophost.v1.MsgFinalizeTokenWithdrawal",
+		"sender":            sender,
+		"bridge_id":         strconv.FormatUint(withdrawal.BridgeId, 10),
+		"output_index":      strconv.FormatUint(withdrawal.OutputIndex, 10),
+		"withdrawal_proofs": proofs,
+		"from":              withdrawal.Sender,
+		"to":                withdrawal.Receiver,
+		"sequence":          strconv.FormatUint(withdrawal.Sequence, 10),
+		"amount":            withdrawal.Amount,
+		"version":           withdrawal.Version,
+		"storage_root":      withdrawal.StorageRoot,
+		"last_block_hash":   withdrawal.LastBlockHash,
+	}
+}
+
+// MarshalWithdrawals returns the withdrawals as indented JSON
+func MarshalWithdrawals(statuses []*WithdrawalStatus) (string, error) {
+	bz, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal withdrawals: %v", err)
+	}
+	return string(bz), nil
+}
+
+func (s *WithdrawalStatus) String() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldText(fmt.Sprintf("Withdrawal #%d ", s.Sequence), styles.Cyan))
+	b.WriteString(styles.BoldText(string(s.State)+"\n", withdrawalStateColor(s.State)))
+	b.WriteString(fmt.Sprintf("  %-14s %s\n", "Amount:", s.Amount))
+	b.WriteString(fmt.Sprintf("  %-14s %s\n", "From (L2):", s.Sender))
+	b.WriteString(fmt.Sprintf("  %-14s %s\n", "To (L1):", s.Receiver))
+	b.WriteString(fmt.Sprintf("  %-14s %d\n", "L2 block:", s.L2BlockNumber))
+
+	switch s.State {
+	case WithdrawalPendingOutput:
+		b.WriteString("  Waiting for the output that includes it to be submitted to the L1\n")
+	case WithdrawalInChallengePeriod:
+		b.WriteString(fmt.Sprintf("  %-14s #%d, finalizable from %s (in %s)\n", "Output:", s.OutputIndex,
+			s.FinalizesAt.Local().Format(time.RFC3339), time.Until(*s.FinalizesAt).Round(time.Second)))
+	case WithdrawalFinalizable:
+		b.WriteString(fmt.Sprintf("  %-14s #%d, finalized on the L1. Run with --finalize to claim it\n", "Output:", s.OutputIndex))
+	case WithdrawalFinalized:
+		b.WriteString(fmt.Sprintf("  %-14s #%d, claimed on the L1\n", "Output:", s.OutputIndex))
+	}
+	if s.FinalizeTxHash != "" {
+		b.WriteString(fmt.Sprintf("  %-14s %s\n", "Finalize tx:", s.FinalizeTxHash))
+	}
+	return b.String()
+}
+
+func withdrawalStateColor(state WithdrawalState) styles.HexColor {
+	switch state {
+	case WithdrawalInChallengePeriod:
+		return styles.Yellow
+	case WithdrawalFinalizable:
+		return styles.Green
+	case WithdrawalFinalized:
+		return styles.White
+	default:
+		return styles.Gray
+	}
+}
@@ -0,0 +1,110 @@
+package opinit_bots
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const withdrawalSender = "init1xj2pvwrqnjh4v0jr9s0hmdxvhrn52thahgqhez"
+
+func withdrawalHash(sequence uint64) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("withdrawal-%d", sequence)))
+}
+
+func newWithdrawalServer(t *testing.T) *httptest.Server {
+	now := time.Now().UTC()
+	// output 1 is finalized, output 2 is within the finalization period of an hour, output 3 is not submitted yet
+	outputTimes := map[string]time.Time{"1": now.Add(-2 * time.Hour), "2": now.Add(-10 * time.Minute)}
+	withdrawal := func(sequence, outputIndex uint64) string {
+		return fmt.Sprintf(`{"bridge_id": 1, "output_index": %d, "withdrawal_proofs": ["cHJvb2Y="], "sender": "%s", "sequence": %d,
+  "amount": {"denom": "uinit", "amount": "100"}, "version": "AQ==", "storage_root": "cm9vdA==", "last_block_hash": "aGFzaA==",
+  "block_number": %d, "receiver": "init1receiver", "withdrawal_hash": "%s"}`, outputIndex, withdrawalSender, sequence, sequence*10, withdrawalHash(sequence))
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/withdrawal/1":
+			_, _ = fmt.Fprint(w, withdrawal(1, 1))
+		case r.URL.Path == "/withdrawals/"+withdrawalSender:
+			assert.Equal(t, "0", r.URL.Query().Get("offset"))
+			_, _ = fmt.Fprintf(w, `{"withdrawals": [%s, %s, %s, %s], "next": 5}`, withdrawal(1, 1), withdrawal(2, 1), withdrawal(3, 2), withdrawal(4, 3))
+		case r.URL.Path == "/opinit/ophost/v1/bridges/1":
+			_, _ = fmt.Fprint(w, `{"bridge_id": "1", "bridge_config": {"finalization_period": "3600s"}}`)
+		case r.URL.Path == "/opinit/ophost/v1/bridges/1/outputs":
+			_, _ = fmt.Fprint(w, `{"output_proposals": [{"bridge_id": "1", "output_index": "2"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/opinit/ophost/v1/bridges/1/outputs/"):
+			index := strings.TrimPrefix(r.URL.Path, "/opinit/ophost/v1/bridges/1/outputs/")
+			_, _ = fmt.Fprintf(w, `{"bridge_id": "1", "output_index": "%s", "output_proposal": {"l1_block_time": "%s"}}`, index, outputTimes[index].Format(time.RFC3339Nano))
+		case r.URL.Path == "/opinit/ophost/v1/bridges/1/withdrawals/claimed/by_hash":
+			// withdrawal 1 is claimed, withdrawal 2 is not
+			claimed := r.URL.Query().Get("withdrawal_hash") == withdrawalHash(1)
+			_, _ = fmt.Fprintf(w, `{"claimed": %t}`, claimed)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetWithdrawals(t *testing.T) {
+	server := newWithdrawalServer(t)
+	defer server.Close()
+
+	withdrawals := newWithdrawals(server.URL, server.URL, server.URL, "initiation-2", "0.015uinit")
+	statuses, err := withdrawals.Get(withdrawalSender)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 4)
+
+	var states []WithdrawalState
+	for _, status := range statuses {
+		states = append(states, status.State)
+	}
+	assert.Equal(t, []WithdrawalState{WithdrawalFinalized, WithdrawalFinalizable, WithdrawalInChallengePeriod, WithdrawalPendingOutput}, states)
+	assert.Equal(t, "100uinit", statuses[0].Amount)
+	assert.Equal(t, int64(20), statuses[1].L2BlockNumber)
+	assert.NotNil(t, statuses[2].FinalizesAt)
+	assert.Nil(t, statuses[3].FinalizesAt)
+	assert.Contains(t, statuses[2].String(), "finalizable from")
+	assert.Contains(t, statuses[3].String(), "Waiting for the output")
+
+	statuses, err = withdrawals.Get("1")
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, WithdrawalFinalized, statuses[0].State)
+
+	_, err = withdrawals.Get("not-an-address")
+	assert.ErrorContains(t, err, "expected a withdrawal sequence or an L2 address")
+
+	assert.ErrorContains(t, withdrawals.Finalize(statuses[0], WithdrawalSigner{KeyName: "user"}), "withdrawal 1 is finalized, it cannot be finalized")
+}
+
+func TestBuildFinalizeWithdrawalMsg(t *testing.T) {
+	var withdrawal apiWithdrawal
+	assert.NoError(t, json.Unmarshal([]byte(`{"bridge_id": 1, "output_index": 3, "withdrawal_proofs": ["cHJvb2Y="], "sender": "init1sender",
+  "sequence": 7, "amount": {"denom": "uinit", "amount": "100"}, "version": "AQ==", "storage_root": "cm9vdA==", "last_block_hash": "aGFzaA==",
+  "receiver": "init1receiver"}`), &withdrawal))
+
+	bz, err := json.Marshal(BuildFinalizeWithdrawalMsg("init1signer", withdrawal))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "@type": "/opinit.ophost.v1.MsgFinalizeTokenWithdrawal",
+  "sender": "init1signer",
+  "bridge_id": "1",
+  "output_index": "3",
+  "withdrawal_proofs": ["cHJvb2Y="],
+  "from": "init1sender",
+  "to": "init1receiver",
+  "sequence": "7",
+  "amount": {"denom": "uinit", "amount": "100"},
+  "version": "AQ==",
+  "storage_root": "cm9vdA==",
+  "last_block_hash": "aGFzaA=="
+}`, string(bz))
+}
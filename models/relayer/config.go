@@ -1,10 +1,13 @@
 package relayer
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
+	"github.com/initia-labs/weave/registry"
 	"github.com/initia-labs/weave/types"
 )
 
@@ -204,12 +207,21 @@ list = [
 ]
 `
 
+	l1RPCAddr, err := getL1RPCAddress(state)
+	if err != nil {
+		return err
+	}
+	l1GRPCAddr := "https://" + state.Config["l1.grpc_address"]
+	// A local L1 is reached directly and serves gRPC without TLS
+	if registry.IsLocalL1(state.Config["l1.chain_id"]) {
+		l1GRPCAddr = "http://" + strings.TrimPrefix(state.Config["l1.grpc_address"], "http://")
+	}
+
 	// Populate data for placeholders
 	data := Data{
-		ID: state.Config["l1.chain_id"],
-		// TODO: revisit
-		RPCAddr:  "https://initia-testnet-rpc.polkachu.com/",
-		GRPCAddr: "https://" + state.Config["l1.grpc_address"],
+		ID:       state.Config["l1.chain_id"],
+		RPCAddr:  l1RPCAddr,
+		GRPCAddr: l1GRPCAddr,
 		EventSource: EventSource{
 			Mode:       "push",
 			URL:        state.Config["l1.websocket"],
@@ -261,3 +273,19 @@ list = [
 
 	return nil
 }
+
+// getL1RPCAddress returns the RPC of the L1 picked during the setup, or the active one of its registry
+func getL1RPCAddress(state State) (string, error) {
+	if rpc := state.Config["l1.rpc_address"]; rpc != "" {
+		return rpc, nil
+	}
+	l1Registry, err := registry.GetL1ChainRegistry(state.Config["l1.chain_id"])
+	if err != nil {
+		return "", fmt.Errorf("failed to load the registry of the L1 %s: %v", state.Config["l1.chain_id"], err)
+	}
+	rpc, err := l1Registry.GetActiveRpc()
+	if err != nil {
+		return "", fmt.Errorf("failed to get an RPC of the L1 %s: %v", state.Config["l1.chain_id"], err)
+	}
+	return rpc, nil
+}
@@ -0,0 +1,17 @@
+package relayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetL1RPCAddress(t *testing.T) {
+	state := NewRelayerState()
+	state.Config["l1.chain_id"] = "local-initia-1"
+	state.Config["l1.rpc_address"] = "http://localhost:26657"
+
+	rpc, err := getL1RPCAddress(state)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:26657", rpc)
+}
@@ -156,25 +156,25 @@ func (m *RollupSelect) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			state.feeWhitelistAccounts = append(state.feeWhitelistAccounts, minitiaConfig.SystemKeys.Challenger.L2Address)
 
 			state.minitiaConfig = &minitiaConfig
-			if minitiaConfig.L1Config.ChainID == InitiaTestnetChainId {
-				testnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+			if minitiaConfig.L1Config.ChainID == InitiaTestnetChainId || registry.IsLocalL1(minitiaConfig.L1Config.ChainID) {
+				l1Registry, err := registry.GetL1ChainRegistry(minitiaConfig.L1Config.ChainID)
 				if err != nil {
 					return m, m.HandlePanic(err)
 				}
-				state.Config["l1.chain_id"] = testnetRegistry.GetChainId()
-				if state.Config["l1.rpc_address"], err = testnetRegistry.GetActiveRpc(); err != nil {
+				state.Config["l1.chain_id"] = l1Registry.GetChainId()
+				if state.Config["l1.rpc_address"], err = l1Registry.GetActiveRpc(); err != nil {
 					return m, m.HandlePanic(err)
 				}
-				if state.Config["l1.grpc_address"], err = testnetRegistry.GetActiveGrpc(); err != nil {
+				if state.Config["l1.grpc_address"], err = l1Registry.GetActiveGrpc(); err != nil {
 					return m, m.HandlePanic(err)
 				}
-				if state.Config["l1.lcd_address"], err = testnetRegistry.GetActiveLcd(); err != nil {
+				if state.Config["l1.lcd_address"], err = l1Registry.GetActiveLcd(); err != nil {
 					return m, m.HandlePanic(err)
 				}
-				if state.Config["l1.websocket"], err = testnetRegistry.GetActiveWebSocket(); err != nil {
+				if state.Config["l1.websocket"], err = l1Registry.GetActiveWebSocket(); err != nil {
 					return m, m.HandlePanic(err)
 				}
-				if state.Config["l1.gas_price.price"], err = testnetRegistry.GetFixedMinGasPriceByDenom(DefaultGasPriceDenom); err != nil {
+				if state.Config["l1.gas_price.price"], err = l1Registry.GetFixedMinGasPriceByDenom(DefaultGasPriceDenom); err != nil {
 					return m, m.HandlePanic(err)
 				}
 				state.Config["l1.gas_price.denom"] = DefaultGasPriceDenom
@@ -1845,12 +1845,12 @@ func (m *SelectSettingUpIBCChannelsMethod) Update(msg tea.Msg) (tea.Model, tea.C
 			}
 			var metadata types.Metadata
 			var networkRegistry *registry.ChainRegistry
-			l1Registry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+			l1ChainType, err := registry.GetL1ChainType(state.Config["l1.chain_id"])
 			if err != nil {
 				return m, m.HandlePanic(err)
 			}
-			if state.Config["l1.chain_id"] == l1Registry.GetChainId() {
-				networkRegistry, err = registry.GetChainRegistry(registry.InitiaL1Testnet)
+			if l1ChainType == registry.InitiaL1Testnet || l1ChainType == registry.InitiaL1Local {
+				networkRegistry, err = registry.GetChainRegistry(l1ChainType)
 				if err != nil {
 					return m, m.HandlePanic(err)
 				}
@@ -35,27 +35,9 @@ func UpdateClientFromConfig() error {
 		return fmt.Errorf("invalid configuration: missing chain configuration")
 	}
 
-	var chainRegistry *registry.ChainRegistry
-
-	// Avoid panic until mainnet launches
-	testnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Testnet)
+	chainRegistry, err := registry.GetL1ChainRegistry(config.Chains[0].ID)
 	if err != nil {
-		return fmt.Errorf("error loading testnet registry: %v", err)
-	}
-	if config.Chains[0].ID == testnetRegistry.GetChainId() {
-		chainRegistry = testnetRegistry
-	} else {
-		mainnetRegistry, err := registry.GetChainRegistry(registry.InitiaL1Mainnet)
-		if err != nil {
-			return fmt.Errorf("error loading mainnet registry: %v", err)
-		}
-		if config.Chains[0].ID == mainnetRegistry.GetChainId() {
-			chainRegistry = mainnetRegistry
-		}
-	}
-
-	if chainRegistry == nil {
-		return fmt.Errorf("chain registry not found")
+		return fmt.Errorf("chain registry not found: %v", err)
 	}
 
 	clientIds := make(map[string]bool)
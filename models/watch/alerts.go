@@ -0,0 +1,280 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/client"
+	"github.com/initia-labs/weave/config"
+)
+
+// AlertsConfigKey is where the alert hooks are stored in the weave config
+const AlertsConfigKey = ConfigKey + ".alerts"
+
+// DefaultExecHookTimeout bounds how long an exec hook runs, so that a stuck command does not stop the watch
+const DefaultExecHookTimeout = 30 * time.Second
+
+const (
+	HookWebhook = "webhook"
+	HookExec    = "exec"
+	HookDesktop = "desktop"
+)
+
+const (
+	// EventChallenge is a challenge the challenger made against an output
+	EventChallenge = "challenge"
+	// EventOutputDeleted is an output deleted from the L1, the outcome of a successful challenge
+	EventOutputDeleted = "output_deleted"
+	// EventBotError is the challenger API becoming unreachable or the challenger logging an error
+	EventBotError = "bot_error"
+	// EventTest is the sample event fired by `weave watch test-alert`
+	EventTest = "test"
+)
+
+// EventTypes are the events an alert hook can be limited to
+var EventTypes = []string{EventChallenge, EventOutputDeleted, EventBotError, EventTest}
+
+// Event is what an alert hook is notified of. Webhooks receive it as their JSON body and exec hooks on their stdin.
+type Event struct {
+	Type      string    `json:"type"`
+	Bot       string    `json:"bot"`
+	L1ChainId string    `json:"l1_chain_id,omitempty"`
+	BridgeId  uint64    `json:"bridge_id,omitempty"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+}
+
+func (e Event) Title() string {
+	return fmt.Sprintf("weave: %s %s", e.Bot, strings.ReplaceAll(e.Type, "_", " "))
+}
+
+// AlertHook is a notification fired on the events of the challenger. A hook without events is fired on all of them.
+type AlertHook struct {
+	Type string `json:"type"`
+	// Target is the URL of a webhook or the shell command of an exec hook
+	Target string   `json:"target,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+func (h AlertHook) Validate() error {
+	switch h.Type {
+	case HookWebhook:
+		u, err := url.Parse(h.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL '%s', expected an http or https URL", h.Target)
+		}
+	case HookExec:
+		if strings.TrimSpace(h.Target) == "" {
+			return fmt.Errorf("an exec hook needs a command")
+		}
+	case HookDesktop:
+		if h.Target != "" {
+			return fmt.Errorf("a desktop hook takes no target")
+		}
+	default:
+		return fmt.Errorf("invalid hook type '%s'. Valid options are: %s, %s, %s", h.Type, HookWebhook, HookExec, HookDesktop)
+	}
+	for _, event := range h.Events {
+		if !isEventType(event) {
+			return fmt.Errorf("invalid event '%s'. Valid options are: [%s]", event, strings.Join(EventTypes, ", "))
+		}
+	}
+	return nil
+}
+
+func isEventType(event string) bool {
+	for _, eventType := range EventTypes {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts tells whether the hook is fired on events of eventType. The sample test event fires every hook.
+func (h AlertHook) Accepts(eventType string) bool {
+	if len(h.Events) == 0 || eventType == EventTest {
+		return true
+	}
+	for _, event := range h.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (h AlertHook) String() string {
+	description := h.Type
+	if h.Target != "" {
+		description += " " + h.Target
+	}
+	if len(h.Events) == 0 {
+		return description + " on all events"
+	}
+	return fmt.Sprintf("%s on %s", description, strings.Join(h.Events, ", "))
+}
+
+// LoadAlertHooks returns the alert hooks in the weave config
+func LoadAlertHooks() ([]AlertHook, error) {
+	var hooks []AlertHook
+	saved := config.GetConfig(AlertsConfigKey)
+	if saved == nil {
+		return hooks, nil
+	}
+	bz, err := json.Marshal(saved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the alert hooks: %v", err)
+	}
+	if err = json.Unmarshal(bz, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to parse the alert hooks: %v", err)
+	}
+	for i, hook := range hooks {
+		if err = hook.Validate(); err != nil {
+			return nil, fmt.Errorf("alert hook %d: %v, fix the %s section of the weave config", i+1, err, AlertsConfigKey)
+		}
+	}
+	return hooks, nil
+}
+
+func saveAlertHooks(hooks []AlertHook) error {
+	saved := make([]map[string]interface{}, 0, len(hooks))
+	for _, hook := range hooks {
+		entry := map[string]interface{}{"type": hook.Type}
+		if hook.Target != "" {
+			entry["target"] = hook.Target
+		}
+		if len(hook.Events) > 0 {
+			entry["events"] = hook.Events
+		}
+		saved = append(saved, entry)
+	}
+	return config.SetConfig(AlertsConfigKey, saved)
+}
+
+// AddAlertHook validates hook and appends it to the alert hooks in the weave config
+func AddAlertHook(hook AlertHook) error {
+	if err := hook.Validate(); err != nil {
+		return err
+	}
+	hooks, err := LoadAlertHooks()
+	if err != nil {
+		return err
+	}
+	return saveAlertHooks(append(hooks, hook))
+}
+
+// RemoveAlertHook removes the hook at index, counted from 1 as listed by `weave watch alerts`
+func RemoveAlertHook(index int) (AlertHook, error) {
+	hooks, err := LoadAlertHooks()
+	if err != nil {
+		return AlertHook{}, err
+	}
+	if index < 1 || index > len(hooks) {
+		return AlertHook{}, fmt.Errorf("there is no alert hook %d, see `weave watch alerts`", index)
+	}
+	removed := hooks[index-1]
+	hooks = append(hooks[:index-1], hooks[index:]...)
+	return removed, saveAlertHooks(hooks)
+}
+
+// Notifier fires the alert hooks that accept an event
+type Notifier struct {
+	Hooks []AlertHook
+	log   func(string)
+	// desktop shows a desktop notification, it is replaced in tests
+	desktop     func(title, message string) error
+	execTimeout time.Duration
+}
+
+func NewNotifier(hooks []AlertHook, log func(string)) *Notifier {
+	return &Notifier{Hooks: hooks, log: log, desktop: showDesktopNotification, execTimeout: DefaultExecHookTimeout}
+}
+
+// Notify fires every hook accepting the event. A failing hook is logged and does not stop the others, the number of
+// failing hooks is returned.
+func (n *Notifier) Notify(event Event) int {
+	n.log(fmt.Sprintf("ALERT %s: %s", event.Type, event.Message))
+	failed := 0
+	for _, hook := range n.Hooks {
+		if !hook.Accepts(event.Type) {
+			continue
+		}
+		if err := n.fire(hook, event); err != nil {
+			n.log(fmt.Sprintf("Failed to fire the %s hook: %v", hook.Type, err))
+			failed++
+		}
+	}
+	return failed
+}
+
+func (n *Notifier) fire(hook AlertHook, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the event: %v", err)
+	}
+	switch hook.Type {
+	case HookWebhook:
+		if _, err = client.NewHTTPClient().Post(hook.Target, "", map[string]string{"Content-Type": "application/json"}, payload, nil); err != nil {
+			return fmt.Errorf("failed to post to %s: %v", hook.Target, err)
+		}
+	case HookExec:
+		ctx, cancel := context.WithTimeout(context.Background(), n.execTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", hook.Target)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), "WEAVE_EVENT_TYPE="+event.Type, "WEAVE_EVENT_MESSAGE="+event.Message)
+		// the commands started by sh may keep the output open after sh is killed
+		cmd.WaitDelay = time.Second
+		output, err := cmd.CombinedOutput()
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("'%s' did not finish within %s", hook.Target, n.execTimeout)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to run '%s': %v, output: %s", hook.Target, err, strings.TrimSpace(string(output)))
+		}
+	case HookDesktop:
+		return n.desktop(event.Title(), event.Message)
+	default:
+		return fmt.Errorf("invalid hook type '%s'", hook.Type)
+	}
+	return nil
+}
+
+func showDesktopNotification(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("notify-send", title, message)
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title)))
+	default:
+		return fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show a desktop notification: %v, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// SampleEvent is the event fired by `weave watch test-alert`
+func SampleEvent(now time.Time) Event {
+	return Event{
+		Type:    EventTest,
+		Bot:     "challenger",
+		Message: "This is a test alert from weave, the alert hooks are working",
+		Time:    now.UTC(),
+	}
+}
@@ -0,0 +1,105 @@
+package watch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertHookValidate(t *testing.T) {
+	assert.NoError(t, AlertHook{Type: HookWebhook, Target: "https://hooks.example.com/weave"}.Validate())
+	assert.NoError(t, AlertHook{Type: HookExec, Target: "cat > /tmp/alert.json", Events: []string{EventChallenge}}.Validate())
+	assert.NoError(t, AlertHook{Type: HookDesktop}.Validate())
+
+	assert.ErrorContains(t, AlertHook{Type: HookWebhook, Target: "hooks.example.com"}.Validate(), "invalid webhook URL")
+	assert.ErrorContains(t, AlertHook{Type: HookExec, Target: " "}.Validate(), "an exec hook needs a command")
+	assert.ErrorContains(t, AlertHook{Type: HookDesktop, Target: "x"}.Validate(), "a desktop hook takes no target")
+	assert.ErrorContains(t, AlertHook{Type: "email"}.Validate(), "invalid hook type 'email'")
+	assert.ErrorContains(t, AlertHook{Type: HookDesktop, Events: []string{"deposit"}}.Validate(), "invalid event 'deposit'")
+}
+
+func TestAlertHookAccepts(t *testing.T) {
+	all := AlertHook{Type: HookDesktop}
+	assert.True(t, all.Accepts(EventBotError))
+
+	challenges := AlertHook{Type: HookDesktop, Events: []string{EventChallenge, EventOutputDeleted}}
+	assert.True(t, challenges.Accepts(EventOutputDeleted))
+	assert.False(t, challenges.Accepts(EventBotError))
+	assert.True(t, challenges.Accepts(EventTest))
+}
+
+func TestNotifierFiresHooks(t *testing.T) {
+	received := make(chan Event, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var event Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		received <- event
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	execOutput := filepath.Join(t.TempDir(), "event.json")
+	var desktop []string
+	var logs []string
+	notifier := NewNotifier([]AlertHook{
+		{Type: HookWebhook, Target: server.URL + "/alerts"},
+		{Type: HookExec, Target: `{ cat; echo; echo "$WEAVE_EVENT_TYPE"; } > "$OUT"`},
+		{Type: HookDesktop, Events: []string{EventOutputDeleted}},
+	}, func(message string) { logs = append(logs, message) })
+	notifier.desktop = func(title, message string) error {
+		desktop = append(desktop, title)
+		return nil
+	}
+	t.Setenv("OUT", execOutput)
+
+	event := Event{Type: EventChallenge, Bot: "challenger", BridgeId: 7, Message: "invalid output #12", Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, 0, notifier.Notify(event))
+
+	assert.Equal(t, event, <-received)
+	bz, err := os.ReadFile(execOutput)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"message":"invalid output #12"`)
+	assert.Contains(t, string(bz), "\nchallenge\n")
+	assert.Empty(t, desktop, "the desktop hook only accepts deleted outputs")
+	assert.Equal(t, []string{"ALERT challenge: invalid output #12"}, logs)
+
+	assert.Equal(t, 0, notifier.Notify(SampleEvent(time.Now())))
+	assert.Equal(t, EventTest, (<-received).Type)
+	assert.Equal(t, []string{"weave: challenger test"}, desktop)
+}
+
+func TestNotifierReportsFailingHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var logs []string
+	notifier := NewNotifier([]AlertHook{
+		{Type: HookWebhook, Target: server.URL},
+		{Type: HookExec, Target: "exit 3"},
+	}, func(message string) { logs = append(logs, message) })
+
+	assert.Equal(t, 2, notifier.Notify(SampleEvent(time.Now())))
+	assert.Len(t, logs, 3)
+	assert.Contains(t, logs[1], "Failed to fire the webhook hook")
+	assert.Contains(t, logs[2], "Failed to fire the exec hook")
+}
+
+func TestNotifierStopsSlowExecHooks(t *testing.T) {
+	var logs []string
+	notifier := NewNotifier([]AlertHook{{Type: HookExec, Target: "sleep 10"}}, func(message string) { logs = append(logs, message) })
+	notifier.execTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	assert.Equal(t, 1, notifier.Notify(SampleEvent(time.Now())))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Contains(t, logs[1], "Failed to fire the exec hook: 'sleep 10' did not finish within 100ms")
+}
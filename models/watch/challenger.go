@@ -0,0 +1,331 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/models/opinit_bots"
+	"github.com/initia-labs/weave/registry"
+	"github.com/initia-labs/weave/service"
+)
+
+const DefaultChallengerInterval = 30 * time.Second
+
+// reErrorLog matches the error, panic and fatal lines opinitd logs, in the json and console formats of its logger
+var reErrorLog = regexp.MustCompile(`"level":"(error|dpanic|panic|fatal)"|\t(ERROR|DPANIC|PANIC|FATAL)\t|^panic: `)
+
+// logSource returns the lines the challenger logged since the previous read
+type logSource interface {
+	Read() ([]string, error)
+}
+
+// ChallengerWatcher polls the HTTP API of the challenger for challenges, the L1 for deleted outputs and the logs of
+// the challenger service for errors, and notifies the alert hooks of each of them
+type ChallengerWatcher struct {
+	notifier    *Notifier
+	log         func(string)
+	now         func() time.Time
+	activity    func() (*opinit_bots.ChallengerActivity, error)
+	outputIndex func(l1ChainId string, bridgeId uint64) (uint64, error)
+	logs        logSource
+
+	// seeded is set once the challenges are first read, they are only recorded then so that a restart does not alert
+	// again
+	seeded bool
+	// bridgeId is the last bridge id the challenger reported, the outputs are checked with it while its API is down
+	bridgeId        uint64
+	seenChallenges  map[string]bool
+	lastOutputIndex *uint64
+	unreachable     bool
+	logErr          string
+	l1Lcd           string
+}
+
+// NewChallengerWatcher watches the challenger configured in opInitHome and fires hooks on what it notices, logging
+// every check through log
+func NewChallengerWatcher(opInitHome string, hooks []AlertHook, log func(string)) (*ChallengerWatcher, error) {
+	w := &ChallengerWatcher{now: time.Now, seenChallenges: map[string]bool{}}
+	w.log = func(message string) {
+		log(fmt.Sprintf("%s %s", w.now().UTC().Format(time.RFC3339), message))
+	}
+	w.notifier = NewNotifier(hooks, w.log)
+	w.activity = func() (*opinit_bots.ChallengerActivity, error) {
+		return opinit_bots.QueryChallengerActivity(opInitHome)
+	}
+	w.outputIndex = w.queryLastOutputIndex
+	logs, err := newChallengerLogSource(w.now())
+	if err != nil {
+		return nil, err
+	}
+	w.logs = logs
+	return w, nil
+}
+
+// Run checks the challenger every interval until ctx is done
+func (w *ChallengerWatcher) Run(ctx context.Context, interval time.Duration) error {
+	w.log(fmt.Sprintf("Watching the challenger every %s", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Check(); err != nil {
+			w.log(fmt.Sprintf("Failed to check the challenger: %v", err))
+		}
+		select {
+		case <-ctx.Done():
+			w.log("Stopped watching the challenger")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check looks for new challenges, deleted outputs and errors of the challenger once
+func (w *ChallengerWatcher) Check() error {
+	activity, err := w.activity()
+	if activity == nil {
+		return err
+	}
+	if err == nil {
+		w.bridgeId = activity.BridgeId
+	}
+	event := Event{Bot: "challenger", L1ChainId: activity.L1ChainId, BridgeId: w.bridgeId}
+
+	if err != nil {
+		if !w.unreachable {
+			w.unreachable = true
+			w.notify(event, EventBotError, err.Error(), w.now())
+			if w.bridgeId == 0 {
+				w.log("The bridge id is not known yet, the outputs on the L1 are checked once the challenger API answers")
+			}
+		}
+	} else {
+		if w.unreachable {
+			w.unreachable = false
+			w.log(fmt.Sprintf("The challenger API at %s is reachable again", activity.Server))
+		}
+		w.checkChallenges(event, activity.LatestChallenges)
+		w.seeded = true
+		w.log(fmt.Sprintf("OK challenger of bridge %d: checked up to output #%d, %d challenges", activity.BridgeId, activity.LastOutputIndex, len(w.seenChallenges)))
+	}
+
+	// the outputs are on the L1, a challenger that is down does not stop them from being deleted
+	if w.bridgeId != 0 {
+		w.checkOutputs(event)
+	}
+	w.checkLogs(event)
+	return nil
+}
+
+func (w *ChallengerWatcher) checkChallenges(event Event, challenges []opinit_bots.ChallengeStatus) {
+	for _, challenge := range challenges {
+		key := fmt.Sprintf("%s/%s/%s", challenge.Time.UTC().Format(time.RFC3339Nano), challenge.EventType, challenge.Log)
+		if w.seenChallenges[key] {
+			continue
+		}
+		w.seenChallenges[key] = true
+		if !w.seeded {
+			continue
+		}
+		w.notify(event, EventChallenge, fmt.Sprintf("The challenger challenged %s: %s", challenge.EventType, challenge.Log), challenge.Time)
+	}
+}
+
+func (w *ChallengerWatcher) checkOutputs(event Event) {
+	index, err := w.outputIndex(event.L1ChainId, event.BridgeId)
+	if err != nil {
+		w.log(fmt.Sprintf("Failed to query the last output of bridge %d on the L1: %v", event.BridgeId, err))
+		return
+	}
+	if w.lastOutputIndex != nil && index < *w.lastOutputIndex {
+		w.notify(event, EventOutputDeleted, fmt.Sprintf("Outputs #%d to #%d of bridge %d were deleted from the L1, the last output is now #%d", index+1, *w.lastOutputIndex, event.BridgeId, index), w.now())
+	}
+	w.lastOutputIndex = &index
+}
+
+func (w *ChallengerWatcher) checkLogs(event Event) {
+	lines, err := w.logs.Read()
+	if err != nil {
+		// the same failure would be logged on every check
+		if err.Error() != w.logErr {
+			w.logErr = err.Error()
+			w.log(fmt.Sprintf("Failed to read the logs of the challenger: %v", err))
+		}
+		return
+	}
+	w.logErr = ""
+	var errorLines []string
+	for _, line := range lines {
+		if reErrorLog.MatchString(line) {
+			errorLines = append(errorLines, strings.TrimSpace(line))
+		}
+	}
+	switch len(errorLines) {
+	case 0:
+	case 1:
+		w.notify(event, EventBotError, fmt.Sprintf("The challenger logged an error: %s", errorLines[0]), w.now())
+	default:
+		w.notify(event, EventBotError, fmt.Sprintf("The challenger logged %d errors, the first one: %s", len(errorLines), errorLines[0]), w.now())
+	}
+}
+
+func (w *ChallengerWatcher) notify(event Event, eventType, message string, at time.Time) {
+	event.Type = eventType
+	event.Message = message
+	event.Time = at.UTC()
+	w.notifier.Notify(event)
+}
+
+func (w *ChallengerWatcher) queryLastOutputIndex(l1ChainId string, bridgeId uint64) (uint64, error) {
+	if w.l1Lcd == "" {
+		l1Registry, err := registry.GetL1ChainRegistry(l1ChainId)
+		if err != nil {
+			return 0, err
+		}
+		if w.l1Lcd, err = l1Registry.GetActiveLcd(); err != nil {
+			return 0, err
+		}
+	}
+	output, err := cosmosutils.QueryLastOutputProposal(w.l1Lcd, strconv.FormatUint(bridgeId, 10))
+	if err != nil {
+		return 0, err
+	}
+	if output == nil {
+		return 0, nil
+	}
+	index, err := strconv.ParseUint(output.OutputIndex, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid output index '%s': %v", output.OutputIndex, err)
+	}
+	return index, nil
+}
+
+func newChallengerLogSource(since time.Time) (logSource, error) {
+	slug, err := service.OPinitChallenger.GetServiceSlug()
+	if err != nil {
+		return nil, err
+	}
+	switch runtime.GOOS {
+	case "linux":
+		return &journalSource{unit: slug + ".service", since: since}, nil
+	case "darwin":
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user home directory: %v", err)
+		}
+		return newFileSource(
+			filepath.Join(userHome, common.WeaveLogDirectory, fmt.Sprintf("%s.stdout.log", slug)),
+			filepath.Join(userHome, common.WeaveLogDirectory, fmt.Sprintf("%s.stderr.log", slug)),
+		), nil
+	default:
+		return nil, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+	}
+}
+
+// journalSource reads the journal of the systemd unit of the challenger, resuming from the cursor of the last read
+type journalSource struct {
+	unit   string
+	since  time.Time
+	cursor string
+}
+
+func (s *journalSource) Read() ([]string, error) {
+	args := []string{"-u", s.unit, "-o", "cat", "--no-pager", "--show-cursor"}
+	if s.cursor != "" {
+		args = append(args, "--after-cursor", s.cursor)
+	} else {
+		args = append(args, "--since", s.since.Local().Format(time.DateTime))
+	}
+	output, err := exec.Command("journalctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run journalctl: %v", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if cursor, ok := strings.CutPrefix(line, "-- cursor: "); ok {
+			s.cursor = cursor
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// fileSource reads the log files of the launchd agent of the challenger from where the last read stopped
+type fileSource struct {
+	offsets map[string]int64
+	paths   []string
+}
+
+// newFileSource starts reading paths from their current end
+func newFileSource(paths ...string) *fileSource {
+	s := &fileSource{offsets: map[string]int64{}, paths: paths}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			s.offsets[path] = info.Size()
+		}
+	}
+	return s
+}
+
+func (s *fileSource) Read() ([]string, error) {
+	var lines []string
+	for _, path := range s.paths {
+		read, err := s.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, read...)
+	}
+	return lines, nil
+}
+
+func (s *fileSource) readFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %v", path, err)
+	}
+	offset := s.offsets[path]
+	if info.Size() < offset {
+		// the logs were pruned
+		offset = 0
+	}
+	if _, err = file.Seek(offset, 0); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var lines []string
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// a line without its newline is still being written, it is read on the next check
+			break
+		}
+		offset += int64(len(line))
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+	}
+	s.offsets[path] = offset
+	return lines, nil
+}
@@ -0,0 +1,187 @@
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/models/opinit_bots"
+)
+
+type fakeLogs struct {
+	lines []string
+	err   error
+}
+
+func (l *fakeLogs) Read() ([]string, error) {
+	lines := l.lines
+	l.lines = nil
+	return lines, l.err
+}
+
+type fakeChallenger struct {
+	activity    *opinit_bots.ChallengerActivity
+	err         error
+	outputIndex uint64
+	logs        *fakeLogs
+}
+
+func newTestChallengerWatcher(challenger *fakeChallenger) (*ChallengerWatcher, *[]Event) {
+	var events []Event
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	w := &ChallengerWatcher{
+		now:            func() time.Time { return now },
+		log:            func(string) {},
+		seenChallenges: map[string]bool{},
+		activity: func() (*opinit_bots.ChallengerActivity, error) {
+			return challenger.activity, challenger.err
+		},
+		outputIndex: func(string, uint64) (uint64, error) {
+			return challenger.outputIndex, nil
+		},
+		logs: challenger.logs,
+	}
+	w.notifier = NewNotifier([]AlertHook{{Type: HookDesktop}}, w.log)
+	w.notifier.desktop = func(string, string) error { return nil }
+	w.notifier.log = func(message string) {
+		parts := strings.SplitN(strings.TrimPrefix(message, "ALERT "), ": ", 2)
+		events = append(events, Event{Type: parts[0], Message: parts[1]})
+	}
+	return w, &events
+}
+
+func TestChallengerWatcherAlertsOnNewChallenges(t *testing.T) {
+	earlier := opinit_bots.ChallengeStatus{EventType: "Output", Log: "output #3 mismatch", Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+	challenger := &fakeChallenger{
+		activity:    &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2", BridgeId: 7, LatestChallenges: []opinit_bots.ChallengeStatus{earlier}},
+		outputIndex: 12,
+		logs:        &fakeLogs{},
+	}
+	w, events := newTestChallengerWatcher(challenger)
+
+	assert.NoError(t, w.Check())
+	assert.Empty(t, *events, "challenges made before the watch started are not alerted")
+
+	challenger.activity.LatestChallenges = append(challenger.activity.LatestChallenges, opinit_bots.ChallengeStatus{EventType: "Output", Log: "output #13 mismatch", Time: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)})
+	challenger.outputIndex = 13
+	assert.NoError(t, w.Check())
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []Event{{Type: EventChallenge, Message: "The challenger challenged Output: output #13 mismatch"}}, *events)
+}
+
+func TestChallengerWatcherAlertsOnDeletedOutputs(t *testing.T) {
+	challenger := &fakeChallenger{
+		activity:    &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2", BridgeId: 7},
+		outputIndex: 13,
+		logs:        &fakeLogs{},
+	}
+	w, events := newTestChallengerWatcher(challenger)
+
+	assert.NoError(t, w.Check())
+	challenger.outputIndex = 11
+	assert.NoError(t, w.Check())
+	challenger.outputIndex = 12
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []Event{{Type: EventOutputDeleted, Message: "Outputs #12 to #13 of bridge 7 were deleted from the L1, the last output is now #11"}}, *events)
+}
+
+func TestChallengerWatcherSeedsOnFirstSuccessfulCheck(t *testing.T) {
+	earlier := opinit_bots.ChallengeStatus{EventType: "Output", Log: "output #3 mismatch", Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+	challenger := &fakeChallenger{
+		activity: &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2"},
+		err:      errors.New("unreachable"),
+		logs:     &fakeLogs{},
+	}
+	w, events := newTestChallengerWatcher(challenger)
+
+	assert.NoError(t, w.Check())
+	assert.Len(t, *events, 1)
+
+	// the challenges read when the API first answers were made before the watch started
+	challenger.err = nil
+	challenger.activity = &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2", BridgeId: 7, LatestChallenges: []opinit_bots.ChallengeStatus{earlier}}
+	assert.NoError(t, w.Check())
+	assert.Len(t, *events, 1)
+}
+
+func TestChallengerWatcherChecksOutputsWhileUnreachable(t *testing.T) {
+	challenger := &fakeChallenger{
+		activity:    &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2", BridgeId: 7},
+		outputIndex: 13,
+		logs:        &fakeLogs{},
+	}
+	w, events := newTestChallengerWatcher(challenger)
+	assert.NoError(t, w.Check())
+
+	// the API no longer reports the bridge id, the last known one is used
+	challenger.activity = &opinit_bots.ChallengerActivity{L1ChainId: "initiation-2"}
+	challenger.err = errors.New("unreachable")
+	challenger.outputIndex = 11
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []Event{
+		{Type: EventBotError, Message: "unreachable"},
+		{Type: EventOutputDeleted, Message: "Outputs #12 to #13 of bridge 7 were deleted from the L1, the last output is now #11"},
+	}, *events)
+}
+
+func TestChallengerWatcherAlertsOnBotErrors(t *testing.T) {
+	challenger := &fakeChallenger{
+		activity: &opinit_bots.ChallengerActivity{Server: "http://localhost:3001", L1ChainId: "initiation-2"},
+		err:      errors.New("failed to query http://localhost:3001/status, is the challenger running?"),
+		logs:     &fakeLogs{},
+	}
+	w, events := newTestChallengerWatcher(challenger)
+
+	assert.NoError(t, w.Check())
+	assert.NoError(t, w.Check())
+	assert.Len(t, *events, 1, "an unreachable challenger is alerted once")
+	assert.Equal(t, EventBotError, (*events)[0].Type)
+
+	challenger.err = nil
+	challenger.logs.lines = []string{
+		`{"level":"info","msg":"processed block"}`,
+		`{"level":"error","msg":"failed to handle output","error":"connection refused"}`,
+	}
+	assert.NoError(t, w.Check())
+	assert.Len(t, *events, 2)
+	assert.Equal(t, `The challenger logged an error: {"level":"error","msg":"failed to handle output","error":"connection refused"}`, (*events)[1].Message)
+
+	challenger.err = errors.New("unreachable again")
+	assert.NoError(t, w.Check())
+	assert.Len(t, *events, 3)
+
+	err := (&ChallengerWatcher{activity: func() (*opinit_bots.ChallengerActivity, error) {
+		return nil, errors.New("failed to read challenger.json")
+	}}).Check()
+	assert.EqualError(t, err, "failed to read challenger.json")
+}
+
+func TestFileSourceReadsAppendedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "opinitd.challenger.stderr.log")
+	assert.NoError(t, os.WriteFile(path, []byte("before the watch\n"), 0644))
+	source := newFileSource(path, filepath.Join(t.TempDir(), "missing.log"))
+
+	lines, err := source.Read()
+	assert.NoError(t, err)
+	assert.Empty(t, lines)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.WriteString("first\nsecond\npartial")
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	lines, err = source.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, lines)
+
+	assert.NoError(t, os.WriteFile(path, []byte("pruned\n"), 0644))
+	lines, err = source.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pruned"}, lines)
+}
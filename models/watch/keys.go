@@ -0,0 +1,246 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/minitia"
+	"github.com/initia-labs/weave/models/opinit_bots"
+	"github.com/initia-labs/weave/models/relayer"
+	"github.com/initia-labs/weave/registry"
+)
+
+var reGasPriceDenom = regexp.MustCompile(`^[0-9.]+([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// WatchedChain is a chain the watched keys pay fees on
+type WatchedChain struct {
+	Name    string
+	ChainId string
+	RPC     string
+	// LCD is used to query the balances when set, the RPC otherwise
+	LCD      string
+	Denom    string
+	GasPrice string
+	// TopUp tells whether the gas station can send on the chain
+	TopUp bool
+	// l1Lcd picks the version of initiad that queries and sends
+	l1Lcd string
+	// celestiaOf is the L1 chain id the Celestia network is paired with, the gas station sends on it with celestia-appd
+	celestiaOf string
+}
+
+// WatchedKey is an operational key weave set up, whose balance on a chain is watched
+type WatchedKey struct {
+	Role    string        `json:"role"`
+	Chain   string        `json:"chain"`
+	Address string        `json:"address"`
+	On      *WatchedChain `json:"-"`
+}
+
+func (k WatchedKey) ThresholdKey() string {
+	return ThresholdKey(k.Role, k.Chain)
+}
+
+func (k WatchedKey) String() string {
+	return fmt.Sprintf("%s on %s (%s)", k.Role, k.Chain, k.Address)
+}
+
+type hermesChain struct {
+	ID       string `toml:"id"`
+	RPCAddr  string `toml:"rpc_addr"`
+	GasPrice struct {
+		Price float64 `toml:"price"`
+		Denom string  `toml:"denom"`
+	} `toml:"gas_price"`
+}
+
+type hermesConfig struct {
+	Chains []hermesChain `toml:"chains"`
+}
+
+// loadHermesChains returns the L1 and L2 of the relayer set up by `weave relayer init`, in this order
+func loadHermesChains(configPath string) ([]hermesChain, error) {
+	bz, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", configPath, err)
+	}
+	var config hermesConfig
+	if err = toml.Unmarshal(bz, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", configPath, err)
+	}
+	if len(config.Chains) < 2 {
+		return nil, fmt.Errorf("invalid configuration %s: missing chain configuration", configPath)
+	}
+	return config.Chains[:2], nil
+}
+
+func gasPriceDenom(gasPrice string) (string, error) {
+	matches := reGasPriceDenom.FindStringSubmatch(gasPrice)
+	if matches == nil {
+		return "", fmt.Errorf("invalid gas price '%s'", gasPrice)
+	}
+	return matches[1], nil
+}
+
+func readBotConfig(opInitHome, bot string, config interface{}) (bool, error) {
+	configPath := filepath.Join(opInitHome, bot+".json")
+	if !io.FileOrFolderExists(configPath) {
+		return false, nil
+	}
+	bz, err := os.ReadFile(configPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %v", configPath, err)
+	}
+	if err = json.Unmarshal(bz, config); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %v", configPath, err)
+	}
+	return true, nil
+}
+
+// DiscoverKeys finds the keys of the OPinit bots in opInitHome and of the relayer, and the chains they pay fees on.
+// Keys whose address cannot be found are reported through warn and left out.
+func DiscoverKeys(opInitHome string, warn func(string)) ([]WatchedKey, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+
+	var executor opinit_bots.ExecutorConfig
+	hasExecutor, err := readBotConfig(opInitHome, "executor", &executor)
+	if err != nil {
+		return nil, err
+	}
+	var challenger opinit_bots.ChallengerConfig
+	hasChallenger, err := readBotConfig(opInitHome, "challenger", &challenger)
+	if err != nil {
+		return nil, err
+	}
+	var hermesChains []hermesChain
+	hermesConfigPath := filepath.Join(userHome, relayer.HermesHome, "config.toml")
+	if io.FileOrFolderExists(hermesConfigPath) {
+		if hermesChains, err = loadHermesChains(hermesConfigPath); err != nil {
+			return nil, err
+		}
+	}
+
+	var l1ChainId string
+	switch {
+	case hasExecutor:
+		l1ChainId = executor.L1Node.ChainID
+	case hasChallenger:
+		l1ChainId = challenger.L1Node.ChainID
+	case len(hermesChains) > 0:
+		l1ChainId = hermesChains[0].ID
+	default:
+		return nil, fmt.Errorf("no OPinit bot in %s nor relayer is set up, there is no key to watch", opInitHome)
+	}
+	l1, err := newL1Chain(l1ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []WatchedKey
+	opinitdPath := filepath.Join(userHome, common.WeaveDataDirectory, opinit_bots.AppName)
+	addOPinitKey := func(role opinit_bots.KeyRole, chain string, on *WatchedChain) {
+		address, err := cosmosutils.OPInitGetAddressForKey(opinitdPath, role.KeyName(), opInitHome)
+		if err != nil {
+			warn(fmt.Sprintf("Not watching the %s key: %v", role, err))
+			return
+		}
+		keys = append(keys, WatchedKey{Role: string(role), Chain: chain, Address: address, On: on})
+	}
+
+	if hasExecutor {
+		l2 := &WatchedChain{Name: ChainL2, ChainId: executor.L2Node.ChainID, RPC: executor.L2Node.RPCAddress, GasPrice: executor.L2Node.GasPrice, TopUp: true, l1Lcd: l1.LCD}
+		if l2.Denom, err = gasPriceDenom(l2.GasPrice); err != nil {
+			return nil, fmt.Errorf("failed to read the L2 gas denom of the executor: %v", err)
+		}
+		addOPinitKey(opinit_bots.BridgeExecutorRole, ChainL1, l1)
+		addOPinitKey(opinit_bots.BridgeExecutorRole, ChainL2, l2)
+		if !executor.DisableOutputSubmitter {
+			addOPinitKey(opinit_bots.OutputSubmitterRole, ChainL1, l1)
+		}
+		if !executor.DisableBatchSubmitter {
+			da := l1
+			if executor.DANode.ChainID != l1ChainId {
+				if da, err = newCelestiaChain(l1ChainId); err != nil {
+					return nil, err
+				}
+			}
+			addOPinitKey(opinit_bots.BatchSubmitterRole, ChainDA, da)
+		}
+	}
+	if hasChallenger {
+		addOPinitKey(opinit_bots.ChallengerRole, ChainL1, l1)
+	}
+
+	if len(hermesChains) > 0 {
+		hermesPath := filepath.Join(userHome, common.WeaveDataDirectory, "hermes")
+		l2 := &WatchedChain{
+			Name:     ChainL2,
+			ChainId:  hermesChains[1].ID,
+			RPC:      hermesChains[1].RPCAddr,
+			Denom:    hermesChains[1].GasPrice.Denom,
+			GasPrice: strconv.FormatFloat(hermesChains[1].GasPrice.Price, 'f', -1, 64) + hermesChains[1].GasPrice.Denom,
+			TopUp:    true,
+			l1Lcd:    l1.LCD,
+		}
+		for _, chain := range []struct {
+			name    string
+			chainId string
+			on      *WatchedChain
+		}{
+			{ChainL1, hermesChains[0].ID, l1},
+			{ChainL2, hermesChains[1].ID, l2},
+		} {
+			address, found := cosmosutils.GetHermesRelayerAddress(hermesPath, chain.chainId)
+			if !found {
+				warn(fmt.Sprintf("Not watching the relayer key on %s: hermes has no weave-relayer key for %s", chain.name, chain.chainId))
+				continue
+			}
+			keys = append(keys, WatchedKey{Role: RelayerRole, Chain: chain.name, Address: address, On: chain.on})
+		}
+	}
+
+	return keys, nil
+}
+
+func newL1Chain(chainId string) (*WatchedChain, error) {
+	l1Registry, err := registry.GetL1ChainRegistry(chainId)
+	if err != nil {
+		return nil, err
+	}
+	chain := &WatchedChain{Name: ChainL1, ChainId: chainId, Denom: opinit_bots.DefaultInitiaGasDenom, TopUp: true}
+	if chain.RPC, err = l1Registry.GetActiveRpc(); err != nil {
+		return nil, err
+	}
+	if chain.LCD, err = l1Registry.GetActiveLcd(); err != nil {
+		return nil, err
+	}
+	if chain.GasPrice, err = l1Registry.GetGasPriceByDenom(chain.Denom); err != nil {
+		return nil, err
+	}
+	chain.l1Lcd = chain.LCD
+	return chain, nil
+}
+
+func newCelestiaChain(l1ChainId string) (*WatchedChain, error) {
+	celestiaRegistry, err := registry.GetCelestiaChainRegistry(l1ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Celestia registry: %v", err)
+	}
+	chain := &WatchedChain{Name: ChainCelestia, ChainId: celestiaRegistry.GetChainId(), Denom: minitia.DefaultCelestiaGasDenom, TopUp: true, celestiaOf: l1ChainId}
+	if chain.LCD, err = celestiaRegistry.GetActiveLcd(); err != nil {
+		return nil, fmt.Errorf("failed to reach Celestia: %v", err)
+	}
+	return chain, nil
+}
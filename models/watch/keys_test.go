@@ -0,0 +1,47 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHermesChains(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte(`
+[global]
+log_level = 'info'
+
+[[chains]]
+id = 'initiation-2'
+rpc_addr = 'https://rpc.testnet.initia.xyz'
+gas_price = { price = 0.15, denom = 'uinit' }
+
+[[chains]]
+id = 'minimove-1'
+rpc_addr = 'http://localhost:26657'
+gas_price = { price = 0, denom = 'umin' }
+`), 0644))
+
+	chains, err := loadHermesChains(configPath)
+	assert.NoError(t, err)
+	assert.Len(t, chains, 2)
+	assert.Equal(t, "initiation-2", chains[0].ID)
+	assert.Equal(t, "http://localhost:26657", chains[1].RPCAddr)
+	assert.Equal(t, "umin", chains[1].GasPrice.Denom)
+}
+
+func TestGasPriceDenom(t *testing.T) {
+	denom, err := gasPriceDenom("0.15uinit")
+	assert.NoError(t, err)
+	assert.Equal(t, "uinit", denom)
+
+	denom, err = gasPriceDenom("0l2/771d639f30fbe45e3fbca954ffbe2fcc26f915f5513c67a4a2d0bc1d635bdefd")
+	assert.NoError(t, err)
+	assert.Equal(t, "l2/771d639f30fbe45e3fbca954ffbe2fcc26f915f5513c67a4a2d0bc1d635bdefd", denom)
+
+	_, err = gasPriceDenom("uinit")
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/service"
+)
+
+// CheckServiceKeyring makes sure the service can unlock the keyring the gas station signs from. The service reads
+// neither the terminal nor the env of the shell that starts it, so the passphrase of the file backend must be in a file.
+func CheckServiceKeyring() error {
+	if config.GetKeyringBackend() != string(cosmosutils.KeyringBackendFile) {
+		return nil
+	}
+	if strings.HasPrefix(config.GetKeyringPassphraseSource(), io.SecretFilePrefix) {
+		return nil
+	}
+	return fmt.Errorf("the watch service cannot read the passphrase of the file keyring from the terminal or %s, keep it in a file with `weave keyring set file --passphrase file:PATH`", cosmosutils.KeyringPassphraseEnv)
+}
+
+// InstallService creates the service that runs `weave watch balances` for the OPinit bots in opInitHome. The service
+// runs the weave binary in use through a link in the weave data directory.
+func InstallService(opInitHome string) (service.Service, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the weave binary: %v", err)
+	}
+	if executable, err = filepath.EvalSymlinks(executable); err != nil {
+		return nil, fmt.Errorf("failed to find the weave binary: %v", err)
+	}
+	linkPath := filepath.Join(userHome, common.WeaveDataDirectory, "weave")
+	if err = os.MkdirAll(filepath.Dir(linkPath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", filepath.Dir(linkPath), err)
+	}
+	if err = os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove %s: %v", linkPath, err)
+	}
+	if err = os.Symlink(executable, linkPath); err != nil {
+		return nil, fmt.Errorf("failed to link %s to %s: %v", linkPath, executable, err)
+	}
+
+	s, err := service.NewService(service.WatchBalances)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Create("", opInitHome); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,232 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/models/minitia"
+	"github.com/initia-labs/weave/models/opinit_bots"
+	"github.com/initia-labs/weave/models/relayer"
+	"github.com/initia-labs/weave/styles"
+)
+
+const (
+	// ConfigKey is where the settings of `weave watch balances` are stored in the weave config
+	ConfigKey = "watch"
+
+	DefaultInterval = 10 * time.Minute
+
+	// RelayerRole is the hermes weave-relayer key
+	RelayerRole = "relayer"
+
+	ChainL1 = "L1"
+	ChainL2 = "L2"
+	ChainDA = "DA"
+	// ChainCelestia is the chain of a batch submitter on Celestia, it has its own daily cap in utia
+	ChainCelestia = "Celestia"
+)
+
+// Threshold is the balance under which a key is topped up and the amount it is topped up with, both in the gas denom
+// of the chain. A zero top-up only reports the low balance.
+type Threshold struct {
+	Min   string `json:"min"`
+	TopUp string `json:"top_up"`
+}
+
+// Settings configures `weave watch balances`
+type Settings struct {
+	Interval string `json:"interval,omitempty"`
+	// Thresholds are keyed by role/chain, eg. output-submitter/l1
+	Thresholds map[string]Threshold `json:"thresholds,omitempty"`
+	// DailyCaps bounds what the gas station sends per UTC day on l1, l2 and celestia, in the gas denom of the chain
+	DailyCaps map[string]string `json:"daily_caps,omitempty"`
+}
+
+// ThresholdKey returns the key of the threshold of role on chain in Settings.Thresholds
+func ThresholdKey(role, chain string) string {
+	return fmt.Sprintf("%s/%s", role, strings.ToLower(chain))
+}
+
+// defaultThreshold tops a key up with what it is funded with at launch once half of it is spent
+func defaultThreshold(funding string) Threshold {
+	amount, _ := new(big.Int).SetString(funding, 10)
+	return Threshold{Min: new(big.Int).Div(amount, big.NewInt(2)).String(), TopUp: funding}
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Interval: DefaultInterval.String(),
+		Thresholds: map[string]Threshold{
+			ThresholdKey(string(opinit_bots.BridgeExecutorRole), ChainL1):  defaultThreshold(minitia.DefaultL1BridgeExecutorBalance),
+			ThresholdKey(string(opinit_bots.BridgeExecutorRole), ChainL2):  defaultThreshold(minitia.DefaultL2BridgeExecutorBalance),
+			ThresholdKey(string(opinit_bots.OutputSubmitterRole), ChainL1): defaultThreshold(minitia.DefaultL1OutputSubmitterBalance),
+			ThresholdKey(string(opinit_bots.BatchSubmitterRole), ChainDA):  defaultThreshold(minitia.DefaultL1BatchSubmitterBalance),
+			ThresholdKey(string(opinit_bots.ChallengerRole), ChainL1):      defaultThreshold(minitia.DefaultL1ChallengerBalance),
+			ThresholdKey(RelayerRole, ChainL1):                             defaultThreshold(relayer.DefaultL1RelayerBalance),
+			ThresholdKey(RelayerRole, ChainL2):                             defaultThreshold(relayer.DefaultL2RelayerBalance),
+		},
+		DailyCaps: map[string]string{
+			strings.ToLower(ChainL1):       "20000000",
+			strings.ToLower(ChainL2):       "200000000",
+			strings.ToLower(ChainCelestia): "5000000",
+		},
+	}
+}
+
+// LoadSettings returns the default settings overridden by the ones in the weave config
+func LoadSettings() (Settings, error) {
+	var configured Settings
+	if saved := config.GetConfig(ConfigKey); saved != nil {
+		bz, err := json.Marshal(saved)
+		if err != nil {
+			return Settings{}, fmt.Errorf("failed to read the watch settings: %v", err)
+		}
+		if err = json.Unmarshal(bz, &configured); err != nil {
+			return Settings{}, fmt.Errorf("failed to parse the watch settings: %v", err)
+		}
+	}
+	settings := mergeSettings(DefaultSettings(), configured)
+	if err := settings.Validate(); err != nil {
+		return Settings{}, fmt.Errorf("%v, fix the %s section of the weave config", err, ConfigKey)
+	}
+	return settings, nil
+}
+
+func mergeSettings(defaults, configured Settings) Settings {
+	if configured.Interval != "" {
+		defaults.Interval = configured.Interval
+	}
+	for key, threshold := range configured.Thresholds {
+		merged := defaults.Thresholds[key]
+		if threshold.Min != "" {
+			merged.Min = threshold.Min
+		}
+		if threshold.TopUp != "" {
+			merged.TopUp = threshold.TopUp
+		}
+		defaults.Thresholds[key] = merged
+	}
+	for chain, amount := range configured.DailyCaps {
+		defaults.DailyCaps[chain] = amount
+	}
+	return defaults
+}
+
+func (s Settings) Validate() error {
+	if _, err := s.GetInterval(); err != nil {
+		return err
+	}
+	for key, threshold := range s.Thresholds {
+		if _, _, err := threshold.amounts(); err != nil {
+			return fmt.Errorf("invalid threshold %s: %v", key, err)
+		}
+	}
+	for chain, amount := range s.DailyCaps {
+		if _, err := parseAmount(amount); err != nil {
+			return fmt.Errorf("invalid daily cap of %s: %v", chain, err)
+		}
+	}
+	return nil
+}
+
+func (s Settings) GetInterval() (time.Duration, error) {
+	interval, err := time.ParseDuration(s.Interval)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid interval '%s', expected a positive duration such as 10m", s.Interval)
+	}
+	return interval, nil
+}
+
+// dailyCap returns what the gas station may send per day on chain, nothing when no cap is set
+func (s Settings) dailyCap(chain string) *big.Int {
+	amount, err := parseAmount(s.DailyCaps[strings.ToLower(chain)])
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return amount
+}
+
+func (t Threshold) amounts() (*big.Int, *big.Int, error) {
+	minimum, err := parseAmount(t.Min)
+	if err != nil {
+		return nil, nil, fmt.Errorf("min: %v", err)
+	}
+	topUp, err := parseAmount(t.TopUp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("top_up: %v", err)
+	}
+	return minimum, topUp, nil
+}
+
+func parseAmount(amount string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("'%s' is not zero or a positive integer", amount)
+	}
+	return value, nil
+}
+
+// SetThreshold stores the threshold of key, a role/chain such as output-submitter/l1, in the weave config
+func SetThreshold(key string, threshold Threshold) error {
+	key = strings.ToLower(key)
+	if _, ok := DefaultSettings().Thresholds[key]; !ok {
+		return fmt.Errorf("unknown key '%s'. Valid options are: [%s]", key, strings.Join(ThresholdKeys(), ", "))
+	}
+	if _, _, err := threshold.amounts(); err != nil {
+		return err
+	}
+	return config.SetConfig(fmt.Sprintf("%s.thresholds.%s", ConfigKey, key), map[string]interface{}{
+		"min":    threshold.Min,
+		"top_up": threshold.TopUp,
+	})
+}
+
+// SetDailyCap stores what the gas station may send per day on chain, l1, l2 or celestia, in the weave config
+func SetDailyCap(chain, amount string) error {
+	chain = strings.ToLower(chain)
+	if _, ok := DefaultSettings().DailyCaps[chain]; !ok {
+		return fmt.Errorf("invalid chain '%s'. Valid options are: [%s]", chain, strings.Join(sortedKeys(DefaultSettings().DailyCaps), ", "))
+	}
+	if _, err := parseAmount(amount); err != nil {
+		return err
+	}
+	return config.SetConfig(fmt.Sprintf("%s.daily_caps.%s", ConfigKey, chain), amount)
+}
+
+// ThresholdKeys returns the role/chain keys a threshold can be set for
+func ThresholdKeys() []string {
+	return sortedKeys(DefaultSettings().Thresholds)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s Settings) String() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldText("Thresholds\n", styles.Cyan))
+	for _, key := range sortedKeys(s.Thresholds) {
+		threshold := s.Thresholds[key]
+		topUp := fmt.Sprintf("top up %s", threshold.TopUp)
+		if threshold.TopUp == "0" {
+			topUp = styles.Text("report only", styles.Gray)
+		}
+		b.WriteString(fmt.Sprintf("  %-24s below %-12s %s\n", key, threshold.Min, topUp))
+	}
+	b.WriteString(styles.BoldText("\nDaily caps\n", styles.Cyan))
+	for _, chain := range sortedKeys(s.DailyCaps) {
+		b.WriteString(fmt.Sprintf("  %-24s %s\n", chain, s.DailyCaps[chain]))
+	}
+	b.WriteString(fmt.Sprintf("\nChecked every %s\n", s.Interval))
+	return b.String()
+}
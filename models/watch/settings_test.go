@@ -0,0 +1,46 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultSettings(t *testing.T) {
+	settings := DefaultSettings()
+	assert.NoError(t, settings.Validate())
+	assert.Equal(t, Threshold{Min: "1000000", TopUp: "2000000"}, settings.Thresholds["output-submitter/l1"])
+	assert.Equal(t, Threshold{Min: "500000", TopUp: "1000000"}, settings.Thresholds["relayer/l2"])
+	interval, err := settings.GetInterval()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultInterval, interval)
+}
+
+func TestMergeSettings(t *testing.T) {
+	settings := mergeSettings(DefaultSettings(), Settings{
+		Interval:   "5m",
+		Thresholds: map[string]Threshold{"output-submitter/l1": {Min: "3000000"}},
+		DailyCaps:  map[string]string{"l1": "0"},
+	})
+	assert.NoError(t, settings.Validate())
+	assert.Equal(t, Threshold{Min: "3000000", TopUp: "2000000"}, settings.Thresholds["output-submitter/l1"])
+	assert.Equal(t, "200000000", settings.DailyCaps["l2"])
+	assert.Equal(t, int64(0), settings.dailyCap("L1").Int64())
+	interval, err := settings.GetInterval()
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, interval)
+}
+
+func TestSettingsValidate(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Thresholds["relayer/l1"] = Threshold{Min: "-1", TopUp: "1"}
+	assert.ErrorContains(t, settings.Validate(), "invalid threshold relayer/l1: min: '-1' is not zero or a positive integer")
+
+	settings = DefaultSettings()
+	settings.Interval = "soon"
+	assert.ErrorContains(t, settings.Validate(), "invalid interval 'soon'")
+
+	assert.ErrorContains(t, SetThreshold("operator/l1", Threshold{Min: "1", TopUp: "1"}), "unknown key 'operator/l1'")
+	assert.ErrorContains(t, SetDailyCap("da", "1"), "invalid chain 'da'. Valid options are: [celestia, l1, l2]")
+}
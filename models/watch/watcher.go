@@ -0,0 +1,320 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+	"github.com/initia-labs/weave/cosmosutils"
+	"github.com/initia-labs/weave/io"
+	"github.com/initia-labs/weave/models/minitia"
+)
+
+// TopUpLedgerFilename records the top-ups of the day in the weave data directory, so that the daily caps hold
+// across restarts
+const TopUpLedgerFilename = "watch.topups.json"
+
+// TopUp is a transfer from the gas station to a watched key
+type TopUp struct {
+	Time    time.Time `json:"time"`
+	Key     string    `json:"key"`
+	Chain   string    `json:"chain"`
+	ChainId string    `json:"chain_id"`
+	Address string    `json:"address"`
+	Amount  string    `json:"amount"`
+	Denom   string    `json:"denom"`
+	TxHash  string    `json:"tx_hash"`
+}
+
+type topUpLedger struct {
+	Day    string  `json:"day"`
+	TopUps []TopUp `json:"top_ups"`
+}
+
+// Watcher checks the balances of the watched keys against their thresholds and tops up the low ones from the gas
+// station, within the daily caps
+type Watcher struct {
+	Settings Settings
+	// DryRun reports the top-ups without sending them
+	DryRun bool
+
+	ledgerPath string
+	// unsavedLedger holds the top-ups of the day that could not be written, top-ups stop until they are
+	unsavedLedger *topUpLedger
+	log           func(string)
+	discover      func() ([]WatchedKey, error)
+	balanceOf     func(WatchedKey) (*big.Int, error)
+	send          func(WatchedKey, *big.Int) (string, error)
+	now           func() time.Time
+
+	querier            *cosmosutils.InitiadQuerier
+	executor           *cosmosutils.InitiadTxExecutor
+	celestiaBinaryPath string
+}
+
+// NewWatcher watches the keys of the OPinit bots in opInitHome and of the relayer, logging every check and top-up
+// through log
+func NewWatcher(opInitHome string, settings Settings, dryRun bool, log func(string)) (*Watcher, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	w := &Watcher{
+		Settings:   settings,
+		DryRun:     dryRun,
+		ledgerPath: filepath.Join(userHome, common.WeaveDataDirectory, TopUpLedgerFilename),
+		now:        time.Now,
+	}
+	w.log = func(message string) {
+		log(fmt.Sprintf("%s %s", w.now().UTC().Format(time.RFC3339), message))
+	}
+	w.discover = func() ([]WatchedKey, error) {
+		return DiscoverKeys(opInitHome, w.log)
+	}
+	w.balanceOf = w.queryBalance
+	w.send = w.sendFromGasStation
+	return w, nil
+}
+
+// Run checks the balances every interval until ctx is done
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) error {
+	w.log(fmt.Sprintf("Watching the balances every %s", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Check(); err != nil {
+			w.log(fmt.Sprintf("Failed to check the balances: %v", err))
+		}
+		select {
+		case <-ctx.Done():
+			w.log("Stopped watching the balances")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check finds the watched keys and tops up those below their threshold
+func (w *Watcher) Check() error {
+	keys, err := w.discover()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		w.checkKey(key)
+	}
+	return nil
+}
+
+func (w *Watcher) checkKey(key WatchedKey) {
+	threshold, ok := w.Settings.Thresholds[key.ThresholdKey()]
+	if !ok {
+		return
+	}
+	minimum, topUp, err := threshold.amounts()
+	if err != nil {
+		w.log(fmt.Sprintf("Skipping %s: invalid threshold %s: %v", key, key.ThresholdKey(), err))
+		return
+	}
+	balance, err := w.balanceOf(key)
+	if err != nil {
+		w.log(fmt.Sprintf("Failed to query the balance of %s: %v", key, err))
+		return
+	}
+	denom := key.On.Denom
+	if balance.Cmp(minimum) >= 0 {
+		w.log(fmt.Sprintf("OK %s: %s%s, threshold %s%s", key, balance, denom, minimum, denom))
+		return
+	}
+
+	low := fmt.Sprintf("LOW %s: %s%s, threshold %s%s", key, balance, denom, minimum, denom)
+	switch {
+	case topUp.Sign() == 0:
+		w.log(fmt.Sprintf("%s, top-ups of %s are disabled", low, key.ThresholdKey()))
+		return
+	case !key.On.TopUp:
+		w.log(fmt.Sprintf("%s, the gas station cannot top up on %s, fund %s yourself", low, key.On.Name, key.Address))
+		return
+	}
+
+	if err = w.flushLedger(); err != nil {
+		w.log(fmt.Sprintf("%s, not topping up until the top-ups of the day are recorded: %v", low, err))
+		return
+	}
+	ledger, err := w.loadLedger()
+	if err != nil {
+		w.log(fmt.Sprintf("%s, not topping up: %v", low, err))
+		return
+	}
+	dailyCap := w.Settings.dailyCap(key.On.Name)
+	sent := ledger.sent(key.On.Name)
+	if new(big.Int).Add(sent, topUp).Cmp(dailyCap) > 0 {
+		w.log(fmt.Sprintf("%s, not topping up %s%s: the gas station already sent %s%s of the daily cap of %s%s on %s", low, topUp, denom, sent, denom, dailyCap, denom, key.On.Name))
+		return
+	}
+	if w.DryRun {
+		w.log(fmt.Sprintf("%s, would top up %s%s from the gas station", low, topUp, denom))
+		return
+	}
+
+	w.log(fmt.Sprintf("%s, topping up %s%s from the gas station", low, topUp, denom))
+	txHash, err := w.send(key, topUp)
+	if err != nil {
+		w.log(fmt.Sprintf("Failed to top up %s: %v", key, err))
+		return
+	}
+	ledger.TopUps = append(ledger.TopUps, TopUp{
+		Time:    w.now().UTC(),
+		Key:     key.ThresholdKey(),
+		Chain:   key.On.Name,
+		ChainId: key.On.ChainId,
+		Address: key.Address,
+		Amount:  topUp.String(),
+		Denom:   denom,
+		TxHash:  txHash,
+	})
+	if err = w.saveLedger(ledger); err != nil {
+		w.unsavedLedger = ledger
+		w.log(fmt.Sprintf("Failed to record the top-up, no more top-ups are sent until it is: %v", err))
+	}
+	w.log(fmt.Sprintf("Topped up %s with %s%s, tx hash %s", key, topUp, denom, txHash))
+}
+
+// loadLedger returns the top-ups of the current UTC day
+func (w *Watcher) loadLedger() (*topUpLedger, error) {
+	today := w.now().UTC().Format(time.DateOnly)
+	ledger := &topUpLedger{Day: today}
+	if !io.FileOrFolderExists(w.ledgerPath) {
+		return ledger, nil
+	}
+	bz, err := os.ReadFile(w.ledgerPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", w.ledgerPath, err)
+	}
+	var saved topUpLedger
+	if err = json.Unmarshal(bz, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", w.ledgerPath, err)
+	}
+	if saved.Day == today {
+		return &saved, nil
+	}
+	return ledger, nil
+}
+
+// flushLedger writes the top-ups of the day that could not be written before. Those of a previous day no longer count
+// toward a cap and are dropped.
+func (w *Watcher) flushLedger() error {
+	if w.unsavedLedger == nil {
+		return nil
+	}
+	if w.unsavedLedger.Day == w.now().UTC().Format(time.DateOnly) {
+		if err := w.saveLedger(w.unsavedLedger); err != nil {
+			return err
+		}
+	}
+	w.unsavedLedger = nil
+	return nil
+}
+
+func (w *Watcher) saveLedger(ledger *topUpLedger) error {
+	bz, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the top-ups: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(w.ledgerPath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(w.ledgerPath), err)
+	}
+	if err = os.WriteFile(w.ledgerPath, bz, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", w.ledgerPath, err)
+	}
+	return nil
+}
+
+// sent returns what the gas station sent on chain in the day of the ledger
+func (l *topUpLedger) sent(chain string) *big.Int {
+	total := big.NewInt(0)
+	for _, topUp := range l.TopUps {
+		if strings.EqualFold(topUp.Chain, chain) {
+			if amount, ok := new(big.Int).SetString(topUp.Amount, 10); ok {
+				total.Add(total, amount)
+			}
+		}
+	}
+	return total
+}
+
+func (w *Watcher) queryBalance(key WatchedKey) (*big.Int, error) {
+	var balances *cosmosutils.Coins
+	var err error
+	if key.On.LCD != "" {
+		balances, err = cosmosutils.QueryBankBalances(key.On.LCD, key.Address)
+	} else {
+		if w.querier == nil {
+			if w.querier, err = cosmosutils.NewInitiadQuerier(key.On.l1Lcd); err != nil {
+				return nil, err
+			}
+		}
+		balances, err = w.querier.QueryBankBalances(key.Address, key.On.RPC)
+	}
+	if err != nil {
+		return nil, err
+	}
+	balance := big.NewInt(0)
+	for _, coin := range *balances {
+		if coin.Denom == key.On.Denom {
+			balance.SetString(coin.Amount, 10)
+		}
+	}
+	return balance, nil
+}
+
+func (w *Watcher) sendFromGasStation(key WatchedKey, amount *big.Int) (string, error) {
+	if config.IsFirstTimeSetup() {
+		return "", fmt.Errorf("the gas station is not set up, run `weave gas-station setup` first")
+	}
+	if key.On.celestiaOf != "" {
+		return w.sendOnCelestia(key, amount)
+	}
+	if w.executor == nil {
+		executor, err := cosmosutils.NewInitiadTxExecutor(key.On.l1Lcd)
+		if err != nil {
+			return "", err
+		}
+		w.executor = executor
+	}
+	txResponse, err := w.executor.BroadcastMsgSend(config.GetGasStationMnemonic(), key.Address, amount.String()+key.On.Denom, key.On.GasPrice, key.On.RPC, key.On.ChainId)
+	if err != nil {
+		return "", err
+	}
+	return txResponse.TxHash, nil
+}
+
+func (w *Watcher) sendOnCelestia(key WatchedKey, amount *big.Int) (string, error) {
+	if w.celestiaBinaryPath == "" {
+		binaryPath, err := minitia.InstallCelestiaBinary(key.On.celestiaOf)
+		if err != nil {
+			return "", fmt.Errorf("failed to install celestia-appd: %v", err)
+		}
+		w.celestiaBinaryPath = binaryPath
+	}
+	var txHash string
+	_, err := minitia.FundOnCelestia(w.celestiaBinaryPath, key.On.celestiaOf, key.Address, amount.String(), func(hash string) error {
+		txHash = hash
+		return nil
+	})
+	if err != nil && txHash == "" {
+		return "", err
+	}
+	if err != nil {
+		// the broadcast tx may still be included, it counts toward the daily cap
+		w.log(fmt.Sprintf("The top-up of %s was broadcast in tx %s but not seen included yet: %v", key, txHash, err))
+	}
+	return txHash, nil
+}
@@ -0,0 +1,159 @@
+package watch
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChainState struct {
+	balances map[string]*big.Int
+	sent     []string
+	sendErr  error
+}
+
+func newTestWatcher(t *testing.T, keys []WatchedKey, state *fakeChainState, now *time.Time) (*Watcher, *[]string) {
+	var logs []string
+	w := &Watcher{
+		Settings:   DefaultSettings(),
+		ledgerPath: filepath.Join(t.TempDir(), TopUpLedgerFilename),
+		log:        func(message string) { logs = append(logs, message) },
+		discover:   func() ([]WatchedKey, error) { return keys, nil },
+		balanceOf: func(key WatchedKey) (*big.Int, error) {
+			balance, ok := state.balances[key.Address]
+			if !ok {
+				return nil, errors.New("unreachable")
+			}
+			return balance, nil
+		},
+		send: func(key WatchedKey, amount *big.Int) (string, error) {
+			if state.sendErr != nil {
+				return "", state.sendErr
+			}
+			state.sent = append(state.sent, key.Address+":"+amount.String())
+			state.balances[key.Address] = new(big.Int).Add(state.balances[key.Address], amount)
+			return "TXHASH", nil
+		},
+		now: func() time.Time { return *now },
+	}
+	return w, &logs
+}
+
+func TestWatcherTopsUpLowKeysWithinDailyCap(t *testing.T) {
+	l1 := &WatchedChain{Name: ChainL1, ChainId: "initiation-2", Denom: "uinit", TopUp: true}
+	keys := []WatchedKey{
+		{Role: "output-submitter", Chain: ChainL1, Address: "init1output", On: l1},
+		{Role: "challenger", Chain: ChainL1, Address: "init1challenger", On: l1},
+		{Role: RelayerRole, Chain: ChainL1, Address: "init1relayer", On: l1},
+	}
+	state := &fakeChainState{balances: map[string]*big.Int{
+		"init1output":     big.NewInt(100),
+		"init1challenger": big.NewInt(5000000),
+		"init1relayer":    big.NewInt(0),
+	}}
+	now := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	w, logs := newTestWatcher(t, keys, state, &now)
+	w.Settings.DailyCaps["l1"] = "2500000"
+
+	assert.NoError(t, w.Check())
+	// the relayer top-up would exceed the daily cap of the L1
+	assert.Equal(t, []string{"init1output:2000000"}, state.sent)
+	assert.Contains(t, strings.Join(*logs, "\n"), "OK challenger on L1 (init1challenger): 5000000uinit")
+	assert.Contains(t, strings.Join(*logs, "\n"), "Topped up output-submitter on L1 (init1output) with 2000000uinit, tx hash TXHASH")
+	assert.Contains(t, strings.Join(*logs, "\n"), "already sent 2000000uinit of the daily cap of 2500000uinit on L1")
+
+	ledger, err := w.loadLedger()
+	assert.NoError(t, err)
+	assert.Len(t, ledger.TopUps, 1)
+	assert.Equal(t, "output-submitter/l1", ledger.TopUps[0].Key)
+
+	// the cap is reset the next UTC day
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []string{"init1output:2000000", "init1relayer:1000000"}, state.sent)
+}
+
+func TestWatcherReportsWithoutTopUp(t *testing.T) {
+	celestia := &WatchedChain{Name: "Celestia", Denom: "utia"}
+	l1 := &WatchedChain{Name: ChainL1, Denom: "uinit", TopUp: true}
+	keys := []WatchedKey{
+		{Role: "batch-submitter", Chain: ChainDA, Address: "celestia1batch", On: celestia},
+		{Role: "challenger", Chain: ChainL1, Address: "init1challenger", On: l1},
+		{Role: RelayerRole, Chain: ChainL1, Address: "init1relayer", On: l1},
+		{Role: "output-submitter", Chain: ChainL1, Address: "init1unreachable", On: l1},
+	}
+	state := &fakeChainState{balances: map[string]*big.Int{
+		"celestia1batch":  big.NewInt(0),
+		"init1challenger": big.NewInt(0),
+		"init1relayer":    big.NewInt(0),
+	}}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	w, logs := newTestWatcher(t, keys, state, &now)
+	w.DryRun = true
+	w.Settings.Thresholds["challenger/l1"] = Threshold{Min: "1000000", TopUp: "0"}
+
+	assert.NoError(t, w.Check())
+	assert.Empty(t, state.sent)
+	output := strings.Join(*logs, "\n")
+	assert.Contains(t, output, "the gas station cannot top up on Celestia, fund celestia1batch yourself")
+	assert.Contains(t, output, "top-ups of challenger/l1 are disabled")
+	assert.Contains(t, output, "would top up 1000000uinit from the gas station")
+	assert.Contains(t, output, "Failed to query the balance of output-submitter on L1 (init1unreachable): unreachable")
+}
+
+func TestWatcherDoesNotRecordFailedTopUps(t *testing.T) {
+	l1 := &WatchedChain{Name: ChainL1, Denom: "uinit", TopUp: true}
+	keys := []WatchedKey{{Role: RelayerRole, Chain: ChainL1, Address: "init1relayer", On: l1}}
+	state := &fakeChainState{balances: map[string]*big.Int{"init1relayer": big.NewInt(0)}, sendErr: errors.New("insufficient funds")}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	w, logs := newTestWatcher(t, keys, state, &now)
+
+	assert.NoError(t, w.Check())
+	assert.Contains(t, strings.Join(*logs, "\n"), "Failed to top up relayer on L1 (init1relayer): insufficient funds")
+	ledger, err := w.loadLedger()
+	assert.NoError(t, err)
+	assert.Empty(t, ledger.TopUps)
+}
+
+func TestWatcherStopsTopUpsUntilLedgerIsWritten(t *testing.T) {
+	celestia := &WatchedChain{Name: ChainCelestia, Denom: "utia", TopUp: true, celestiaOf: "initiation-2"}
+	l1 := &WatchedChain{Name: ChainL1, Denom: "uinit", TopUp: true}
+	keys := []WatchedKey{
+		{Role: "batch-submitter", Chain: ChainDA, Address: "celestia1batch", On: celestia},
+		{Role: RelayerRole, Chain: ChainL1, Address: "init1relayer", On: l1},
+	}
+	state := &fakeChainState{balances: map[string]*big.Int{"celestia1batch": big.NewInt(0), "init1relayer": big.NewInt(0)}}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	w, logs := newTestWatcher(t, keys, state, &now)
+	ledgerPath := w.ledgerPath
+	// the ledger cannot be written once the first top-up is sent, its parent is a file
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	assert.NoError(t, os.WriteFile(blocker, nil, 0644))
+	send := w.send
+	w.send = func(key WatchedKey, amount *big.Int) (string, error) {
+		w.ledgerPath = filepath.Join(blocker, TopUpLedgerFilename)
+		return send(key, amount)
+	}
+
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []string{"celestia1batch:1000000"}, state.sent)
+	output := strings.Join(*logs, "\n")
+	assert.Contains(t, output, "Failed to record the top-up, no more top-ups are sent until it is")
+	assert.Contains(t, output, "LOW relayer on L1 (init1relayer): 0uinit, threshold 500000uinit, not topping up until the top-ups of the day are recorded")
+
+	// once the ledger can be written, the pending top-up is recorded before the next one is sent
+	w.send = send
+	w.ledgerPath = ledgerPath
+	assert.NoError(t, w.Check())
+	assert.Equal(t, []string{"celestia1batch:1000000", "init1relayer:1000000"}, state.sent)
+	ledger, err := w.loadLedger()
+	assert.NoError(t, err)
+	assert.Len(t, ledger.TopUps, 2)
+	assert.Equal(t, "1000000", ledger.sent(ChainCelestia).String())
+}
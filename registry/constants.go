@@ -9,6 +9,7 @@ const (
 	CelestiaMainnet
 	InitiaL1Testnet
 	InitiaL1Mainnet
+	InitiaL1Local
 )
 
 const (
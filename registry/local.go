@@ -0,0 +1,284 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/initia-labs/weave/common"
+	"github.com/initia-labs/weave/config"
+)
+
+const (
+	LocalL1ConfigKey        string = "local_l1"
+	LocalL1PrettyName       string = "Initia (local)"
+	LocalL1Provider         string = "local"
+	LocalL1DefaultDenom     string = "uinit"
+	LocalL1Bech32Prefix     string = "init"
+	LocalL1DefaultRpc       string = "http://localhost:26657"
+	LocalL1DefaultLcd       string = "http://localhost:1317"
+	LocalL1DefaultGrpc      string = "localhost:9090"
+	LocalL1DefaultGasPrices string = "0.015uinit"
+
+	// LocalOPInitBotsSpecVersion is the OPinit bots config version used for a local L1, which is not listed in the
+	// remote spec_version
+	LocalOPInitBotsSpecVersion int = 1
+)
+
+var reGasPrice = regexp.MustCompile(`^([0-9]*\.?[0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// LocalL1Config holds the endpoints of an Initia L1 run on this machine, e.g. with `weave initia init`
+type LocalL1Config struct {
+	ChainId   string `json:"chain_id"`
+	Rpc       string `json:"rpc"`
+	Lcd       string `json:"lcd"`
+	Grpc      string `json:"grpc,omitempty"`
+	GasPrices string `json:"gas_prices"`
+}
+
+// Validate checks that the config has a chain id, well-formed endpoints and gas prices
+func (c LocalL1Config) Validate() error {
+	if c.ChainId == "" {
+		return fmt.Errorf("chain id is required")
+	}
+	for name, endpoint := range map[string]string{"RPC": c.Rpc, "LCD": c.Lcd} {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid %s endpoint %q, expected http(s)://host:port", name, endpoint)
+		}
+	}
+	if c.Grpc != "" {
+		if _, _, err := net.SplitHostPort(strings.TrimPrefix(c.Grpc, "grpc://")); err != nil {
+			return fmt.Errorf("invalid gRPC endpoint %q, expected host:port", c.Grpc)
+		}
+	}
+	if _, err := parseGasPrice(c.GasPrices); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseGasPrice(gasPrices string) (FeeTokens, error) {
+	var first *FeeTokens
+	for _, gasPrice := range strings.Split(gasPrices, ",") {
+		matches := reGasPrice.FindStringSubmatch(strings.TrimSpace(gasPrice))
+		if matches == nil {
+			return FeeTokens{}, fmt.Errorf("invalid gas prices %q, expected e.g. %s", gasPrices, LocalL1DefaultGasPrices)
+		}
+		price, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			return FeeTokens{}, fmt.Errorf("invalid gas price amount %q: %v", matches[1], err)
+		}
+		feeToken := FeeTokens{Denom: matches[2], FixedMinGasPrice: price}
+		if feeToken.Denom == LocalL1DefaultDenom {
+			return feeToken, nil
+		}
+		if first == nil {
+			first = &feeToken
+		}
+	}
+	return *first, nil
+}
+
+// NewLocalL1Registry builds a chain registry entry for a local L1 so it can be used wherever a registry-backed
+// network is expected
+func NewLocalL1Registry(cfg LocalL1Config) (*ChainRegistry, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	feeToken, _ := parseGasPrice(cfg.GasPrices)
+
+	chainRegistry := &ChainRegistry{
+		ChainId:      cfg.ChainId,
+		PrettyName:   LocalL1PrettyName,
+		Bech32Prefix: LocalL1Bech32Prefix,
+		Fees:         Fees{FeeTokens: []FeeTokens{feeToken}},
+		Apis: Apis{
+			Rpc:  []Endpoint{{Address: cfg.Rpc, Provider: LocalL1Provider}},
+			Rest: []Endpoint{{Address: cfg.Lcd, Provider: LocalL1Provider}},
+		},
+	}
+	if cfg.Grpc != "" {
+		chainRegistry.Apis.Grpc = []Endpoint{{Address: cfg.Grpc, Provider: LocalL1Provider}}
+	}
+	return chainRegistry, nil
+}
+
+// UseLocalL1 makes cfg the registry of InitiaL1Local for the current process
+func UseLocalL1(cfg LocalL1Config) error {
+	chainRegistry, err := NewLocalL1Registry(cfg)
+	if err != nil {
+		return err
+	}
+	LoadedChainRegistry[InitiaL1Local] = chainRegistry
+	return nil
+}
+
+// SaveLocalL1 uses cfg as the local L1 and stores it in the weave config, so that later commands such as
+// `weave opinit init` and `weave relayer init` can find it
+func SaveLocalL1(cfg LocalL1Config) error {
+	if err := UseLocalL1(cfg); err != nil {
+		return err
+	}
+	return config.SetConfig(LocalL1ConfigKey, map[string]interface{}{
+		"chain_id":   cfg.ChainId,
+		"rpc":        cfg.Rpc,
+		"lcd":        cfg.Lcd,
+		"grpc":       cfg.Grpc,
+		"gas_prices": cfg.GasPrices,
+	})
+}
+
+// GetSavedLocalL1Config returns the local L1 stored in the weave config, if any
+func GetSavedLocalL1Config() (LocalL1Config, bool) {
+	var cfg LocalL1Config
+	saved := config.GetConfig(LocalL1ConfigKey)
+	if saved == nil {
+		return cfg, false
+	}
+	bz, err := json.Marshal(saved)
+	if err != nil {
+		return cfg, false
+	}
+	if err = json.Unmarshal(bz, &cfg); err != nil || cfg.ChainId == "" {
+		return cfg, false
+	}
+	return cfg, true
+}
+
+func loadLocalL1Registry() error {
+	cfg, ok := GetSavedLocalL1Config()
+	if !ok {
+		return fmt.Errorf("no local L1 is configured, select the local L1 in `weave rollup launch` first")
+	}
+	return UseLocalL1(cfg)
+}
+
+// IsLocalL1 reports whether chainId belongs to the local L1 in use or stored in the weave config
+func IsLocalL1(chainId string) bool {
+	if chainRegistry, ok := LoadedChainRegistry[InitiaL1Local]; ok {
+		return chainRegistry.GetChainId() == chainId
+	}
+	cfg, ok := GetSavedLocalL1Config()
+	return ok && cfg.ChainId == chainId
+}
+
+// GetL1ChainType returns the chain type of the Initia L1 with the given chain id. The local L1 is checked first, so
+// no remote registry is fetched for it.
+func GetL1ChainType(chainId string) (ChainType, error) {
+	if IsLocalL1(chainId) {
+		return InitiaL1Local, nil
+	}
+	testnetRegistry, err := GetChainRegistry(InitiaL1Testnet)
+	if err != nil {
+		return 0, err
+	}
+	if testnetRegistry.GetChainId() == chainId {
+		return InitiaL1Testnet, nil
+	}
+	return InitiaL1Mainnet, nil
+}
+
+// GetL1ChainRegistry returns the registry of the Initia L1 with the given chain id, checking the local L1 before the
+// remote testnet and mainnet registries
+func GetL1ChainRegistry(chainId string) (*ChainRegistry, error) {
+	for _, chainType := range []ChainType{InitiaL1Local, InitiaL1Testnet, InitiaL1Mainnet} {
+		if chainType == InitiaL1Local && !IsLocalL1(chainId) {
+			continue
+		}
+		chainRegistry, err := GetChainRegistry(chainType)
+		if err == nil && chainRegistry.GetChainId() == chainId {
+			return chainRegistry, nil
+		}
+	}
+	return nil, fmt.Errorf("chain %s not found in the registry", chainId)
+}
+
+// GetCelestiaChainType returns the Celestia network used as DA layer together with the given Initia L1. A local L1
+// pairs with the Celestia testnet.
+func GetCelestiaChainType(l1ChainId string) (ChainType, error) {
+	l1ChainType, err := GetL1ChainType(l1ChainId)
+	if err != nil {
+		return 0, err
+	}
+	if l1ChainType == InitiaL1Mainnet {
+		return CelestiaMainnet, nil
+	}
+	return CelestiaTestnet, nil
+}
+
+// GetCelestiaChainRegistry returns the registry of the Celestia network paired with the given Initia L1
+func GetCelestiaChainRegistry(l1ChainId string) (*ChainRegistry, error) {
+	chainType, err := GetCelestiaChainType(l1ChainId)
+	if err != nil {
+		return nil, err
+	}
+	return GetChainRegistry(chainType)
+}
+
+// ReadLocalL1Config reads the chain id, endpoints and minimum gas prices of the node in initiaHome from its
+// client.toml, config.toml, app.toml and genesis.json. Values that are not set fall back to the node defaults.
+func ReadLocalL1Config(initiaHome string) (LocalL1Config, error) {
+	configDir := filepath.Join(initiaHome, "config")
+	if _, err := os.Stat(configDir); err != nil {
+		return LocalL1Config{}, fmt.Errorf("no initia node config found in %s: %v", initiaHome, err)
+	}
+
+	var clientToml struct {
+		ChainId string `toml:"chain-id"`
+	}
+	var configToml struct {
+		Rpc struct {
+			Laddr string `toml:"laddr"`
+		} `toml:"rpc"`
+	}
+	var appToml struct {
+		MinimumGasPrices string `toml:"minimum-gas-prices"`
+		Api              struct {
+			Address string `toml:"address"`
+		} `toml:"api"`
+		Grpc struct {
+			Address string `toml:"address"`
+		} `toml:"grpc"`
+	}
+	for file, target := range map[string]interface{}{"client.toml": &clientToml, "config.toml": &configToml, "app.toml": &appToml} {
+		if _, err := toml.DecodeFile(filepath.Join(configDir, file), target); err != nil && !os.IsNotExist(err) {
+			return LocalL1Config{}, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+	}
+
+	chainId := clientToml.ChainId
+	if chainId == "" {
+		var genesis struct {
+			ChainId string `json:"chain_id"`
+		}
+		if bz, err := os.ReadFile(filepath.Join(configDir, "genesis.json")); err == nil {
+			_ = json.Unmarshal(bz, &genesis)
+		}
+		chainId = genesis.ChainId
+	}
+	if chainId == "" {
+		return LocalL1Config{}, fmt.Errorf("cannot find the chain id of the node in %s", initiaHome)
+	}
+
+	gasPrices := LocalL1DefaultGasPrices
+	if feeToken, err := parseGasPrice(appToml.MinimumGasPrices); err == nil && feeToken.FixedMinGasPrice > 0 {
+		gasPrices = appToml.MinimumGasPrices
+	}
+
+	return LocalL1Config{
+		ChainId:   chainId,
+		Rpc:       common.ListenAddressToEndpoint(configToml.Rpc.Laddr, "http://", LocalL1DefaultRpc),
+		Lcd:       common.ListenAddressToEndpoint(appToml.Api.Address, "http://", LocalL1DefaultLcd),
+		Grpc:      common.ListenAddressToEndpoint(appToml.Grpc.Address, "", LocalL1DefaultGrpc),
+		GasPrices: gasPrices,
+	}, nil
+}
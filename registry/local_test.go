@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeNodeConfig(t *testing.T, home, file, content string) {
+	configDir := filepath.Join(home, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, file), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", file, err)
+	}
+}
+
+func TestReadLocalL1Config(t *testing.T) {
+	home := t.TempDir()
+	writeNodeConfig(t, home, "client.toml", `chain-id = "local-1"`)
+	writeNodeConfig(t, home, "config.toml", "[rpc]\nladdr = \"tcp://0.0.0.0:36657\"\n")
+	writeNodeConfig(t, home, "app.toml", "minimum-gas-prices = \"0.1uinit\"\n[api]\naddress = \"tcp://127.0.0.1:1318\"\n[grpc]\naddress = \"0.0.0.0:9091\"\n")
+
+	cfg, err := ReadLocalL1Config(home)
+	if err != nil {
+		t.Fatalf("ReadLocalL1Config() error = %v", err)
+	}
+	expected := LocalL1Config{
+		ChainId:   "local-1",
+		Rpc:       "http://localhost:36657",
+		Lcd:       "http://127.0.0.1:1318",
+		Grpc:      "localhost:9091",
+		GasPrices: "0.1uinit",
+	}
+	if cfg != expected {
+		t.Errorf("ReadLocalL1Config() = %+v, want %+v", cfg, expected)
+	}
+}
+
+func TestReadLocalL1ConfigDefaults(t *testing.T) {
+	home := t.TempDir()
+	writeNodeConfig(t, home, "genesis.json", `{"chain_id": "genesis-1"}`)
+
+	cfg, err := ReadLocalL1Config(home)
+	if err != nil {
+		t.Fatalf("ReadLocalL1Config() error = %v", err)
+	}
+	expected := LocalL1Config{
+		ChainId:   "genesis-1",
+		Rpc:       LocalL1DefaultRpc,
+		Lcd:       LocalL1DefaultLcd,
+		Grpc:      LocalL1DefaultGrpc,
+		GasPrices: LocalL1DefaultGasPrices,
+	}
+	if cfg != expected {
+		t.Errorf("ReadLocalL1Config() = %+v, want %+v", cfg, expected)
+	}
+
+	if _, err = ReadLocalL1Config(filepath.Join(home, "missing")); err == nil {
+		t.Error("expected an error for a home without a node config")
+	}
+}
+
+func TestLocalL1ConfigValidate(t *testing.T) {
+	valid := LocalL1Config{ChainId: "local-1", Rpc: LocalL1DefaultRpc, Lcd: LocalL1DefaultLcd, GasPrices: LocalL1DefaultGasPrices}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	invalid := map[string]LocalL1Config{
+		"missing chain id": {Rpc: LocalL1DefaultRpc, Lcd: LocalL1DefaultLcd, GasPrices: LocalL1DefaultGasPrices},
+		"invalid rpc":      {ChainId: "local-1", Rpc: "localhost:26657", Lcd: LocalL1DefaultLcd, GasPrices: LocalL1DefaultGasPrices},
+		"invalid grpc":     {ChainId: "local-1", Rpc: LocalL1DefaultRpc, Lcd: LocalL1DefaultLcd, Grpc: "localhost", GasPrices: LocalL1DefaultGasPrices},
+		"invalid gas":      {ChainId: "local-1", Rpc: LocalL1DefaultRpc, Lcd: LocalL1DefaultLcd, GasPrices: "uinit"},
+	}
+	for name, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected Validate() to fail for %s", name)
+		}
+	}
+}
+
+func TestUseLocalL1(t *testing.T) {
+	t.Cleanup(func() { delete(LoadedChainRegistry, InitiaL1Local) })
+
+	cfg := LocalL1Config{
+		ChainId:   "local-1",
+		Rpc:       LocalL1DefaultRpc,
+		Lcd:       LocalL1DefaultLcd,
+		Grpc:      LocalL1DefaultGrpc,
+		GasPrices: "0.5uusdc,0.015uinit",
+	}
+	if err := UseLocalL1(cfg); err != nil {
+		t.Fatalf("UseLocalL1() error = %v", err)
+	}
+
+	if !IsLocalL1("local-1") || IsLocalL1("initiation-2") {
+		t.Error("expected only local-1 to be the local L1")
+	}
+	chainType, err := GetL1ChainType("local-1")
+	if err != nil || chainType != InitiaL1Local {
+		t.Errorf("GetL1ChainType() = %v, %v, want %v", chainType, err, InitiaL1Local)
+	}
+
+	chainRegistry, err := GetL1ChainRegistry("local-1")
+	if err != nil {
+		t.Fatalf("GetL1ChainRegistry() error = %v", err)
+	}
+	if rpc := chainRegistry.Apis.Rpc[0].Address; rpc != LocalL1DefaultRpc {
+		t.Errorf("RPC endpoint = %s, want %s", rpc, LocalL1DefaultRpc)
+	}
+	if gasPrice, _ := chainRegistry.GetFixedMinGasPriceByDenom(LocalL1DefaultDenom); gasPrice != "0.015" {
+		t.Errorf("GetFixedMinGasPriceByDenom() = %s, want 0.015", gasPrice)
+	}
+}
@@ -30,6 +30,7 @@ type Fees struct {
 type FeeTokens struct {
 	Denom            string  `json:"denom"`
 	FixedMinGasPrice float64 `json:"fixed_min_gas_price"`
+	AverageGasPrice  float64 `json:"average_gas_price"`
 }
 
 type Codebase struct {
@@ -108,6 +109,21 @@ func (cr *ChainRegistry) GetFixedMinGasPriceByDenom(denom string) (string, error
 	return "", fmt.Errorf("denomination %s not found in fee tokens", denom)
 }
 
+// GetGasPriceByDenom returns the average gas price of the fee token, falling back to its fixed minimum gas price
+// when the registry does not list an average one
+func (cr *ChainRegistry) GetGasPriceByDenom(denom string) (string, error) {
+	for _, feeToken := range cr.Fees.FeeTokens {
+		if feeToken.Denom == denom {
+			gasPrice := feeToken.AverageGasPrice
+			if gasPrice == 0 {
+				gasPrice = feeToken.FixedMinGasPrice
+			}
+			return fmt.Sprintf("%s%s", strconv.FormatFloat(gasPrice, 'f', -1, 64), denom), nil
+		}
+	}
+	return "", fmt.Errorf("denomination %s not found in fee tokens", denom)
+}
+
 func checkAndAddPort(addr string) (string, error) {
 	u, err := url.Parse(addr)
 	if err != nil {
@@ -294,6 +310,10 @@ func (cr *ChainRegistry) GetDefaultMinGasPrices() (string, error) {
 }
 
 func loadChainRegistry(chainType ChainType) error {
+	if chainType == InitiaL1Local {
+		return loadLocalL1Registry()
+	}
+
 	httpClient := client.NewHTTPClient()
 	endpoint := GetRegistryEndpoint(chainType)
 	LoadedChainRegistry[chainType] = &ChainRegistry{}
@@ -404,6 +424,9 @@ func loadOPInitBotsSpecVersion() error {
 }
 
 func GetOPInitBotsSpecVersion(chainId string) (int, error) {
+	if IsLocalL1(chainId) {
+		return LocalOPInitBotsSpecVersion, nil
+	}
 	if OPInitBotsSpecVersion == nil {
 		if err := loadOPInitBotsSpecVersion(); err != nil {
 			return 0, err
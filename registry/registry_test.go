@@ -133,6 +133,40 @@ func TestGetMinGasPriceByDenom(t *testing.T) {
 	}
 }
 
+// Test GetGasPriceByDenom
+func TestGetGasPriceByDenom(t *testing.T) {
+	cr := ChainRegistry{
+		Fees: Fees{
+			FeeTokens: []FeeTokens{
+				{Denom: "utia", FixedMinGasPrice: 0.002, AverageGasPrice: 0.02},
+				{Denom: "uinit", FixedMinGasPrice: 0.015},
+			},
+		},
+	}
+
+	tests := []struct {
+		denom     string
+		expected  string
+		expectErr bool
+	}{
+		{"utia", "0.02utia", false},
+		{"uinit", "0.015uinit", false},
+		{"btc", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.denom, func(t *testing.T) {
+			result, err := cr.GetGasPriceByDenom(tt.denom)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("expected error: %v, got: %v", tt.expectErr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected: %s, got: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
 // Test GetActiveRpc
 func TestGetActiveRpc(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -12,6 +12,8 @@ func (ct ChainType) String() string {
 		return "Initia L1 Testnet"
 	case InitiaL1Mainnet:
 		return "Initia L1 Mainnet"
+	case InitiaL1Local:
+		return "Initia L1 Local"
 	default:
 		return "Unknown"
 	}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/initia-labs/weave/common"
+)
+
+const SystemdUnitDirectory = "/etc/systemd/system"
+
+// WeaveUnitMarker is the first line of the systemd units weave writes
+const WeaveUnitMarker = "# Managed by weave"
+
+// ExistingUnit is a systemd unit that was installed outside of weave and runs a node binary
+type ExistingUnit struct {
+	Name     string
+	Path     string
+	ExecPath string
+	Active   bool
+}
+
+// FindExistingSystemdUnits returns the systemd units whose ExecStart runs one of the given binaries against appHome.
+// A unit without an explicit home is assumed to use the binary's default home, which is matched with defaultHomeDir.
+func FindExistingSystemdUnits(binaries []string, appHome, defaultHomeDir string) ([]ExistingUnit, error) {
+	if runtime.GOOS != "linux" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(SystemdUnitDirectory, "*.service"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list systemd units: %v", err)
+	}
+
+	var units []ExistingUnit
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil || isWeaveUnit(string(content)) {
+			continue
+		}
+
+		execPath, home, ok := parseUnit(string(content), binaries)
+		if !ok {
+			continue
+		}
+		if home == "" && filepath.Base(appHome) != defaultHomeDir {
+			continue
+		}
+		if home != "" && filepath.Clean(home) != filepath.Clean(appHome) {
+			continue
+		}
+
+		name := filepath.Base(path)
+		units = append(units, ExistingUnit{
+			Name:     name,
+			Path:     path,
+			ExecPath: execPath,
+			Active:   exec.Command("systemctl", "is-active", "--quiet", name).Run() == nil,
+		})
+	}
+
+	return units, nil
+}
+
+// parseUnit extracts the executable and the home directory from a unit file when it runs one of the binaries
+func parseUnit(content string, binaries []string) (execPath, home string, ok bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "ExecStart="):
+			fields := strings.Fields(strings.TrimPrefix(line, "ExecStart="))
+			if len(fields) == 0 {
+				continue
+			}
+			for _, binary := range binaries {
+				if filepath.Base(fields[0]) == binary {
+					execPath = fields[0]
+					ok = true
+				}
+			}
+			for idx, field := range fields {
+				if field == "--home" && idx+1 < len(fields) {
+					home = fields[idx+1]
+				} else if strings.HasPrefix(field, "--home=") {
+					home = strings.TrimPrefix(field, "--home=")
+				}
+			}
+		case strings.HasPrefix(line, "Environment="):
+			for _, env := range splitEnvironment(strings.TrimPrefix(line, "Environment=")) {
+				if strings.HasPrefix(env, "DAEMON_HOME=") && home == "" {
+					home = strings.TrimPrefix(env, "DAEMON_HOME=")
+				}
+			}
+		}
+	}
+	return execPath, home, ok
+}
+
+// splitEnvironment splits the assignments of an Environment= line, which are separated by spaces and may be quoted
+func splitEnvironment(value string) []string {
+	var assignments []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range value {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case (r == ' ' || r == '\t') && !quoted:
+			if current.Len() > 0 {
+				assignments = append(assignments, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		assignments = append(assignments, current.String())
+	}
+	return assignments
+}
+
+// isWeaveUnit tells whether the unit with content was written by weave, which is never adopted. The units written
+// before the marker was added are told apart by their ExecStart, which runs a binary of the weave data directory.
+// A hand-made unit may have the name of a weave unit, so the name is not looked at.
+func isWeaveUnit(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == WeaveUnitMarker {
+			return true
+		}
+		if execStart, ok := strings.CutPrefix(line, "ExecStart="); ok {
+			fields := strings.Fields(execStart)
+			if len(fields) > 0 && strings.Contains(fields[0], "/"+common.WeaveDataDirectory+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TakeOverExistingUnits replaces the existing units with the weave-managed service of commandName. A unit at the path
+// of the service is migrated first so that it is backed up before the service is written over it. The service is then
+// created with create, the other units are migrated and the service is started when one of them was running. When a
+// step fails, a service that did not exist before is removed and the migrated units are restored.
+func TakeOverExistingUnits(commandName CommandName, units []ExistingUnit, create func(Service) error) error {
+	srv, err := NewService(commandName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize service: %v", err)
+	}
+	serviceFilePath, err := GetServiceFilePath(commandName)
+	if err != nil {
+		return err
+	}
+	sameNamed, others := splitUnitsAt(units, serviceFilePath)
+	_, statErr := os.Stat(serviceFilePath)
+	existed := statErr == nil && len(sameNamed) == 0
+
+	var migrated []ExistingUnit
+	var backupPaths []string
+	created := false
+	rollback := func(cause error) error {
+		if created && !existed {
+			if err := removeUnit(serviceFilePath); err != nil {
+				return fmt.Errorf("%v, and failed to remove %s: %v", cause, serviceFilePath, err)
+			}
+		}
+		for idx := len(migrated) - 1; idx >= 0; idx-- {
+			if err := restoreExistingUnit(migrated[idx], backupPaths[idx]); err != nil {
+				return fmt.Errorf("%v, and failed to restore unit %s from %s: %v", cause, migrated[idx].Name, backupPaths[idx], err)
+			}
+			fmt.Printf("Restored unit %s\n", migrated[idx].Name)
+		}
+		return cause
+	}
+
+	wasRunning := false
+	migrate := func(unit ExistingUnit) error {
+		backupPath, err := MigrateExistingUnit(unit)
+		if backupPath != "" {
+			migrated = append(migrated, unit)
+			backupPaths = append(backupPaths, backupPath)
+		}
+		if err != nil {
+			return err
+		}
+		wasRunning = wasRunning || unit.Active
+		fmt.Printf("Migrated existing unit %s, a copy is kept at %s\n", unit.Name, backupPath)
+		return nil
+	}
+
+	for _, unit := range sameNamed {
+		if err = migrate(unit); err != nil {
+			return rollback(err)
+		}
+	}
+	// a failed create may have written the unit already
+	created = true
+	if err = create(srv); err != nil {
+		return rollback(fmt.Errorf("failed to create service: %v", err))
+	}
+	for _, unit := range others {
+		if err = migrate(unit); err != nil {
+			return rollback(err)
+		}
+	}
+
+	if wasRunning {
+		if err = srv.Start(); err != nil {
+			_ = srv.Stop()
+			return rollback(fmt.Errorf("failed to start service: %v", err))
+		}
+	}
+	return nil
+}
+
+// splitUnitsAt returns the units at path, which the service written there would replace, and the other units
+func splitUnitsAt(units []ExistingUnit, path string) (at, others []ExistingUnit) {
+	for _, unit := range units {
+		if filepath.Clean(unit.Path) == filepath.Clean(path) {
+			at = append(at, unit)
+		} else {
+			others = append(others, unit)
+		}
+	}
+	return at, others
+}
+
+// restoreExistingUnit puts back a unit migrated by MigrateExistingUnit, and starts it again if it was running
+func restoreExistingUnit(unit ExistingUnit, backupPath string) error {
+	if err := exec.Command("sudo", "cp", backupPath, unit.Path).Run(); err != nil {
+		return fmt.Errorf("failed to copy the unit back: %v", err)
+	}
+	if err := exec.Command("sudo", "systemctl", "daemon-reload").Run(); err != nil {
+		return fmt.Errorf("failed to reload systemd daemon: %v", err)
+	}
+	if err := exec.Command("sudo", "systemctl", "enable", unit.Name).Run(); err != nil {
+		return fmt.Errorf("failed to enable unit: %v", err)
+	}
+	if unit.Active {
+		if err := exec.Command("sudo", "systemctl", "start", unit.Name).Run(); err != nil {
+			return fmt.Errorf("failed to start unit: %v", err)
+		}
+	}
+	return nil
+}
+
+func removeUnit(path string) error {
+	_ = exec.Command("sudo", "systemctl", "disable", filepath.Base(path)).Run()
+	if err := exec.Command("sudo", "rm", "-f", path).Run(); err != nil {
+		return err
+	}
+	return exec.Command("sudo", "systemctl", "daemon-reload").Run()
+}
+
+// MigrateExistingUnit stops and disables an existing unit, keeps a copy of it under the weave data directory
+// and removes it so that the weave-managed unit can take over. The path of the copy is returned as soon as it is
+// written, also when a later step fails.
+func MigrateExistingUnit(unit ExistingUnit) (string, error) {
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %v", err)
+	}
+
+	backupDir := filepath.Join(userHome, common.WeaveDataDirectory, "systemd-backup")
+	if err = os.MkdirAll(backupDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	content, err := os.ReadFile(unit.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read unit %s: %v", unit.Name, err)
+	}
+	backupPath := filepath.Join(backupDir, unit.Name)
+	if err = os.WriteFile(backupPath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to back up unit %s: %v", unit.Name, err)
+	}
+
+	if unit.Active {
+		if err = exec.Command("sudo", "systemctl", "stop", unit.Name).Run(); err != nil {
+			return backupPath, fmt.Errorf("failed to stop unit %s: %v", unit.Name, err)
+		}
+	}
+	if err = exec.Command("sudo", "systemctl", "disable", unit.Name).Run(); err != nil {
+		return backupPath, fmt.Errorf("failed to disable unit %s: %v", unit.Name, err)
+	}
+	if err = exec.Command("sudo", "rm", "-f", unit.Path).Run(); err != nil {
+		return backupPath, fmt.Errorf("failed to remove unit %s: %v", unit.Name, err)
+	}
+	if err = exec.Command("sudo", "systemctl", "daemon-reload").Run(); err != nil {
+		return backupPath, fmt.Errorf("failed to reload systemd daemon: %v", err)
+	}
+
+	return backupPath, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectedExecPath string
+		expectedHome     string
+		expectedOk       bool
+	}{
+		{
+			name:             "HomeFlag",
+			content:          "[Service]\nExecStart=/usr/local/bin/initiad start --home /data/initia\n",
+			expectedExecPath: "/usr/local/bin/initiad",
+			expectedHome:     "/data/initia",
+			expectedOk:       true,
+		},
+		{
+			name:             "HomeFlagWithEquals",
+			content:          "[Service]\nExecStart=/usr/local/bin/minitiad start --home=/data/minitia\n",
+			expectedExecPath: "/usr/local/bin/minitiad",
+			expectedHome:     "/data/minitia",
+			expectedOk:       true,
+		},
+		{
+			name:             "DefaultHome",
+			content:          "[Service]\nExecStart=/usr/local/bin/initiad start\n",
+			expectedExecPath: "/usr/local/bin/initiad",
+			expectedOk:       true,
+		},
+		{
+			name:             "CosmovisorDaemonHome",
+			content:          "[Service]\nEnvironment=\"DAEMON_NAME=initiad\"\nEnvironment=\"DAEMON_HOME=/data/initia\"\nExecStart=/usr/bin/cosmovisor run start\n",
+			expectedExecPath: "/usr/bin/cosmovisor",
+			expectedHome:     "/data/initia",
+			expectedOk:       true,
+		},
+		{
+			name:             "SeveralVariablesOnOneLine",
+			content:          "[Service]\nEnvironment=DAEMON_NAME=initiad DAEMON_HOME=/data/initia DAEMON_RESTART_AFTER_UPGRADE=true\nExecStart=/usr/bin/cosmovisor run start\n",
+			expectedExecPath: "/usr/bin/cosmovisor",
+			expectedHome:     "/data/initia",
+			expectedOk:       true,
+		},
+		{
+			name:             "SeveralQuotedVariablesOnOneLine",
+			content:          "[Service]\nEnvironment=\"DAEMON_NAME=initiad\" \"DAEMON_HOME=/data/initia node\"\nExecStart=/usr/bin/cosmovisor run start\n",
+			expectedExecPath: "/usr/bin/cosmovisor",
+			expectedHome:     "/data/initia node",
+			expectedOk:       true,
+		},
+		{
+			name:       "OtherBinary",
+			content:    "[Service]\nExecStart=/usr/bin/nginx -g 'daemon off;'\n",
+			expectedOk: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			execPath, home, ok := parseUnit(tc.content, []string{"initiad", "minitiad", "cosmovisor"})
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedExecPath, execPath)
+			assert.Equal(t, tc.expectedHome, home)
+		})
+	}
+}
+
+func TestIsWeaveUnit(t *testing.T) {
+	for commandName, template := range LinuxTemplateMap {
+		assert.True(t, isWeaveUnit(string(template)), "the %s template has the weave marker", commandName)
+	}
+	// written by weave before the marker was added
+	assert.True(t, isWeaveUnit("[Service]\nExecStart=/home/ubuntu/.weave/data/minimove@v1.0.0/minitiad start --home /home/ubuntu/.minitia\n"))
+	// hand-made units with the names of weave units
+	assert.False(t, isWeaveUnit("[Service]\nExecStart=/usr/local/bin/minitiad start --home /data/minitia\n"))
+	assert.False(t, isWeaveUnit("[Service]\nEnvironment=\"DAEMON_HOME=/data/initia\"\nExecStart=/usr/bin/cosmovisor run start\n"))
+}
+
+func TestSplitUnitsAt(t *testing.T) {
+	units := []ExistingUnit{
+		{Name: "cosmovisor.service", Path: "/etc/systemd/system/cosmovisor.service"},
+		{Name: "initiad.service", Path: "/etc/systemd/system/initiad.service"},
+	}
+	at, others := splitUnitsAt(units, "/etc/systemd/system/cosmovisor.service")
+	assert.Equal(t, units[:1], at)
+	assert.Equal(t, units[1:], others)
+}
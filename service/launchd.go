@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -16,6 +17,9 @@ import (
 	weaveio "github.com/initia-labs/weave/io"
 )
 
+// reLaunchdPID matches the PID `launchctl list <label>` prints for an agent with a running process
+var reLaunchdPID = regexp.MustCompile(`"PID" = \d+;`)
+
 type Launchd struct {
 	commandName CommandName
 }
@@ -121,6 +125,20 @@ func (j *Launchd) Stop() error {
 	return cmd.Run()
 }
 
+// IsActive reports whether the agent has a running process
+func (j *Launchd) IsActive() (bool, error) {
+	serviceName, err := j.GetServiceName()
+	if err != nil {
+		return false, fmt.Errorf("failed to get service name: %v", err)
+	}
+	// list fails for an agent that is not loaded, which cannot be running
+	output, err := exec.Command("launchctl", "list", serviceName).Output()
+	if err != nil {
+		return false, nil
+	}
+	return reLaunchdPID.Match(output), nil
+}
+
 func (j *Launchd) Restart() error {
 	err := j.Stop()
 	if err != nil {
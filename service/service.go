@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"syscall"
 	"time"
@@ -29,6 +30,39 @@ func NewService(commandName CommandName) (Service, error) {
 	}
 }
 
+// IsActive reports whether the service of commandName is running
+func IsActive(commandName CommandName) (bool, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return NewSystemd(commandName).IsActive()
+	case "darwin":
+		return NewLaunchd(commandName).IsActive()
+	default:
+		return false, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+	}
+}
+
+// GetServiceFilePath returns where the unit (Linux) or plist (macOS) of the service is written
+func GetServiceFilePath(commandName CommandName) (string, error) {
+	slug, err := commandName.GetServiceSlug()
+	if err != nil {
+		return "", err
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(SystemdUnitDirectory, slug+".service"), nil
+	case "darwin":
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %v", err)
+		}
+		return filepath.Join(userHome, "Library/LaunchAgents", fmt.Sprintf("com.%s.daemon.plist", slug)), nil
+	default:
+		return "", fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+	}
+}
+
 func NonDetachStart(s Service) error {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
@@ -135,6 +135,30 @@ func (j *Systemd) Stop() error {
 	return cmd.Run()
 }
 
+// IsActive reports whether the unit is running, or is being started or stopped
+func (j *Systemd) IsActive() (bool, error) {
+	serviceName, err := j.GetServiceName()
+	if err != nil {
+		return false, err
+	}
+	// is-active exits with a non-zero code for every state but active, the state is read from its output instead
+	output, err := exec.Command("systemctl", "is-active", serviceName).Output()
+	state := strings.TrimSpace(string(output))
+	if state == "" {
+		return false, fmt.Errorf("failed to get the state of %s: %v", serviceName, err)
+	}
+	return isActiveUnitState(state), nil
+}
+
+func isActiveUnitState(state string) bool {
+	switch state {
+	case "active", "activating", "deactivating", "reloading", "refreshing":
+		return true
+	default:
+		return false
+	}
+}
+
 func (j *Systemd) Restart() error {
 	serviceName, err := j.GetServiceName()
 	if err != nil {
@@ -239,8 +239,47 @@ const DarwinRelayerTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
 </plist>
 `
 
+// DarwinWatchBalancesTemplate should inject the arguments as follows: [binaryName, binaryPath, appHome, userHome, weaveLogPath, serviceName]
+const DarwinWatchBalancesTemplate Template = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Label</key>
+    <string>com.%[1]s.watch.daemon</string>
+
+    <key>ProgramArguments</key>
+    <array>
+        <string>%[2]s/%[1]s</string>
+        <string>watch</string>
+        <string>balances</string>
+        <string>--opinit-dir=%[3]s</string>
+    </array>
+
+    <key>RunAtLoad</key>
+    <false/>
+
+    <key>KeepAlive</key>
+    <false/>
+
+    <!-- Adding the environment variable -->
+    <key>EnvironmentVariables</key>
+    <dict>
+		<key>HOME</key>
+        <string>%[4]s</string>
+    </dict>
+
+    <key>StandardOutPath</key>
+    <string>%[5]s/%[1]s.watch.stdout.log</string>
+
+    <key>StandardErrorPath</key>
+    <string>%[5]s/%[1]s.watch.stderr.log</string>
+</dict>
+</plist>
+`
+
 // LinuxRunUpgradableCosmovisorTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
 const LinuxRunUpgradableCosmovisorTemplate Template = `
+# Managed by weave
 [Unit]
 Description=%[1]s
 After=network.target
@@ -263,6 +302,7 @@ WantedBy=multi-user.target
 
 // LinuxRunNonUpgradableCosmovisorTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
 const LinuxRunNonUpgradableCosmovisorTemplate Template = `
+# Managed by weave
 [Unit]
 Description=%[1]s
 After=network.target
@@ -285,6 +325,7 @@ WantedBy=multi-user.target
 
 // LinuxRunBinaryTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
 const LinuxRunBinaryTemplate Template = `
+# Managed by weave
 [Unit]
 Description=%[1]s
 After=network.target
@@ -303,6 +344,7 @@ WantedBy=multi-user.target
 
 // LinuxOPinitBotTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
 const LinuxOPinitBotTemplate Template = `
+# Managed by weave
 [Unit]
 Description=%[1]s %[4]s
 After=network.target
@@ -321,6 +363,7 @@ WantedBy=multi-user.target
 
 // LinuxRelayerTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
 const LinuxRelayerTemplate Template = `
+# Managed by weave
 [Unit]
 Description=%[1]s
 After=network.target
@@ -336,6 +379,23 @@ LimitNOFILE=65535
 WantedBy=multi-user.target
 `
 
+// LinuxWatchBalancesTemplate should inject the arguments as follows: [binaryName, currentUser.Username, binaryPath, serviceName, appHome]
+const LinuxWatchBalancesTemplate Template = `
+# Managed by weave
+[Unit]
+Description=%[1]s watch balances
+After=network.target
+
+[Service]
+Type=exec
+User=%[2]s
+ExecStart=%[3]s/%[1]s watch balances --opinit-dir %[5]s
+KillSignal=SIGINT
+
+[Install]
+WantedBy=multi-user.target
+`
+
 var (
 	LinuxTemplateMap = map[CommandName]Template{
 		UpgradableInitia:    LinuxRunUpgradableCosmovisorTemplate,
@@ -344,6 +404,7 @@ var (
 		OPinitExecutor:      LinuxOPinitBotTemplate,
 		OPinitChallenger:    LinuxOPinitBotTemplate,
 		Relayer:             LinuxRelayerTemplate,
+		WatchBalances:       LinuxWatchBalancesTemplate,
 	}
 	DarwinTemplateMap = map[CommandName]Template{
 		UpgradableInitia:    DarwinRunUpgradableCosmovisorTemplate,
@@ -352,5 +413,6 @@ var (
 		OPinitExecutor:      DarwinOPinitBotTemplate,
 		OPinitChallenger:    DarwinOPinitBotTemplate,
 		Relayer:             DarwinRelayerTemplate,
+		WatchBalances:       DarwinWatchBalancesTemplate,
 	}
 )
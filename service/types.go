@@ -11,8 +11,12 @@ const (
 	OPinitExecutor      CommandName = "executor"
 	OPinitChallenger    CommandName = "challenger"
 	Relayer             CommandName = "relayer"
+	WatchBalances       CommandName = "watch_balances"
 )
 
+// CommandNames are all the services weave manages
+var CommandNames = []CommandName{UpgradableInitia, NonUpgradableInitia, Minitia, OPinitExecutor, OPinitChallenger, Relayer, WatchBalances}
+
 func (cmd CommandName) GetBinaryName() (string, error) {
 	switch cmd {
 	case UpgradableInitia, NonUpgradableInitia:
@@ -23,6 +27,8 @@ func (cmd CommandName) GetBinaryName() (string, error) {
 		return "opinitd", nil
 	case Relayer:
 		return "hermes", nil
+	case WatchBalances:
+		return "weave", nil
 	default:
 		return "", fmt.Errorf("unsupported command: %v", cmd)
 	}
@@ -42,6 +48,8 @@ func (cmd CommandName) GetServiceSlug() (string, error) {
 		return "opinitd.challenger", nil
 	case Relayer:
 		return "hermes", nil
+	case WatchBalances:
+		return "weave.watch", nil
 	default:
 		return "", fmt.Errorf("unsupported command: %v", cmd)
 	}
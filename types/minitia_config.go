@@ -1,5 +1,10 @@
 package types
 
+import "fmt"
+
+// RedactedMnemonic is the placeholder written in place of a mnemonic that must not be shown or stored
+const RedactedMnemonic = "<redacted>"
+
 type MinitiaConfig struct {
 	L1Config        *L1Config        `json:"l1_config,omitempty"`
 	L2Config        *L2Config        `json:"l2_config,omitempty"`
@@ -136,6 +141,67 @@ func (m *MinitiaConfig) Clone() *MinitiaConfig {
 	return clone
 }
 
+// Redacted returns a deep copy of MinitiaConfig with every system key mnemonic replaced by RedactedMnemonic.
+func (m *MinitiaConfig) Redacted() *MinitiaConfig {
+	clone := m.Clone()
+	if clone == nil || clone.SystemKeys == nil {
+		return clone
+	}
+
+	for _, acc := range clone.SystemKeys.Accounts() {
+		if acc.Mnemonic != "" {
+			acc.Mnemonic = RedactedMnemonic
+		}
+	}
+	return clone
+}
+
+// Accounts returns the system accounts keyed by their JSON field names. Missing accounts are left out.
+func (k *SystemKeys) Accounts() map[string]*SystemAccount {
+	accounts := make(map[string]*SystemAccount)
+	if k == nil {
+		return accounts
+	}
+	for name, acc := range map[string]*SystemAccount{
+		"validator":        k.Validator,
+		"bridge_executor":  k.BridgeExecutor,
+		"output_submitter": k.OutputSubmitter,
+		"batch_submitter":  k.BatchSubmitter,
+		"challenger":       k.Challenger,
+	} {
+		if acc != nil {
+			accounts[name] = acc
+		}
+	}
+	return accounts
+}
+
+// Mnemonics returns every non-redacted mnemonic keyed by the JSON field name of its system account
+func (k *SystemKeys) Mnemonics() map[string]string {
+	mnemonics := make(map[string]string)
+	for name, acc := range k.Accounts() {
+		if acc.Mnemonic != "" && acc.Mnemonic != RedactedMnemonic {
+			mnemonics[name] = acc.Mnemonic
+		}
+	}
+	return mnemonics
+}
+
+// RestoreMnemonics fills in the redacted or empty mnemonics from a map produced by Mnemonics
+func (k *SystemKeys) RestoreMnemonics(mnemonics map[string]string) error {
+	for name, acc := range k.Accounts() {
+		if acc.Mnemonic != "" && acc.Mnemonic != RedactedMnemonic {
+			continue
+		}
+		mnemonic, ok := mnemonics[name]
+		if !ok {
+			return fmt.Errorf("no mnemonic found for %s", name)
+		}
+		acc.Mnemonic = mnemonic
+	}
+	return nil
+}
+
 func cloneSystemAccount(acc *SystemAccount) *SystemAccount {
 	if acc == nil {
 		return nil
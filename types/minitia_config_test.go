@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/initia-labs/weave/io"
 )
 
 // Test the creation of a new SystemAccount using NewSystemAccount function
@@ -42,3 +44,53 @@ func TestGenesisAccounts(t *testing.T) {
 	assert.Equal(t, "address2", accounts[1].Address, "Expected second account address to be 'address2'")
 	assert.Equal(t, "200coins", accounts[1].Coins, "Expected second account coins to be '200coins'")
 }
+
+func TestMinitiaConfigRedacted(t *testing.T) {
+	config := &MinitiaConfig{
+		L2Config: &L2Config{ChainID: "minitia-1"},
+		SystemKeys: &SystemKeys{
+			Validator:      NewSystemAccount("validator mnemonic", "init1validator"),
+			BatchSubmitter: NewBatchSubmitterAccount("batch mnemonic", "celestia1batch"),
+			Challenger:     &SystemAccount{L1Address: "init1challenger"},
+		},
+	}
+
+	redacted := config.Redacted()
+	assert.Equal(t, RedactedMnemonic, redacted.SystemKeys.Validator.Mnemonic)
+	assert.Equal(t, RedactedMnemonic, redacted.SystemKeys.BatchSubmitter.Mnemonic)
+	assert.Equal(t, "", redacted.SystemKeys.Challenger.Mnemonic)
+	assert.Equal(t, "init1validator", redacted.SystemKeys.Validator.L1Address)
+	assert.Equal(t, "minitia-1", redacted.L2Config.ChainID)
+
+	// the original config keeps its mnemonics
+	assert.Equal(t, "validator mnemonic", config.SystemKeys.Validator.Mnemonic)
+	assert.Nil(t, (*MinitiaConfig)(nil).Redacted())
+}
+
+func TestMinitiaConfigFormatRoundTrip(t *testing.T) {
+	config := &MinitiaConfig{
+		L1Config: &L1Config{ChainID: "initiation-2", RpcUrl: "https://rpc.testnet.initia.xyz:443", GasPrices: "0.15uinit"},
+		L2Config: &L2Config{ChainID: "minimove-1", Denom: "umin", Moniker: "operator", BridgeID: 42},
+		OpBridge: &OpBridge{
+			OutputSubmissionInterval:    "1m",
+			OutputFinalizationPeriod:    "168h",
+			OutputSubmissionStartHeight: 1,
+			BatchSubmissionTarget:       "CELESTIA",
+			EnableOracle:                true,
+		},
+		SystemKeys: &SystemKeys{
+			Validator:      NewSystemAccount("validator mnemonic", "init1validator"),
+			BatchSubmitter: NewBatchSubmitterAccount("batch mnemonic", "celestia1batch"),
+		},
+		GenesisAccounts: &GenesisAccounts{{Address: "init1validator", Coins: "100umin"}},
+	}
+
+	for _, format := range []io.ConfigFormat{io.ConfigFormatJSON, io.ConfigFormatYAML, io.ConfigFormatTOML} {
+		data, err := io.MarshalConfig(config, format)
+		assert.NoError(t, err)
+
+		var decoded MinitiaConfig
+		assert.NoError(t, io.UnmarshalConfig(data, format, &decoded), format)
+		assert.Equal(t, config, &decoded, format)
+	}
+}
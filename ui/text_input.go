@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"strings"
 	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,6 +25,7 @@ type TextInput struct {
 	ToggleTooltip bool
 	Tooltip       *Tooltip
 	TooltipWidth  int
+	Hidden        bool
 }
 
 func NewTextInput(cannotBack bool) TextInput {
@@ -57,6 +59,14 @@ func (ti *TextInput) WithPrefillValue(value string) {
 	ti.Cursor = len(ti.Text)
 }
 
+// HiddenTextMask stands for a hidden input once it is submitted, whatever its length
+const HiddenTextMask = "********"
+
+// WithHidden masks the typed text, e.g. for passphrases
+func (ti *TextInput) WithHidden() {
+	ti.Hidden = true
+}
+
 func (ti *TextInput) WithTooltip(t *Tooltip) {
 	ti.Tooltip = t
 }
@@ -164,6 +174,10 @@ func moveToNextWord(text string, cursor int) int {
 func (ti TextInput) View() string {
 	var beforeCursor, cursorChar, afterCursor, footerText string
 
+	if ti.Hidden {
+		ti.Text = strings.Repeat("*", len(ti.Text))
+	}
+
 	if ti.CannotBack {
 		footerText = styles.RenderFooter("Enter to submit, or Ctrl+c to quit.")
 	} else {